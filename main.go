@@ -1,11 +1,22 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"github.com/vmware/terraform-provider-vcd/v3/vcd"
 )
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: vcd.Provider})
+	// The provider server combines the SDK v2 provider with the Plugin Framework one
+	serverFactory, err := vcd.NewMuxProviderServer(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = tf5server.Serve("registry.terraform.io/vmware/vcd", serverFactory)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,190 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// Delay before the first retry of a throttled request, when the response has no Retry-After header
+	throttlingInitialBackoff = 1 * time.Second
+	// Maximum delay between retries of a throttled request
+	throttlingMaxBackoff = 30 * time.Second
+)
+
+// requestRateLimiter spreads the API requests so that no more than a given number is sent every second
+type requestRateLimiter struct {
+	interval time.Duration
+	next     time.Time
+	sync.Mutex
+}
+
+func newRequestRateLimiter(requestsPerSecond int) *requestRateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &requestRateLimiter{interval: time.Second / time.Duration(requestsPerSecond)}
+}
+
+// wait blocks until the next request is allowed, or the context is done
+func (l *requestRateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	l.Unlock()
+
+	return sleepWithContext(ctx, time.Until(start))
+}
+
+// throttledTransport is an HTTP transport that applies the provider rate limit to every API request, and retries
+// the requests rejected by VCD because of overload (429 and 503), honouring the Retry-After header
+type throttledTransport struct {
+	transport http.RoundTripper
+	limiter   *requestRateLimiter
+	// maxRetryTime is the longest time spent retrying a throttled request
+	maxRetryTime time.Duration
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	deadline := time.Now().Add(t.maxRetryTime)
+	for attempt := 0; ; attempt++ {
+		err := t.limiter.wait(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		resp, err := t.transport.RoundTrip(req)
+		if err != nil || !isThrottlingResponse(resp) {
+			return resp, err
+		}
+
+		delay := throttlingRetryDelay(resp, attempt)
+		// A request whose body can't be read again is not retried
+		if time.Now().Add(delay).After(deadline) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			return resp, nil
+		}
+		debugPrintf("[throttling] %s %s returned %d. Retrying in %s\n", req.Method, req.URL, resp.StatusCode, delay)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		err = sleepWithContext(req.Context(), delay)
+		if err != nil {
+			return nil, err
+		}
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			retry.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+		req = retry
+	}
+}
+
+// isThrottlingResponse returns true for the responses that VCD sends when it can't handle more requests
+func isThrottlingResponse(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+}
+
+// throttlingRetryDelay returns the delay requested by the Retry-After header of the response (either in seconds
+// or as an HTTP date) or, when missing, an exponential backoff based on the number of previous attempts
+func throttlingRetryDelay(resp *http.Response, attempt int) time.Duration {
+	retryAfter := resp.Header.Get("Retry-After")
+	if retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			if delay := time.Until(date); delay > 0 {
+				return delay
+			}
+			return 0
+		}
+	}
+	delay := throttlingInitialBackoff
+	for i := 0; i < attempt && delay < throttlingMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > throttlingMaxBackoff {
+		delay = throttlingMaxBackoff
+	}
+	return delay
+}
+
+// sleepWithContext waits for the given time, or until the context is done
+func sleepWithContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// acquireOperationSlot waits until the number of running resource create, update and delete operations is below the
+// provider 'max_concurrent_operations'. It returns the function that releases the slot
+func (cli *VCDClient) acquireOperationSlot(ctx context.Context) (func(), error) {
+	if cli.operationSlots == nil {
+		return func() {}, nil
+	}
+	select {
+	case cli.operationSlots <- struct{}{}:
+		return func() { <-cli.operationSlots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out waiting for one of the other %d running operations to complete ('max_concurrent_operations'): %s", cap(cli.operationSlots), ctx.Err())
+	}
+}
+
+// limitConcurrentOperations returns a copy of the given resources, whose create, update and delete operations
+// respect the provider 'max_concurrent_operations'. Reads are not limited
+func limitConcurrentOperations(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	limitedResources := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		limitedResource := *resource
+		if resource.CreateContext != nil {
+			limitedResource.CreateContext = schema.CreateContextFunc(withOperationSlot(resource.CreateContext))
+		}
+		if resource.UpdateContext != nil {
+			limitedResource.UpdateContext = schema.UpdateContextFunc(withOperationSlot(resource.UpdateContext))
+		}
+		if resource.DeleteContext != nil {
+			limitedResource.DeleteContext = schema.DeleteContextFunc(withOperationSlot(resource.DeleteContext))
+		}
+		limitedResources[name] = &limitedResource
+	}
+	return limitedResources
+}
+
+func withOperationSlot(operation func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		vcdClient, ok := meta.(*VCDClient)
+		if ok {
+			release, err := vcdClient.acquireOperationSlot(ctx)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			defer release()
+		}
+		return operation(ctx, d, meta)
+	}
+}
@@ -0,0 +1,134 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_throttlingRetryDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		attempt    int
+		want       time.Duration
+	}{
+		{name: "seconds", retryAfter: "3", want: 3 * time.Second},
+		{name: "past date", retryAfter: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0},
+		{name: "no header, first attempt", want: throttlingInitialBackoff},
+		{name: "no header, third attempt", attempt: 2, want: 4 * throttlingInitialBackoff},
+		{name: "no header, many attempts", attempt: 20, want: throttlingMaxBackoff},
+		{name: "invalid header", retryAfter: "soon", attempt: 1, want: 2 * throttlingInitialBackoff},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+			got := throttlingRetryDelay(resp, tt.attempt)
+			if got != tt.want {
+				t.Errorf("throttlingRetryDelay() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_throttledTransport(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := http.Client{Transport: &throttledTransport{
+		transport:    http.DefaultTransport,
+		maxRetryTime: time.Minute,
+	}}
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("error creating request: %s", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200 after retries, got %d", resp.StatusCode)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+
+	// Without retry time, the throttled response is returned
+	atomic.StoreInt32(&calls, 0)
+	client.Transport = &throttledTransport{transport: http.DefaultTransport}
+	req, _ = http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 without retries, got %d", resp.StatusCode)
+	}
+}
+
+func Test_requestRateLimiter(t *testing.T) {
+	limiter := newRequestRateLimiter(50)
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		err := limiter.wait(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	// The first request is immediate, the other 5 are spaced by 20ms
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected requests to be spread over at least 100ms, took %s", elapsed)
+	}
+
+	if newRequestRateLimiter(0) != nil {
+		t.Errorf("expected no limiter when the rate is 0")
+	}
+}
+
+func Test_acquireOperationSlot(t *testing.T) {
+	cli := &VCDClient{operationSlots: make(chan struct{}, 1)}
+	release, err := cli.acquireOperationSlot(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = cli.acquireOperationSlot(ctx)
+	if err == nil {
+		t.Errorf("expected error when all the slots are taken")
+	}
+
+	release()
+	release, err = cli.acquireOperationSlot(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error after release: %s", err)
+	}
+	release()
+}
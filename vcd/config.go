@@ -1,17 +1,23 @@
 package vcd
 
 import (
+	"context"
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 	"github.com/vmware/go-vcloud-director/v2/util"
@@ -39,6 +45,14 @@ type Config struct {
 	Href                    string
 	MaxRetryTimeout         int
 	InsecureFlag            bool
+	CaFile                  string // File containing additional CA certificates to verify the VCD endpoint
+	CaPem                   string // PEM-encoded additional CA certificates to verify the VCD endpoint
+	ClientCert              string // Client certificate (PEM-encoded or file path) for mutual TLS
+	ClientKey               string // Client certificate key (PEM-encoded or file path) for mutual TLS
+	ProxyUrl                string // Proxy used to reach the VCD endpoint. When empty, the proxy comes from the environment
+	MaxRequestsPerSecond    int    // Maximum number of API requests sent every second. 0 means unlimited
+	MaxConcurrentOperations int    // Maximum number of resource create, update and delete operations running at the same time. 0 means unlimited
+	PreflightRightsCheck    bool   // Whether plans fail when the session lacks the rights needed by the planned resources
 
 	// UseSamlAdfs specifies if SAML auth is used for authenticating VCD instead of local login.
 	// The following conditions must be met so that authentication SAML authentication works:
@@ -53,6 +67,10 @@ type Config struct {
 	// IgnoredMetadata allows to configure a set of metadata entries that should be ignored by all the
 	// API operations related to metadata.
 	IgnoredMetadata []govcd.IgnoredMetadata
+
+	// ReadCacheTTL enables the cache of parent objects (Orgs, VDCs and NSX-T Edge Gateways) retrieved
+	// by the provider, for the given duration. The cache is disabled when this value is 0
+	ReadCacheTTL time.Duration
 }
 
 type VCDClient struct {
@@ -62,6 +80,15 @@ type VCDClient struct {
 	Vdc             string // name of default VDC
 	MaxRetryTimeout int
 	InsecureFlag    bool
+
+	// readCache holds the parent objects retrieved during this run. It is nil when the cache is disabled
+	readCache *objectCache
+	// operationSlots limits the resource operations running at the same time. It is nil when unlimited
+	operationSlots chan struct{}
+	// preflightRightsCheck makes the plan of a resource fail when the session lacks the rights to manage it
+	preflightRightsCheck bool
+	// sessionRights holds the rights of the authenticated principal, loaded at first use
+	sessionRights *sessionRightsCache
 }
 
 // StringMap type is used to simplify reading resource definitions
@@ -116,6 +143,220 @@ func (c *cacheStorage) reset() {
 	c.conMap = make(map[string]cachedConnection)
 }
 
+// objectCache holds the parent objects (Orgs, VDCs, NSX-T Edge Gateways) that are retrieved over and over
+// while reading resources, so that refreshing a big state doesn't need to query them for each resource.
+// Keys follow the format of the lock keys ("org:ORG", "org:ORG|vdc:VDC") or are the ID of the object, so that
+// the lock helpers can invalidate the objects that are about to change.
+//
+// WARNING: callers receive a copy of the cached object, but the inner structures are shared. Objects retrieved
+// with the cache should not be modified in place.
+// Reference lists embedded in the cached objects (such as the users of an Admin Org or the resource entities of a
+// VDC) change whenever a child entity is created or deleted. For this reason, the cache is bypassed and emptied
+// while any resource is being created, updated or deleted (see trackReadCacheWrites): it is only effective when
+// refreshing resources.
+type objectCache struct {
+	ttl     time.Duration
+	objects map[string]cachedObject
+	// writes is the number of resource create, update and delete operations in progress
+	writes int
+	// generation changes whenever objects are discarded, so that an object retrieved before that is not stored
+	generation uint64
+	// hits and misses record how many times the cache was used. They are only used for debugging
+	hits   int
+	misses int
+	sync.Mutex
+}
+
+type cachedObject struct {
+	expiration time.Time
+	object     interface{}
+}
+
+func newObjectCache(ttl time.Duration) *objectCache {
+	return &objectCache{ttl: ttl, objects: make(map[string]cachedObject)}
+}
+
+// get returns the object stored with the given key, if it exists and hasn't expired, and no resource is being written
+func (c *objectCache) get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.Lock()
+	defer c.Unlock()
+	if c.writes > 0 {
+		c.misses++
+		return nil, false
+	}
+	cached, ok := c.objects[key]
+	if ok && time.Now().After(cached.expiration) {
+		delete(c.objects, key)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	return cached.object, true
+}
+
+// currentGeneration returns the generation to pass to set for an object that is about to be retrieved
+func (c *objectCache) currentGeneration() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.Lock()
+	defer c.Unlock()
+	return c.generation
+}
+
+// set stores the given object, which will expire after the cache TTL. The object is not stored when a resource is
+// being written, or when objects were discarded after the given generation, as it could have been retrieved before
+// the change that caused it
+func (c *objectCache) set(key string, object interface{}, generation uint64) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	if c.writes > 0 || generation != c.generation {
+		return
+	}
+	c.objects[key] = cachedObject{expiration: time.Now().Add(c.ttl), object: object}
+}
+
+// beginWrite empties the cache and bypasses it until the matching endWrite
+func (c *objectCache) beginWrite() {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.writes++
+	c.generation++
+	c.objects = make(map[string]cachedObject)
+}
+
+// endWrite empties the cache, which is used again when no other write is in progress
+func (c *objectCache) endWrite() {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.writes--
+	c.generation++
+	c.objects = make(map[string]cachedObject)
+}
+
+// invalidate removes the objects affected by a change of the entity identified by the given lock key.
+// These are the objects whose key is the lock key or one of its ancestors (for "org:ORG|vdc:VDC|vapp:VAPP",
+// the VDC and the Org), the Admin Org of any Org key, as its lists of users, groups and VDCs may change
+// with any entity of the Org, and the objects stored with an ID key that the lock key refers to
+func (c *objectCache) invalidate(lockKey string) {
+	if c == nil || lockKey == "" {
+		return
+	}
+	adminOrgKey := ""
+	if strings.HasPrefix(lockKey, "org:") {
+		adminOrgKey = "admin_" + strings.SplitN(lockKey, "|", 2)[0]
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.generation++
+	for key := range c.objects {
+		if key == lockKey || key == adminOrgKey || strings.HasPrefix(lockKey, key+"|") || strings.HasSuffix(key, ":"+lockKey) {
+			delete(c.objects, key)
+		}
+	}
+}
+
+// invalidateOrg removes from the read cache the Org with the given name, so that the next retrieval gets its
+// current lists of users, groups and VDCs. Resources that change those lists without holding an Org lock call it
+func (cli *VCDClient) invalidateOrg(orgName string) {
+	cli.readCache.invalidate("org:" + orgName)
+}
+
+// reset removes all the cached objects
+func (c *objectCache) reset() {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	debugPrintf("[read cache] reset after %d hits and %d misses\n", c.hits, c.misses)
+	c.generation++
+	c.objects = make(map[string]cachedObject)
+}
+
+// trackReadCacheWrites returns a copy of the given resources, whose create, update and delete operations bypass
+// the read cache while they run. Any of them can change the parent objects (e.g. the networks of a VDC) or create
+// the entity that a following read looks up in them, regardless of the lock they hold
+func trackReadCacheWrites(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	trackedResources := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		trackedResource := *resource
+		if resource.CreateContext != nil {
+			trackedResource.CreateContext = schema.CreateContextFunc(withReadCacheWrite(resource.CreateContext))
+		}
+		if resource.UpdateContext != nil {
+			trackedResource.UpdateContext = schema.UpdateContextFunc(withReadCacheWrite(resource.UpdateContext))
+		}
+		if resource.DeleteContext != nil {
+			trackedResource.DeleteContext = schema.DeleteContextFunc(withReadCacheWrite(resource.DeleteContext))
+		}
+		trackedResources[name] = &trackedResource
+	}
+	return trackedResources
+}
+
+func withReadCacheWrite(operation func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if vcdClient, ok := meta.(*VCDClient); ok {
+			vcdClient.readCache.beginWrite()
+			defer vcdClient.readCache.endWrite()
+		}
+		return operation(ctx, d, meta)
+	}
+}
+
+// cachedOrgAndVdc is the object cached by GetOrgAndVdc
+type cachedOrgAndVdc struct {
+	org *govcd.Org
+	vdc *govcd.Vdc
+}
+
+// copyOrg, copyAdminOrg, copyVdc and copyNsxtEdgeGateway return a copy of the given object that
+// doesn't share its main structure, so that a caller updating its fields doesn't alter the cached one
+
+func copyOrg(org *govcd.Org) *govcd.Org {
+	orgCopy := *org
+	orgType := *org.Org
+	orgCopy.Org = &orgType
+	return &orgCopy
+}
+
+func copyAdminOrg(adminOrg *govcd.AdminOrg) *govcd.AdminOrg {
+	adminOrgCopy := *adminOrg
+	adminOrgType := *adminOrg.AdminOrg
+	adminOrgCopy.AdminOrg = &adminOrgType
+	return &adminOrgCopy
+}
+
+func copyVdc(vdc *govcd.Vdc) *govcd.Vdc {
+	vdcCopy := *vdc
+	vdcType := *vdc.Vdc
+	vdcCopy.Vdc = &vdcType
+	return &vdcCopy
+}
+
+func copyNsxtEdgeGateway(egw *govcd.NsxtEdgeGateway) *govcd.NsxtEdgeGateway {
+	egwCopy := *egw
+	egwType := *egw.EdgeGateway
+	egwCopy.EdgeGateway = &egwType
+	return &egwCopy
+}
+
 var (
 	// Enables the caching of authenticated connections
 	enableConnectionCache = os.Getenv("VCD_CACHE") != ""
@@ -150,13 +391,25 @@ func debugPrintf(format string, args ...interface{}) {
 // This is a global mutexKV for all resources
 var vcdMutexKV = newMutexKV()
 
+// lockKey locks the given key and removes from the read cache the objects that are about to change
+func (cli *VCDClient) lockKey(key string) {
+	vcdMutexKV.kvLock(key)
+	cli.readCache.invalidate(key)
+}
+
+// unlockKey removes from the read cache the objects that were changed while holding the lock, and unlocks the given key
+func (cli *VCDClient) unlockKey(key string) {
+	cli.readCache.invalidate(key)
+	vcdMutexKV.kvUnlock(key)
+}
+
 func (cli *VCDClient) lockVapp(d *schema.ResourceData) {
 	vappName := d.Get("name").(string)
 	if vappName == "" {
 		panic("vApp name not found")
 	}
 	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
-	vcdMutexKV.kvLock(key)
+	cli.lockKey(key)
 }
 
 func (cli *VCDClient) unLockVapp(d *schema.ResourceData) {
@@ -165,7 +418,7 @@ func (cli *VCDClient) unLockVapp(d *schema.ResourceData) {
 		panic("vApp name not found")
 	}
 	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
-	vcdMutexKV.kvUnlock(key)
+	cli.unlockKey(key)
 }
 
 // lockEdgeGateway locks an edge gateway resource
@@ -176,7 +429,7 @@ func (cli *VCDClient) lockEdgeGateway(d *schema.ResourceData) {
 		panic("edge gateway ID not found")
 	}
 
-	vcdMutexKV.kvLock(edgeGatewayId)
+	cli.lockKey(edgeGatewayId)
 }
 
 // unlockEdgeGateway unlocks an Edge Gateway resource
@@ -187,7 +440,7 @@ func (cli *VCDClient) unlockEdgeGateway(d *schema.ResourceData) {
 		panic("edge gateway ID not found")
 	}
 
-	vcdMutexKV.kvUnlock(edgeGatewayId)
+	cli.unlockKey(edgeGatewayId)
 }
 
 // lockParentVappWithName locks using provided vappName.
@@ -197,7 +450,7 @@ func (cli *VCDClient) lockParentVappWithName(d *schema.ResourceData, vappName st
 		panic("vApp name not found")
 	}
 	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
-	vcdMutexKV.kvLock(key)
+	cli.lockKey(key)
 }
 
 func (cli *VCDClient) unLockParentVappWithName(d *schema.ResourceData, vappName string) {
@@ -205,7 +458,7 @@ func (cli *VCDClient) unLockParentVappWithName(d *schema.ResourceData, vappName
 		panic("vApp name not found")
 	}
 	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
-	vcdMutexKV.kvUnlock(key)
+	cli.unlockKey(key)
 }
 
 // function lockParentVapp locks using vapp_name name existing in resource parameters.
@@ -216,7 +469,7 @@ func (cli *VCDClient) lockParentVapp(d *schema.ResourceData) {
 		panic("vApp name not found")
 	}
 	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
-	vcdMutexKV.kvLock(key)
+	cli.lockKey(key)
 }
 
 func (cli *VCDClient) unLockParentVapp(d *schema.ResourceData) {
@@ -225,7 +478,7 @@ func (cli *VCDClient) unLockParentVapp(d *schema.ResourceData) {
 		panic("vApp name not found")
 	}
 	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
-	vcdMutexKV.kvUnlock(key)
+	cli.unlockKey(key)
 }
 
 func (cli *VCDClient) lockVappWithName(org, vdc, vappName string) func() {
@@ -233,17 +486,15 @@ func (cli *VCDClient) lockVappWithName(org, vdc, vappName string) func() {
 		panic("vApp name not found")
 	}
 	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", org, vdc, vappName)
-	vcdMutexKV.kvLock(key)
+	cli.lockKey(key)
 
 	return func() {
-		vcdMutexKV.kvUnlock(key)
+		cli.unlockKey(key)
 	}
 }
 
 // lockParentVm locks using vapp_name and vm_name names existing in resource parameters.
 // Parent means the resource belongs to the VM being locked
-//
-//lint:ignore U1000 For future use
 func (cli *VCDClient) lockParentVm(d *schema.ResourceData) {
 	vappName := d.Get("vapp_name").(string)
 	if vappName == "" {
@@ -254,10 +505,9 @@ func (cli *VCDClient) lockParentVm(d *schema.ResourceData) {
 		panic("vmName name not found")
 	}
 	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s|vm:%s", cli.getOrgName(d), cli.getVdcName(d), vappName, vmName)
-	vcdMutexKV.kvLock(key)
+	cli.lockKey(key)
 }
 
-//lint:ignore U1000 For future use
 func (cli *VCDClient) unLockParentVm(d *schema.ResourceData) {
 	vappName := d.Get("vapp_name").(string)
 	if vappName == "" {
@@ -268,17 +518,17 @@ func (cli *VCDClient) unLockParentVm(d *schema.ResourceData) {
 		panic("vmName name not found")
 	}
 	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s|vm:%s", cli.getOrgName(d), cli.getVdcName(d), vappName, vmName)
-	vcdMutexKV.kvUnlock(key)
+	cli.unlockKey(key)
 }
 
 // lockById locks on supplied ID field
 func (cli *VCDClient) lockById(id string) {
-	vcdMutexKV.kvLock(id)
+	cli.lockKey(id)
 }
 
 // unlockById unlocks on supplied ID field
 func (cli *VCDClient) unlockById(id string) {
-	vcdMutexKV.kvUnlock(id)
+	cli.unlockKey(id)
 }
 
 // lockParentVdcGroup locks on VDC Group ID using 'vdc_group_id' field
@@ -288,7 +538,7 @@ func (cli *VCDClient) lockParentVdcGroup(d *schema.ResourceData) {
 		panic("'vdc_group_id' is empty")
 	}
 
-	vcdMutexKV.kvLock(vdcGroupId)
+	cli.lockKey(vdcGroupId)
 }
 
 // unlockParentVdcGroup unlocks on VDC Group ID using 'vdc_group_id' field
@@ -298,7 +548,7 @@ func (cli *VCDClient) unlockParentVdcGroup(d *schema.ResourceData) {
 		panic("'vdc_group_id' is empty")
 	}
 
-	vcdMutexKV.kvUnlock(vdcGroupId)
+	cli.unlockKey(vdcGroupId)
 }
 
 // lockParentExternalNetwork locks on External Network using 'external_network_id' field
@@ -308,7 +558,7 @@ func (cli *VCDClient) lockParentExternalNetwork(d *schema.ResourceData) {
 		panic("'external_network_id' is empty")
 	}
 
-	vcdMutexKV.kvLock(externalNetworkId)
+	cli.lockKey(externalNetworkId)
 }
 
 // unlockParentVdcGroup unlocks on External Network using 'external_network_id' field
@@ -318,7 +568,7 @@ func (cli *VCDClient) unlockParentExternalNetwork(d *schema.ResourceData) {
 		panic("'external_network_id' is empty")
 	}
 
-	vcdMutexKV.kvUnlock(externalNetworkId)
+	cli.unlockKey(externalNetworkId)
 }
 
 // lockIfOwnerIsVdcGroup locks VDC Group based on `owner_id` field (if it is a VDC Group)
@@ -326,7 +576,7 @@ func (cli *VCDClient) lockIfOwnerIsVdcGroup(d *schema.ResourceData) {
 	vdcGroupId := d.Get("owner_id")
 	vdcGroupIdValue := vdcGroupId.(string)
 	if govcd.OwnerIsVdcGroup(vdcGroupIdValue) {
-		vcdMutexKV.kvLock(vdcGroupIdValue)
+		cli.lockKey(vdcGroupIdValue)
 	}
 }
 
@@ -335,7 +585,7 @@ func (cli *VCDClient) unLockIfOwnerIsVdcGroup(d *schema.ResourceData) {
 	vdcGroupId := d.Get("owner_id")
 	vdcGroupIdValue := vdcGroupId.(string)
 	if govcd.OwnerIsVdcGroup(vdcGroupIdValue) {
-		vcdMutexKV.kvUnlock(vdcGroupIdValue)
+		cli.unlockKey(vdcGroupIdValue)
 	}
 }
 
@@ -374,7 +624,7 @@ func (cli *VCDClient) lockParentEdgeGtw(d *schema.ResourceData) {
 		panic("edge gateway ID not found")
 	}
 
-	vcdMutexKV.kvLock(edgeGtwIdValue)
+	cli.lockKey(edgeGtwIdValue)
 }
 
 func (cli *VCDClient) unLockParentEdgeGtw(d *schema.ResourceData) {
@@ -410,7 +660,7 @@ func (cli *VCDClient) unLockParentEdgeGtw(d *schema.ResourceData) {
 		panic("edge gateway ID not found")
 	}
 
-	vcdMutexKV.kvUnlock(edgeGtwIdValue)
+	cli.unlockKey(edgeGtwIdValue)
 }
 
 // lockParentVdcGroupOrEdgeGateway handles lock of parent Edge Gateway or parent VDC group, depending
@@ -443,12 +693,12 @@ func (cli *VCDClient) lockParentVdcGroupOrEdgeGateway(d *schema.ResourceData) (f
 
 func (cli *VCDClient) lockParentOrgNetwork(d *schema.ResourceData) {
 	orgNetworkId := d.Get("org_network_id").(string)
-	vcdMutexKV.kvLock(orgNetworkId)
+	cli.lockKey(orgNetworkId)
 }
 
 func (cli *VCDClient) unLockParentOrgNetwork(d *schema.ResourceData) {
 	orgNetworkId := d.Get("org_network_id").(string)
-	vcdMutexKV.kvUnlock(orgNetworkId)
+	cli.unlockKey(orgNetworkId)
 }
 
 func (cli *VCDClient) getOrgName(d *schema.ResourceData) string {
@@ -484,6 +734,12 @@ func (cli *VCDClient) GetOrgAndVdc(orgName, vdcName string) (org *govcd.Org, vdc
 	if vdcName == "" {
 		return nil, nil, fmt.Errorf("empty VDC name provided")
 	}
+	cacheKey := fmt.Sprintf("org:%s|vdc:%s", orgName, vdcName)
+	if cached, ok := cli.readCache.get(cacheKey); ok {
+		orgAndVdc := cached.(cachedOrgAndVdc)
+		return copyOrg(orgAndVdc.org), copyVdc(orgAndVdc.vdc), nil
+	}
+	generation := cli.readCache.currentGeneration()
 	org, err = cli.VCDClient.GetOrgByName(orgName)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error retrieving Org %s: %s", orgName, err)
@@ -498,6 +754,7 @@ func (cli *VCDClient) GetOrgAndVdc(orgName, vdcName string) (org *govcd.Org, vdc
 	if vdc == nil || vdc.Vdc.ID == "" || vdc.Vdc.HREF == "" || vdc.Vdc.Name == "" {
 		return nil, nil, fmt.Errorf("error retrieving VDC %s: not found", vdcName)
 	}
+	cli.readCache.set(cacheKey, cachedOrgAndVdc{org: copyOrg(org), vdc: copyVdc(vdc)}, generation)
 	return org, vdc, err
 }
 
@@ -513,6 +770,11 @@ func (cli *VCDClient) GetAdminOrg(orgName string) (org *govcd.AdminOrg, err erro
 		return nil, fmt.Errorf("empty Org name provided")
 	}
 
+	cacheKey := fmt.Sprintf("admin_org:%s", orgName)
+	if cached, ok := cli.readCache.get(cacheKey); ok {
+		return copyAdminOrg(cached.(*govcd.AdminOrg)), nil
+	}
+	generation := cli.readCache.currentGeneration()
 	org, err = cli.VCDClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving Org %s: %s", orgName, err)
@@ -520,6 +782,7 @@ func (cli *VCDClient) GetAdminOrg(orgName string) (org *govcd.AdminOrg, err erro
 	if org.AdminOrg.Name == "" || org.AdminOrg.HREF == "" || org.AdminOrg.ID == "" {
 		return nil, fmt.Errorf("empty org %s found", orgName)
 	}
+	cli.readCache.set(cacheKey, copyAdminOrg(org), generation)
 	return org, err
 }
 
@@ -535,6 +798,11 @@ func (cli *VCDClient) GetOrg(orgName string) (org *govcd.Org, err error) {
 		return nil, fmt.Errorf("empty Org name provided")
 	}
 
+	cacheKey := fmt.Sprintf("org:%s", orgName)
+	if cached, ok := cli.readCache.get(cacheKey); ok {
+		return copyOrg(cached.(*govcd.Org)), nil
+	}
+	generation := cli.readCache.currentGeneration()
 	org, err = cli.VCDClient.GetOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving Org %s: %s", orgName, err)
@@ -542,6 +810,7 @@ func (cli *VCDClient) GetOrg(orgName string) (org *govcd.Org, err error) {
 	if org.Org.Name == "" || org.Org.HREF == "" || org.Org.ID == "" {
 		return nil, fmt.Errorf("empty Org %s found", orgName)
 	}
+	cli.readCache.set(cacheKey, copyOrg(org), generation)
 	return org, err
 }
 
@@ -611,6 +880,11 @@ func (cli *VCDClient) GetNsxtEdgeGatewayById(orgName, edgeGwId string) (eg *govc
 	if edgeGwId == "" {
 		return nil, fmt.Errorf("empty NSX-T Edge Gateway ID provided")
 	}
+	cacheKey := fmt.Sprintf("nsxt_edge_gateway:%s", edgeGwId)
+	if cached, ok := cli.readCache.get(cacheKey); ok {
+		return copyNsxtEdgeGateway(cached.(*govcd.NsxtEdgeGateway)), nil
+	}
+	generation := cli.readCache.currentGeneration()
 
 	org, err := cli.GetOrg(orgName)
 	if err != nil {
@@ -624,6 +898,7 @@ func (cli *VCDClient) GetNsxtEdgeGatewayById(orgName, edgeGwId string) (eg *govc
 		}
 		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
+	cli.readCache.set(cacheKey, copyNsxtEdgeGateway(eg), generation)
 	return eg, nil
 }
 
@@ -718,7 +993,16 @@ func (c *Config) Client() (*VCDClient, error) {
 		c.ServiceAccountTokenFile + "#" +
 		c.SysOrg + "#" +
 		c.Vdc + "#" +
-		c.Href
+		c.Href + "#" +
+		c.CaFile + "#" +
+		c.CaPem + "#" +
+		c.ClientCert + "#" +
+		c.ClientKey + "#" +
+		c.ProxyUrl + "#" +
+		strconv.Itoa(c.MaxRequestsPerSecond) + "#" +
+		strconv.Itoa(c.MaxConcurrentOperations) + "#" +
+		strconv.FormatBool(c.PreflightRightsCheck) + "#" +
+		c.ReadCacheTTL.String()
 	checksum := fmt.Sprintf("%x", sha256.Sum256([]byte(rawData)))
 
 	// The cached connection is served only if the variable VCD_CACHE is set
@@ -748,18 +1032,34 @@ func (c *Config) Client() (*VCDClient, error) {
 
 	userAgent := buildUserAgent(BuildVersion, c.SysOrg)
 
+	transportOption, err := c.httpTransportOption()
+	if err != nil {
+		return nil, err
+	}
+
 	vcdClient := &VCDClient{
 		VCDClient: govcd.NewVCDClient(*authUrl, c.InsecureFlag,
 			govcd.WithMaxRetryTimeout(c.MaxRetryTimeout),
 			govcd.WithSamlAdfs(c.UseSamlAdfs, c.CustomAdfsRptId),
 			govcd.WithHttpUserAgent(userAgent),
 			govcd.WithIgnoredMetadata(c.IgnoredMetadata),
+			transportOption,
 		),
 		SysOrg:          c.SysOrg,
 		Org:             c.Org,
 		Vdc:             c.Vdc,
 		MaxRetryTimeout: c.MaxRetryTimeout,
-		InsecureFlag:    c.InsecureFlag}
+		InsecureFlag:    c.InsecureFlag,
+		// The session rights are loaded only when needed, such as by 'preflight_rights_check'
+		preflightRightsCheck: c.PreflightRightsCheck,
+		sessionRights:        &sessionRightsCache{},
+	}
+	if c.ReadCacheTTL > 0 {
+		vcdClient.readCache = newObjectCache(c.ReadCacheTTL)
+	}
+	if c.MaxConcurrentOperations > 0 {
+		vcdClient.operationSlots = make(chan struct{}, c.MaxConcurrentOperations)
+	}
 
 	err = ProviderAuthenticate(vcdClient.VCDClient, c.User, c.Password, c.Token, c.SysOrg, c.ApiToken, c.ApiTokenFile, c.ServiceAccountTokenFile)
 	if err != nil {
@@ -772,6 +1072,90 @@ func (c *Config) Client() (*VCDClient, error) {
 	return vcdClient, nil
 }
 
+// httpTransportOption returns a client option that applies the CA certificates, client certificate and proxy
+// settings of the configuration to the HTTP transport of the VCD client. When a rate limit is configured, the
+// transport is also wrapped to enforce it and retry the requests throttled by VCD
+func (c *Config) httpTransportOption() (govcd.VCDClientOption, error) {
+	var rootCAs *x509.CertPool
+	caPem := []byte(c.CaPem)
+	if c.CaFile != "" {
+		var err error
+		caPem, err = os.ReadFile(filepath.Clean(c.CaFile))
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA file '%s': %s", c.CaFile, err)
+		}
+	}
+	if len(caPem) > 0 {
+		var err error
+		rootCAs, err = x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caPem) {
+			return nil, fmt.Errorf("no valid PEM certificates found in the CA bundle")
+		}
+	}
+
+	var clientCertificates []tls.Certificate
+	if c.ClientCert != "" || c.ClientKey != "" {
+		certPem, err := pemOrFileContents(c.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client certificate: %s", err)
+		}
+		keyPem, err := pemOrFileContents(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client key: %s", err)
+		}
+		clientCertificate, err := tls.X509KeyPair(certPem, keyPem)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %s", err)
+		}
+		clientCertificates = append(clientCertificates, clientCertificate)
+	}
+
+	var proxy func(*http.Request) (*url.URL, error)
+	if c.ProxyUrl != "" {
+		proxyUrl, err := url.Parse(c.ProxyUrl)
+		if err != nil || proxyUrl.Scheme == "" || proxyUrl.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL '%s'", c.ProxyUrl)
+		}
+		proxy = http.ProxyURL(proxyUrl)
+	}
+
+	return func(vcdClient *govcd.VCDClient) error {
+		transport, ok := vcdClient.Client.Http.Transport.(*http.Transport)
+		if !ok {
+			return fmt.Errorf("unexpected HTTP transport type %T", vcdClient.Client.Http.Transport)
+		}
+		if rootCAs != nil {
+			transport.TLSClientConfig.RootCAs = rootCAs
+		}
+		if len(clientCertificates) > 0 {
+			transport.TLSClientConfig.Certificates = clientCertificates
+		}
+		if proxy != nil {
+			transport.Proxy = proxy
+		}
+		// Throttling is opt-in, as the retries it brings apply to every request, including the non-idempotent ones
+		if c.MaxRequestsPerSecond > 0 {
+			vcdClient.Client.Http.Transport = &throttledTransport{
+				transport:    transport,
+				limiter:      newRequestRateLimiter(c.MaxRequestsPerSecond),
+				maxRetryTime: time.Duration(vcdClient.Client.MaxRetryTimeout) * time.Second,
+			}
+		}
+		return nil
+	}, nil
+}
+
+// pemOrFileContents returns the given value if it is PEM-encoded data, or the contents of the file it points to
+func pemOrFileContents(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(filepath.Clean(value))
+}
+
 // callFuncName returns the name of the function that called the current function. It is used for
 // tracing
 func callFuncName() string {
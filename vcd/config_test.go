@@ -7,6 +7,7 @@ package vcd
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -26,6 +27,7 @@ import (
 
 	semver "github.com/hashicorp/go-version"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -947,6 +949,16 @@ func TestMain(m *testing.M) {
 				return testAccProvider, nil
 			},
 		}
+		// testAccMuxProviders serve the same provider, combined with the Plugin Framework one
+		testAccMuxProviders = map[string]func() (tfprotov5.ProviderServer, error){
+			"vcd": func() (tfprotov5.ProviderServer, error) {
+				serverFactory, err := muxProviderServer(context.Background(), testAccProvider)
+				if err != nil {
+					return nil, err
+				}
+				return serverFactory(), nil
+			},
+		}
 	}
 
 	// Runs all test functions
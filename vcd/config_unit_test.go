@@ -3,8 +3,21 @@
 package vcd
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
 )
 
 func Test_isScalar(t *testing.T) {
@@ -128,3 +141,189 @@ func Test_isScalar(t *testing.T) {
 		})
 	}
 }
+
+func Test_objectCache(t *testing.T) {
+	cache := newObjectCache(time.Minute)
+	cache.set("org:org1", "org1", cache.currentGeneration())
+	cache.set("admin_org:org1", "admin-org1", cache.currentGeneration())
+	cache.set("org:org1|vdc:vdc1", "vdc1", cache.currentGeneration())
+	cache.set("org:org1|vdc:vdc2", "vdc2", cache.currentGeneration())
+	cache.set("nsxt_edge_gateway:urn:vcloud:gateway:1234", "edge", cache.currentGeneration())
+
+	for _, key := range []string{"org:org1", "org:org1|vdc:vdc1", "nsxt_edge_gateway:urn:vcloud:gateway:1234"} {
+		if _, ok := cache.get(key); !ok {
+			t.Errorf("expected key %s to be cached", key)
+		}
+	}
+
+	// A lock on a vApp invalidates its VDC, its Org and its Admin Org, but not other VDCs
+	cache.invalidate("org:org1|vdc:vdc1|vapp:vapp1")
+	for key, wanted := range map[string]bool{
+		"org:org1":          false,
+		"admin_org:org1":    false,
+		"org:org1|vdc:vdc1": false,
+		"org:org1|vdc:vdc2": true,
+		"nsxt_edge_gateway:urn:vcloud:gateway:1234": true,
+	} {
+		if _, ok := cache.get(key); ok != wanted {
+			t.Errorf("key %s: expected cached=%t after vApp lock, got %t", key, wanted, ok)
+		}
+	}
+
+	// A lock on an Org invalidates its Admin Org, but not the ones of other Orgs
+	cache.set("admin_org:org1", "admin-org1", cache.currentGeneration())
+	cache.set("admin_org:org10", "admin-org10", cache.currentGeneration())
+	cache.invalidate("org:org1")
+	if _, ok := cache.get("admin_org:org1"); ok {
+		t.Errorf("expected Admin Org to be removed from the cache after Org lock")
+	}
+	if _, ok := cache.get("admin_org:org10"); !ok {
+		t.Errorf("expected Admin Org of another Org to stay in the cache after Org lock")
+	}
+
+	// A lock on an edge gateway ID invalidates the edge gateway
+	cache.invalidate("urn:vcloud:gateway:1234")
+	if _, ok := cache.get("nsxt_edge_gateway:urn:vcloud:gateway:1234"); ok {
+		t.Errorf("expected edge gateway to be removed from the cache")
+	}
+
+	cache.reset()
+	if _, ok := cache.get("org:org1|vdc:vdc2"); ok {
+		t.Errorf("expected empty cache after reset")
+	}
+
+	// Expired objects are not returned
+	expiringCache := newObjectCache(time.Millisecond)
+	expiringCache.set("org:org1", "org1", expiringCache.currentGeneration())
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := expiringCache.get("org:org1"); ok {
+		t.Errorf("expected expired object not to be returned")
+	}
+
+	// Objects retrieved before an invalidation are not stored
+	generation := cache.currentGeneration()
+	cache.invalidate("org:org2")
+	cache.set("org:org1", "org1", generation)
+	if _, ok := cache.get("org:org1"); ok {
+		t.Errorf("expected object retrieved before an invalidation not to be stored")
+	}
+
+	// The cache is bypassed while a resource is written, and emptied when the write starts and ends
+	cache.set("org:org1", "org1", cache.currentGeneration())
+	cache.beginWrite()
+	if _, ok := cache.get("org:org1"); ok {
+		t.Errorf("expected the cache to be bypassed during a write")
+	}
+	generation = cache.currentGeneration()
+	cache.set("org:org1|vdc:vdc1", "vdc1", generation)
+	cache.endWrite()
+	cache.set("org:org1|vdc:vdc2", "vdc2", generation)
+	for _, key := range []string{"org:org1", "org:org1|vdc:vdc1", "org:org1|vdc:vdc2"} {
+		if _, ok := cache.get(key); ok {
+			t.Errorf("key %s: expected object not to be cached after a write", key)
+		}
+	}
+	cache.set("org:org1", "org1", cache.currentGeneration())
+	if _, ok := cache.get("org:org1"); !ok {
+		t.Errorf("expected the cache to be used again after the write")
+	}
+
+	// A nil cache (disabled) never returns objects
+	var disabledCache *objectCache
+	disabledCache.set("org:org1", "org1", disabledCache.currentGeneration())
+	if _, ok := disabledCache.get("org:org1"); ok {
+		t.Errorf("expected disabled cache to be empty")
+	}
+}
+
+// testSelfSignedPem returns a PEM-encoded self-signed certificate and its key
+func testSelfSignedPem(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err)
+	}
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating certificate: %s", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("error encoding key: %s", err)
+	}
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDer})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return string(certPem), string(keyPem)
+}
+
+func Test_httpTransportOption(t *testing.T) {
+	certPem, keyPem := testSelfSignedPem(t)
+	certFile := filepath.Join(t.TempDir(), "cert.pem")
+	err := os.WriteFile(certFile, []byte(certPem), 0600)
+	if err != nil {
+		t.Fatalf("error writing certificate file: %s", err)
+	}
+
+	endpoint, _ := url.Parse("https://vcd.example.com/api")
+	tests := []struct {
+		name           string
+		config         Config
+		wantErr        bool
+		wantCAs        bool
+		wantCerts      int
+		wantProxy      string
+		wantThrottling bool
+	}{
+		{name: "defaults", config: Config{}},
+		{name: "rate limit", config: Config{MaxRequestsPerSecond: 10}, wantThrottling: true},
+		{name: "CA from file", config: Config{CaFile: certFile}, wantCAs: true},
+		{name: "CA as PEM", config: Config{CaPem: certPem}, wantCAs: true},
+		{name: "invalid CA", config: Config{CaPem: "not a certificate"}, wantErr: true},
+		{name: "missing CA file", config: Config{CaFile: certFile + ".missing"}, wantErr: true},
+		{name: "client certificate as PEM", config: Config{ClientCert: certPem, ClientKey: keyPem}, wantCerts: 1},
+		{name: "client certificate from file", config: Config{ClientCert: certFile, ClientKey: keyPem}, wantCerts: 1},
+		{name: "client certificate without key", config: Config{ClientCert: certPem}, wantErr: true},
+		{name: "proxy", config: Config{ProxyUrl: "http://proxy.example.com:3128"}, wantProxy: "http://proxy.example.com:3128"},
+		{name: "invalid proxy", config: Config{ProxyUrl: "proxy.example.com"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			option, err := tt.config.httpTransportOption()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("httpTransportOption() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			vcdClient := govcd.NewVCDClient(*endpoint, false, option)
+			httpTransport := vcdClient.Client.Http.Transport
+			throttled, isThrottled := httpTransport.(*throttledTransport)
+			if isThrottled != tt.wantThrottling {
+				t.Fatalf("expected throttled transport: %t", tt.wantThrottling)
+			}
+			if isThrottled {
+				httpTransport = throttled.transport
+			}
+			transport := httpTransport.(*http.Transport)
+			if (transport.TLSClientConfig.RootCAs != nil) != tt.wantCAs {
+				t.Errorf("expected custom CAs: %t", tt.wantCAs)
+			}
+			if len(transport.TLSClientConfig.Certificates) != tt.wantCerts {
+				t.Errorf("expected %d client certificates, got %d", tt.wantCerts, len(transport.TLSClientConfig.Certificates))
+			}
+			if tt.wantProxy != "" {
+				proxy, err := transport.Proxy(&http.Request{URL: endpoint})
+				if err != nil || proxy == nil || proxy.String() != tt.wantProxy {
+					t.Errorf("expected proxy %s, got %v (%v)", tt.wantProxy, proxy, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package vcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// This file contains plan-time validations which are plugged into resources using the
+// `CustomizeDiff` field. They catch cross-field and cross-resource inconsistencies during
+// `terraform plan`, so that an impossible configuration does not fail in the middle of an apply
+// after some changes have already been performed.
+//
+// All checks return a cty.PathError (built with planAttributeError) so that Terraform can point
+// the resulting diagnostic to the offending attribute. Checks must skip values that are unknown at
+// plan time, as they are only resolved during apply.
+
+// schemaGetter is satisfied by both *schema.ResourceData and *schema.ResourceDiff, so that lookup
+// helpers can be shared between CRUD operations and CustomizeDiff functions
+type schemaGetter interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+// planAttributeError returns an error which is reported by Terraform as a diagnostic attached to
+// the given top level attribute
+func planAttributeError(attribute, format string, args ...interface{}) error {
+	return cty.GetAttrPath(attribute).NewErrorf(format, args...)
+}
+
+// isAttributeConfigured checks whether the given top level attribute is explicitly set in HCL
+// configuration. Values that are not yet known (e.g. references to other resources) are considered
+// set.
+// Note. d.GetOk can't be used for Optional+Computed attributes, as it returns the value from state
+// when the field is not present in configuration.
+func isAttributeConfigured(d *schema.ResourceDiff, attribute string) bool {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.Type().HasAttribute(attribute) {
+		return false
+	}
+	return !rawConfig.GetAttr(attribute).IsNull()
+}
+
+// getConfiguredKnownInt returns the value of an integer attribute only when it is explicitly set in
+// configuration and its value is known at plan time
+func getConfiguredKnownInt(d *schema.ResourceDiff, attribute string) (int, bool) {
+	if !isAttributeConfigured(d, attribute) || !d.NewValueKnown(attribute) {
+		return 0, false
+	}
+	return d.Get(attribute).(int), true
+}
+
+// vmCustomizeDiff returns plan-time validations for `vcd_vapp_vm` and `vcd_vm`
+func vmCustomizeDiff(vmType typeOfVm) schema.CustomizeDiffFunc {
+	return customdiff.Sequence(
+		func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			if vmType == standaloneVmType && isAttributeConfigured(d, "vapp_name") {
+				return planAttributeError("vapp_name", "vApp name must not be set for a standalone VM (resource `%s`)", vmType)
+			}
+			return nil
+		},
+		validateVmCpuAndMemoryAtPlan,
+		validateVmSizingPolicyAtPlan,
+	)
+}
+
+// validateVmCpuAndMemoryAtPlan checks explicitly configured CPU and memory values for consistency
+func validateVmCpuAndMemoryAtPlan(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	cpus, cpusOk := getConfiguredKnownInt(d, "cpus")
+	cores, coresOk := getConfiguredKnownInt(d, "cpu_cores")
+	if cpusOk && coresOk {
+		if err := validateVmCpuTopology(cpus, cores); err != nil {
+			return err
+		}
+	}
+
+	memory, memoryOk := getConfiguredKnownInt(d, "memory")
+	memoryReservation, memoryReservationOk := getConfiguredKnownInt(d, "memory_reservation")
+	if memoryOk && memoryReservationOk && memoryReservation > memory {
+		return planAttributeError("memory_reservation", "memory reservation (%d MB) cannot be greater than memory (%d MB)",
+			memoryReservation, memory)
+	}
+
+	return nil
+}
+
+// validateVmSizingPolicyAtPlan retrieves the VM Sizing Policy set in `sizing_policy_id` and checks
+// that explicitly configured `cpus`, `cpu_cores` and `memory` do not conflict with it. The lookup is
+// only performed when any of the involved fields change, to avoid an API call on every plan.
+func validateVmSizingPolicyAtPlan(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok || vcdClient == nil {
+		return nil
+	}
+
+	if !d.NewValueKnown("sizing_policy_id") || !isAttributeConfigured(d, "sizing_policy_id") {
+		return nil
+	}
+
+	if d.Id() != "" && !d.HasChanges("sizing_policy_id", "cpus", "cpu_cores", "memory") {
+		return nil
+	}
+
+	configured := make(map[string]int)
+	for _, attribute := range []string{"cpus", "cpu_cores", "memory"} {
+		if value, ok := getConfiguredKnownInt(d, attribute); ok {
+			configured[attribute] = value
+		}
+	}
+	if len(configured) == 0 {
+		return nil
+	}
+
+	sizingPolicy, err := lookupComputePolicy(d, vcdClient, "sizing_policy_id")
+	if err != nil {
+		return planAttributeError("sizing_policy_id", "error finding sizing policy: %s", err)
+	}
+	if sizingPolicy == nil {
+		return nil
+	}
+
+	return validateVmValuesAgainstSizingPolicy(sizingPolicy.VdcComputePolicyV2, configured)
+}
+
+// validateVmCpuTopology checks that the number of CPUs can be split evenly into sockets having the
+// given number of cores
+func validateVmCpuTopology(cpus, cores int) error {
+	if cores <= 0 {
+		return planAttributeError("cpu_cores", "the number of cores per socket must be greater than 0, got %d", cores)
+	}
+	if cpus%cores != 0 {
+		return planAttributeError("cpus", "the number of CPUs (%d) must be a multiple of the number of cores per socket (%d)",
+			cpus, cores)
+	}
+	return nil
+}
+
+// validateVmValuesAgainstSizingPolicy checks that configured values ('cpus', 'cpu_cores' and
+// 'memory') match the ones enforced by a VM Sizing Policy. A policy value always takes precedence
+// over the VM definition, so a different value would never be applied.
+func validateVmValuesAgainstSizingPolicy(policy *types.VdcComputePolicyV2, configured map[string]int) error {
+	if policy == nil {
+		return nil
+	}
+
+	policyValues := map[string]*int{
+		"cpus":      policy.CPUCount,
+		"cpu_cores": policy.CoresPerSocket,
+		"memory":    policy.Memory,
+	}
+	for _, attribute := range []string{"cpus", "cpu_cores", "memory"} {
+		configuredValue, isConfigured := configured[attribute]
+		policyValue := policyValues[attribute]
+		if !isConfigured || policyValue == nil {
+			continue
+		}
+		if configuredValue != *policyValue {
+			return planAttributeError(attribute, "value %d conflicts with VM Sizing Policy '%s', which sets '%s' to %d. "+
+				"Remove '%s' or use a different sizing policy", configuredValue, policy.Name, attribute, *policyValue, attribute)
+		}
+	}
+	return nil
+}
+
+// orgVdcCustomizeDiff returns plan-time validations for `vcd_org_vdc`
+func orgVdcCustomizeDiff() schema.CustomizeDiffFunc {
+	return customdiff.Sequence(
+		validateOrgVdcFlexSettingsAtPlan,
+		validateOrgVdcDefaultComputePolicyAtPlan,
+	)
+}
+
+// validateOrgVdcFlexSettingsAtPlan checks that Flex specific settings are used only with the Flex
+// allocation model. With Flex, they are optional and computed by VCD when missing
+func validateOrgVdcFlexSettingsAtPlan(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("allocation_model") {
+		return nil
+	}
+
+	configured := make(map[string]bool)
+	for _, attribute := range []string{"elasticity", "include_vm_memory_overhead"} {
+		configured[attribute] = isAttributeConfigured(d, attribute)
+	}
+
+	return validateOrgVdcFlexSettings(d.Get("allocation_model").(string), configured)
+}
+
+// validateOrgVdcFlexSettings performs the checks of validateOrgVdcFlexSettingsAtPlan for the given
+// allocation model and map of attributes that are set in configuration
+func validateOrgVdcFlexSettings(allocationModel string, configured map[string]bool) error {
+	if allocationModel == "Flex" {
+		return nil
+	}
+	for _, attribute := range []string{"elasticity", "include_vm_memory_overhead"} {
+		if configured[attribute] {
+			return planAttributeError(attribute, "'%s' can be used only with Flex allocation model, got '%s'",
+				attribute, allocationModel)
+		}
+	}
+	return nil
+}
+
+// validateOrgVdcDefaultComputePolicyAtPlan checks that the default Compute Policy is one of the
+// policies assigned to the VDC
+func validateOrgVdcDefaultComputePolicyAtPlan(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	defaultPolicyAttribute := "default_compute_policy_id"
+	if !isAttributeConfigured(d, defaultPolicyAttribute) {
+		defaultPolicyAttribute = "default_vm_sizing_policy_id"
+	}
+	if !isAttributeConfigured(d, defaultPolicyAttribute) || !d.NewValueKnown(defaultPolicyAttribute) {
+		return nil
+	}
+	defaultPolicyId := d.Get(defaultPolicyAttribute).(string)
+
+	computePolicyAttributes := []string{"vm_sizing_policy_ids", "vm_placement_policy_ids", "vm_vgpu_policy_ids"}
+	var assignedPolicyIds []string
+	anyConfigured := false
+	for _, attribute := range computePolicyAttributes {
+		if !isAttributeConfigured(d, attribute) {
+			continue
+		}
+		if !d.GetRawConfig().GetAttr(attribute).IsWhollyKnown() {
+			return nil
+		}
+		anyConfigured = true
+		assignedPolicyIds = append(assignedPolicyIds, convertSchemaSetToSliceOfStrings(d.Get(attribute).(*schema.Set))...)
+	}
+
+	if !anyConfigured {
+		return planAttributeError(defaultPolicyAttribute, "when '%s' is used, it requires also one of %v",
+			defaultPolicyAttribute, computePolicyAttributes)
+	}
+	if !contains(assignedPolicyIds, defaultPolicyId) {
+		return planAttributeError(defaultPolicyAttribute, "'%s' %s is not present in any of %v",
+			defaultPolicyAttribute, defaultPolicyId, computePolicyAttributes)
+	}
+	return nil
+}
+
+// nsxtNatRuleCustomizeDiff returns plan-time validations for `vcd_nsxt_nat_rule`
+func nsxtNatRuleCustomizeDiff() schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if !d.NewValueKnown("rule_type") {
+			return nil
+		}
+		isConfigured := func(attribute string) bool {
+			return isAttributeConfigured(d, attribute)
+		}
+		return validateNsxtNatRuleFields(d.Get("rule_type").(string), isConfigured)
+	}
+}
+
+// validateNsxtNatRuleFields checks that address and port fields required (or forbidden) by a
+// given NAT rule type are set (or unset)
+func validateNsxtNatRuleFields(ruleType string, isConfigured func(attribute string) bool) error {
+	var required []string
+	switch ruleType {
+	case types.NsxtNatRuleTypeDnat, types.NsxtNatRuleTypeReflexive:
+		required = []string{"external_address", "internal_address"}
+	case types.NsxtNatRuleTypeNoDnat, types.NsxtNatRuleTypeSnat:
+		required = []string{"external_address"}
+	case types.NsxtNatRuleTypeNoSnat:
+		required = []string{"internal_address"}
+	default:
+		return nil
+	}
+
+	for _, attribute := range required {
+		if !isConfigured(attribute) {
+			return planAttributeError(attribute, "'%s' is required for NAT rule type '%s'", attribute, ruleType)
+		}
+	}
+
+	allowedRuleTypes := map[string][]string{
+		"dnat_external_port":       {types.NsxtNatRuleTypeDnat, types.NsxtNatRuleTypeNoDnat},
+		"snat_destination_address": {types.NsxtNatRuleTypeSnat, types.NsxtNatRuleTypeNoSnat},
+	}
+	for _, attribute := range []string{"dnat_external_port", "snat_destination_address"} {
+		if isConfigured(attribute) && !contains(allowedRuleTypes[attribute], ruleType) {
+			return planAttributeError(attribute, "'%s' can only be used with NAT rule types %v, got '%s'",
+				attribute, allowedRuleTypes[attribute], ruleType)
+		}
+	}
+
+	return nil
+}
+
+// independentDiskCustomizeDiff returns plan-time validations for `vcd_independent_disk`
+func independentDiskCustomizeDiff() schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if d.Id() == "" || !d.HasChange("size_in_mb") || !d.NewValueKnown("size_in_mb") {
+			return nil
+		}
+		// A disk which is recreated can have any size
+		if d.HasChanges("org", "vdc", "name", "bus_type", "bus_sub_type", "sharing_type") {
+			return nil
+		}
+		oldSize, newSize := d.GetChange("size_in_mb")
+		return validateIndependentDiskSize(oldSize.(int), newSize.(int))
+	}
+}
+
+// validateIndependentDiskSize checks that an existing independent disk is only grown, as shrinking
+// it is not supported by VCD and could lose data
+func validateIndependentDiskSize(oldSize, newSize int) error {
+	if newSize < oldSize {
+		return planAttributeError("size_in_mb", "independent disk can only be grown: 'size_in_mb' %d is lower than current size %d",
+			newSize, oldSize)
+	}
+	return nil
+}
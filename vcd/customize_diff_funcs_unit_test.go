@@ -0,0 +1,210 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// checkPlanAttributeError checks that err is a cty.PathError pointing to the expected attribute
+func checkPlanAttributeError(t *testing.T, err error, wantAttribute string) {
+	if wantAttribute == "" {
+		if err != nil {
+			t.Errorf("expected no error, got: %s", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Errorf("expected error for attribute '%s', got none", wantAttribute)
+		return
+	}
+	pathErr, ok := err.(cty.PathError)
+	if !ok {
+		t.Errorf("expected cty.PathError, got %T: %s", err, err)
+		return
+	}
+	if !pathErr.Path.Equals(cty.GetAttrPath(wantAttribute)) {
+		t.Errorf("expected error for attribute '%s', got path %#v: %s", wantAttribute, pathErr.Path, err)
+	}
+}
+
+// Test_validateNsxtNatRuleFields checks the plan-time validation of NAT rule address fields
+func Test_validateNsxtNatRuleFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		ruleType      string
+		configured    []string
+		wantAttribute string
+	}{
+		{
+			name:       "DNAT complete",
+			ruleType:   types.NsxtNatRuleTypeDnat,
+			configured: []string{"external_address", "internal_address", "dnat_external_port"},
+		},
+		{
+			name:          "DNAT without external address",
+			ruleType:      types.NsxtNatRuleTypeDnat,
+			configured:    []string{"internal_address"},
+			wantAttribute: "external_address",
+		},
+		{
+			name:          "DNAT with SNAT destination",
+			ruleType:      types.NsxtNatRuleTypeDnat,
+			configured:    []string{"external_address", "internal_address", "snat_destination_address"},
+			wantAttribute: "snat_destination_address",
+		},
+		{
+			name:       "SNAT without internal address",
+			ruleType:   types.NsxtNatRuleTypeSnat,
+			configured: []string{"external_address", "snat_destination_address"},
+		},
+		{
+			name:          "SNAT with DNAT port",
+			ruleType:      types.NsxtNatRuleTypeSnat,
+			configured:    []string{"external_address", "dnat_external_port"},
+			wantAttribute: "dnat_external_port",
+		},
+		{
+			name:       "NO_SNAT with internal address",
+			ruleType:   types.NsxtNatRuleTypeNoSnat,
+			configured: []string{"internal_address"},
+		},
+		{
+			name:          "NO_SNAT without internal address",
+			ruleType:      types.NsxtNatRuleTypeNoSnat,
+			configured:    []string{"external_address"},
+			wantAttribute: "internal_address",
+		},
+		{
+			name:       "NO_DNAT with external port",
+			ruleType:   types.NsxtNatRuleTypeNoDnat,
+			configured: []string{"external_address", "dnat_external_port"},
+		},
+		{
+			name:          "REFLEXIVE without internal address",
+			ruleType:      types.NsxtNatRuleTypeReflexive,
+			configured:    []string{"external_address"},
+			wantAttribute: "internal_address",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isConfigured := func(attribute string) bool {
+				return contains(tt.configured, attribute)
+			}
+			checkPlanAttributeError(t, validateNsxtNatRuleFields(tt.ruleType, isConfigured), tt.wantAttribute)
+		})
+	}
+}
+
+// Test_validateOrgVdcFlexSettings checks the plan-time validation of Flex allocation model settings
+func Test_validateOrgVdcFlexSettings(t *testing.T) {
+	tests := []struct {
+		name            string
+		allocationModel string
+		configured      map[string]bool
+		wantAttribute   string
+	}{
+		{
+			name:            "Flex with all settings",
+			allocationModel: "Flex",
+			configured:      map[string]bool{"elasticity": true, "include_vm_memory_overhead": true},
+		},
+		{
+			name:            "Flex without elasticity",
+			allocationModel: "Flex",
+			configured:      map[string]bool{"include_vm_memory_overhead": true},
+		},
+		{
+			name:            "Flex without settings",
+			allocationModel: "Flex",
+			configured:      map[string]bool{},
+		},
+		{
+			name:            "AllocationPool with memory overhead",
+			allocationModel: "AllocationPool",
+			configured:      map[string]bool{"include_vm_memory_overhead": true},
+			wantAttribute:   "include_vm_memory_overhead",
+		},
+		{
+			name:            "AllocationVApp without Flex settings",
+			allocationModel: "AllocationVApp",
+			configured:      map[string]bool{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkPlanAttributeError(t, validateOrgVdcFlexSettings(tt.allocationModel, tt.configured), tt.wantAttribute)
+		})
+	}
+}
+
+// Test_validateVmValuesAgainstSizingPolicy checks that explicit VM values are compared against the
+// ones enforced by a sizing policy
+func Test_validateVmValuesAgainstSizingPolicy(t *testing.T) {
+	policy := &types.VdcComputePolicyV2{
+		VdcComputePolicy: types.VdcComputePolicy{
+			Name:     "sizing",
+			CPUCount: addrOf(4),
+			Memory:   addrOf(2048),
+		},
+	}
+	tests := []struct {
+		name          string
+		configured    map[string]int
+		wantAttribute string
+	}{
+		{
+			name:       "matching values",
+			configured: map[string]int{"cpus": 4, "memory": 2048},
+		},
+		{
+			name:       "value not enforced by policy",
+			configured: map[string]int{"cpu_cores": 2},
+		},
+		{
+			name:          "different CPU count",
+			configured:    map[string]int{"cpus": 8},
+			wantAttribute: "cpus",
+		},
+		{
+			name:          "different memory",
+			configured:    map[string]int{"cpus": 4, "memory": 4096},
+			wantAttribute: "memory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkPlanAttributeError(t, validateVmValuesAgainstSizingPolicy(policy, tt.configured), tt.wantAttribute)
+		})
+	}
+}
+
+// Test_validateVmCpuTopology checks the CPU and cores per socket combination
+func Test_validateVmCpuTopology(t *testing.T) {
+	checkPlanAttributeError(t, validateVmCpuTopology(4, 2), "")
+	checkPlanAttributeError(t, validateVmCpuTopology(3, 2), "cpus")
+	checkPlanAttributeError(t, validateVmCpuTopology(2, 0), "cpu_cores")
+}
+
+// Test_validateIndependentDiskSize checks that independent disks can only be grown
+func Test_validateIndependentDiskSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		oldSize       int
+		newSize       int
+		wantAttribute string
+	}{
+		{name: "grow", oldSize: 1024, newSize: 2048},
+		{name: "same size", oldSize: 1024, newSize: 1024},
+		{name: "shrink", oldSize: 2048, newSize: 1024, wantAttribute: "size_in_mb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkPlanAttributeError(t, validateIndependentDiskSize(tt.oldSize, tt.newSize), tt.wantAttribute)
+		})
+	}
+}
@@ -62,6 +62,22 @@ func testSpecificDataSourceNotFound(dataSourceName string, vcdClient *VCDClient)
 				dataSourceName: "vcd_multisite_site_data",
 				reason:         "The VCD site data is always available",
 			},
+			{
+				dataSourceName: "vcd_vapp_lease_expiry",
+				reason:         "The vApp lease expiry lists all vApps of a VDC, and can be empty",
+			},
+			{
+				dataSourceName: "vcd_least_privilege_rights",
+				reason:         "The least privilege rights are computed from the given resource types",
+			},
+			{
+				dataSourceName: "vcd_current_session",
+				reason:         "The current session is always available",
+			},
+			{
+				dataSourceName: "vcd_org_ldap_search",
+				reason:         "The LDAP search returns an empty list when nothing matches",
+			},
 		}
 		for _, skip := range skipAlwaysSlice {
 			if dataSourceName == skip.dataSourceName {
@@ -177,6 +193,7 @@ func testSpecificDataSourceNotFound(dataSourceName string, vcdClient *VCDClient)
 			"vcd_nsxt_segment_profile_template",
 			"vcd_nsxt_network_context_profile",
 			"vcd_nsxt_edgegateway_l2_vpn_tunnel",
+			"vcd_nsxt_edgegateway_l2_vpn_tunnel_status",
 			"vcd_vgpu_profile",
 			"vcd_multisite_site_association",
 			"vcd_multisite_site_data",
@@ -246,6 +263,13 @@ func testSpecificDataSourceNotFound(dataSourceName string, vcdClient *VCDClient)
 			params["MandatoryFields"] = `vdc_id = "deadbeef-dead-beef-dead-beefdeadbeef"`
 		}
 
+		if dataSourceName == "vcd_rights_comparison" {
+			config := `org = "` + testConfig.VCD.Org + `"` + "\n"
+			config += `first {` + "\n" + `type = "role"` + "\n" + `name = "non-existing"` + "\n" + `}` + "\n"
+			config += `second {` + "\n" + `type = "rights"` + "\n" + `}` + "\n"
+			params["MandatoryFields"] = config
+		}
+
 		if dataSourceName == "vcd_org_vdc_nsxt_network_profile" {
 			config := `org = "` + testConfig.VCD.Org + `"` + "\n"
 			config += `vdc = "non-existing"` + "\n"
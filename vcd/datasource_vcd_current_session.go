@@ -0,0 +1,85 @@
+package vcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdCurrentSession() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdCurrentSessionRead,
+		Schema: map[string]*schema.Schema{
+			"user_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the authenticated user, API token owner or service account",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the authenticated user",
+			},
+			"org_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the Organization of the authenticated user",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Organization of the authenticated user",
+			},
+			"connection_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "How the provider authenticated. One of 'password', 'bearer_token' or 'api_token'",
+			},
+			"is_sysadmin": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the authenticated user is a System administrator",
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Names of the roles of the authenticated user",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"rights": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Effective rights of the authenticated user, given by all its roles",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func datasourceVcdCurrentSessionRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	session, _, err := vcdClient.getCachedSession()
+	if err != nil {
+		return diag.Errorf("[current session read] %s", err)
+	}
+
+	dSet(d, "user_name", session.UserName)
+	dSet(d, "user_id", session.UserId)
+	dSet(d, "org_name", session.OrgName)
+	dSet(d, "org_id", session.OrgId)
+	dSet(d, "connection_type", session.ConnectionType)
+	dSet(d, "is_sysadmin", session.IsSysAdmin)
+	err = d.Set("roles", convertStringsToTypeSet(session.Roles))
+	if err != nil {
+		return diag.Errorf("[current session read] error setting roles: %s", err)
+	}
+	err = d.Set("rights", convertStringsToTypeSet(sortedKeys(session.Rights)))
+	if err != nil {
+		return diag.Errorf("[current session read] error setting rights: %s", err)
+	}
+
+	d.SetId(session.UserId)
+	return nil
+}
@@ -0,0 +1,54 @@
+//go:build role || ALL || functional
+
+package vcd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdCurrentSessionDS(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"FuncName": t.Name(),
+		"Tags":     "role",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdCurrentSessionDS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.vcd_current_session.current", "id", "data.vcd_current_session.current", "user_id"),
+					resource.TestCheckResourceAttrSet("data.vcd_current_session.current", "user_name"),
+					resource.TestCheckResourceAttrSet("data.vcd_current_session.current", "org_name"),
+					resource.TestMatchResourceAttr("data.vcd_current_session.current", "org_id", regexp.MustCompile(`^urn:vcloud:org:`)),
+					resource.TestMatchResourceAttr("data.vcd_current_session.current", "connection_type", regexp.MustCompile(`^(password|bearer_token|api_token)$`)),
+					resource.TestCheckResourceAttr("data.vcd_current_session.current", "is_sysadmin", fmt.Sprintf("%t", usingSysAdmin())),
+					resource.TestMatchResourceAttr("data.vcd_current_session.current", "roles.#", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestMatchResourceAttr("data.vcd_current_session.current", "rights.#", regexp.MustCompile(`^[1-9]\d*$`)),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdCurrentSessionDS = `
+data "vcd_current_session" "current" {
+}
+`
@@ -0,0 +1,86 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func datasourceVcdLeastPrivilegeRights() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdLeastPrivilegeRightsRead,
+		Schema: map[string]*schema.Schema{
+			"resource_types": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Description: "Resource types used in a configuration, such as 'vcd_vapp_vm'. Data sources are " +
+					"identified by the 'data.' prefix, such as 'data.vcd_catalog', and only need view rights",
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+			"include_implied_rights": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to add the rights implied by the needed ones, as required when creating a role",
+			},
+			"rights": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Set of rights needed to use the given resource types",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"unknown_resource_types": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Description: "Resource types for which the needed rights are not known, or that can't be managed by " +
+					"tenant users",
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+			"unavailable_rights": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Needed rights which are not available in this VCD",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func datasourceVcdLeastPrivilegeRightsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	resourceTypes := convertSchemaSetToSliceOfStrings(d.Get("resource_types").(*schema.Set))
+	neededRights, unknownResourceTypes := getLeastPrivilegeRights(resourceTypes)
+
+	allRights, err := vcdClient.Client.GetAllRights(nil)
+	if err != nil {
+		return diag.Errorf("[least privilege rights read] error retrieving rights: %s", err)
+	}
+	availableRights := make(map[string]*types.Right, len(allRights))
+	for _, right := range allRights {
+		availableRights[right.Name] = right
+	}
+	rights, unavailableRights := resolveRights(neededRights, availableRights, d.Get("include_implied_rights").(bool))
+
+	err = d.Set("rights", convertStringsToTypeSet(rights))
+	if err != nil {
+		return diag.Errorf("[least privilege rights read] error setting rights: %s", err)
+	}
+	err = d.Set("unknown_resource_types", convertStringsToTypeSet(unknownResourceTypes))
+	if err != nil {
+		return diag.Errorf("[least privilege rights read] error setting unknown resource types: %s", err)
+	}
+	err = d.Set("unavailable_rights", convertStringsToTypeSet(unavailableRights))
+	if err != nil {
+		return diag.Errorf("[least privilege rights read] error setting unavailable rights: %s", err)
+	}
+
+	sort.Strings(resourceTypes)
+	d.SetId(fmt.Sprintf("least_privilege_rights:%d", hashcodeString(strings.Join(resourceTypes, ","))))
+	return nil
+}
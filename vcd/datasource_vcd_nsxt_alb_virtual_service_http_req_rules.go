@@ -0,0 +1,32 @@
+package vcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdAlbVirtualServiceHttpReqRules() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdAlbVirtualServiceHttpReqRulesRead,
+
+		Schema: map[string]*schema.Schema{
+			"virtual_service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "NSX-T ALB Virtual Service ID",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        albVsHttpRequestRuleSchema(true),
+				Description: "A single HTTP Request Rule",
+			},
+		},
+	}
+}
+
+func datasourceVcdAlbVirtualServiceHttpReqRulesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(readAlbVsHttpRequestRules(d, meta, "datasource"))
+}
@@ -0,0 +1,215 @@
+package vcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdNsxtDistributedFirewallEffectivePolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdNsxtDistributedFirewallEffectivePolicyRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of VDC Group for Distributed Firewall",
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Owner used to classify the rules. Rules with this owner are 'owned', rules with " +
+					"another owner are 'other_owner' and rules without owner are 'unmanaged'",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Ordered list of all the firewall rules of the VDC Group",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firewall Rule ID",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firewall Rule name",
+						},
+						"comment": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Comment of the rule, without the owner marker",
+						},
+						"owner": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Owner of the rule, empty for rules that are not managed with an owner",
+						},
+						"ownership": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "One of 'owned', 'other_owner', 'unmanaged'",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Defines if the rule should 'ALLOW', 'DROP', 'REJECT' matching traffic",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Defined if Firewall Rule is active",
+						},
+						"direction": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Direction on which Firewall Rule applies (One of 'IN', 'OUT', 'IN_OUT')",
+						},
+						"ip_protocol": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Firewall Rule Protocol (One of 'IPV4', 'IPV6', 'IPV4_IPV6')",
+						},
+						"source_ids": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Description: "A set of Source Firewall Group IDs (IP Sets or Security Groups). Empty means 'Any'",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"destination_ids": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Description: "A set of Destination Firewall Group IDs (IP Sets or Security Groups). Empty means 'Any'",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"app_port_profile_ids": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Description: "A set of Application Port Profile IDs. Empty means 'Any'",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"owners": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "All the owners found in the firewall rules",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"owned_rule_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Ordered IDs of the rules that belong to 'owner'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"other_owner_rule_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Ordered IDs of the rules that belong to other owners",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"unmanaged_rule_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Ordered IDs of the rules without owner",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdNsxtDistributedFirewallEffectivePolicyRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Effective Policy DS Read] error retrieving Org: %s", err)
+	}
+
+	vdcGroup, err := org.GetVdcGroupById(d.Get("vdc_group_id").(string))
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Effective Policy DS Read] error retrieving VDC Group: %s", err)
+	}
+
+	fwRules, err := vdcGroup.GetDistributedFirewall()
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Effective Policy DS Read] error retrieving NSX-T Firewall Rules: %s", err)
+	}
+
+	owner := d.Get("owner").(string)
+	rules := fwRules.DistributedFirewallRuleContainer.Values
+	owners := make([]string, 0)
+	result := make([]interface{}, len(rules))
+	for index, rule := range rules {
+		comment, ruleOwner := dfwCommentOwner(rule.Comments)
+		if ruleOwner != "" && !contains(owners, ruleOwner) {
+			owners = append(owners, ruleOwner)
+		}
+
+		result[index] = map[string]interface{}{
+			"id":                   rule.ID,
+			"name":                 rule.Name,
+			"comment":              comment,
+			"owner":                ruleOwner,
+			"ownership":            dfwRuleOwnership(ruleOwner, owner),
+			"action":               rule.ActionValue,
+			"enabled":              rule.Enabled,
+			"direction":            rule.Direction,
+			"ip_protocol":          rule.IpProtocol,
+			"source_ids":           convertStringsToTypeSet(extractIdsFromOpenApiReferences(rule.SourceFirewallGroups)),
+			"destination_ids":      convertStringsToTypeSet(extractIdsFromOpenApiReferences(rule.DestinationFirewallGroups)),
+			"app_port_profile_ids": convertStringsToTypeSet(extractIdsFromOpenApiReferences(rule.ApplicationPortProfiles)),
+		}
+	}
+
+	err = d.Set("rule", result)
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Effective Policy DS Read] error storing 'rule': %s", err)
+	}
+	err = d.Set("owners", convertStringsToTypeSet(owners))
+	if err != nil {
+		return diag.Errorf("[Distributed Firewall Effective Policy DS Read] error storing 'owners': %s", err)
+	}
+	ownershipFields := map[string]string{
+		"owned_rule_ids":       dfwRuleOwned,
+		"other_owner_rule_ids": dfwRuleOtherOwner,
+		"unmanaged_rule_ids":   dfwRuleUnmanaged,
+	}
+	for field, ownership := range ownershipFields {
+		err = d.Set(field, dfwRuleIdsByOwnership(rules, owner, ownership))
+		if err != nil {
+			return diag.Errorf("[Distributed Firewall Effective Policy DS Read] error storing '%s': %s", field, err)
+		}
+	}
+
+	d.SetId(vdcGroup.VdcGroup.Id)
+
+	return nil
+}
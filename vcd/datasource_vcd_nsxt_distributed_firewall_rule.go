@@ -40,6 +40,11 @@ func datasourceVcdNsxtDistributedFirewallRule() *schema.Resource {
 				Computed:    true,
 				Description: "Comment that is shown next to rule in UI (VCD 10.3.2+)",
 			},
+			"owner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Owner of the rule, set by the 'owner' field of the rule resources",
+			},
 			"direction": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -0,0 +1,265 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+const (
+	// nsxtL2VpnTunnelMetricsApiVersion is the API version used to retrieve the statistics of an L2 VPN Tunnel,
+	// which is the first one supporting L2 VPN
+	nsxtL2VpnTunnelMetricsApiVersion = "37.0"
+	nsxtL2VpnTunnelMetricsEndpoint   = "edgeGateways/%s/l2vpn/tunnels/%s/metrics"
+)
+
+// nsxtL2VpnTunnelMetrics contains the statistics of the segments stretched by an L2 VPN Tunnel. The SDK only decodes
+// the statistics of a single segment, while the response can contain a list with one entry per segment
+type nsxtL2VpnTunnelMetrics struct {
+	types.EdgeL2VpnTunnelStatistics
+	TunnelStatistics []types.EdgeL2VpnTunnelStatistics `json:"tunnelStatistics"`
+}
+
+// segments returns the statistics of each segment, whatever form the response had
+func (metrics *nsxtL2VpnTunnelMetrics) segments() []types.EdgeL2VpnTunnelStatistics {
+	if len(metrics.TunnelStatistics) > 0 {
+		return metrics.TunnelStatistics
+	}
+	if metrics.SegmentPath != "" {
+		return []types.EdgeL2VpnTunnelStatistics{metrics.EdgeL2VpnTunnelStatistics}
+	}
+	return nil
+}
+
+func datasourceVcdNsxtEdgegatewayL2VpnTunnelStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdNsxtEdgegatewayL2VpnTunnelStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"edge_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Edge Gateway ID for the tunnel",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the L2 VPN Tunnel session",
+			},
+			"session_mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Mode of the tunnel session, either CLIENT or SERVER",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Runtime status of the L2 VPN Tunnel (UP, DOWN)",
+			},
+			"failure_reason": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Reason for the L2 VPN Tunnel not being UP",
+			},
+			"stretched_network": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Traffic statistics of each segment stretched by the tunnel",
+				Elem:        nsxtL2VpnTunnelSegmentStatisticsSchema,
+			},
+		},
+	}
+}
+
+var nsxtL2VpnTunnelSegmentStatisticsSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"network_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the Org VDC network backed by the segment. Empty when the segment doesn't match any stretched network",
+		},
+		"network_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the Org VDC network backed by the segment",
+		},
+		"tunnel_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Tunnel ID of the network for the tunnel",
+		},
+		"segment_path": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "NSX-T policy path of the segment",
+		},
+		"bytes_in": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of bytes received",
+		},
+		"bytes_out": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of bytes sent",
+		},
+		"packets_in": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets received",
+		},
+		"packets_out": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets sent",
+		},
+		"bum_bytes_in": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of broadcast, unknown unicast and multicast (BUM) bytes received",
+		},
+		"bum_bytes_out": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of broadcast, unknown unicast and multicast (BUM) bytes sent",
+		},
+		"bum_packets_in": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of broadcast, unknown unicast and multicast (BUM) packets received",
+		},
+		"bum_packets_out": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of broadcast, unknown unicast and multicast (BUM) packets sent",
+		},
+		"packets_receive_error": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets dropped while receiving",
+		},
+		"packets_sent_error": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets dropped while sending",
+		},
+	},
+}
+
+func datasourceVcdNsxtEdgegatewayL2VpnTunnelStatusRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	orgName := d.Get("org").(string)
+	edgeGatewayId := d.Get("edge_gateway_id").(string)
+	nsxtEdge, err := vcdClient.GetNsxtEdgeGatewayById(orgName, edgeGatewayId)
+	if err != nil {
+		return diag.Errorf("[L2 VPN Tunnel status DS read] error retrieving Edge Gateway: %s", err)
+	}
+
+	tunnelName := d.Get("name").(string)
+	tunnel, err := nsxtEdge.GetL2VpnTunnelByName(tunnelName)
+	if err != nil {
+		return diag.Errorf("[L2 VPN Tunnel status DS read] error retrieving L2 VPN Tunnel '%s': %s", tunnelName, err)
+	}
+	dSet(d, "session_mode", tunnel.NsxtL2VpnTunnel.SessionMode)
+
+	status, err := tunnel.Status()
+	if err != nil {
+		return diag.Errorf("[L2 VPN Tunnel status DS read] error retrieving L2 VPN Tunnel status: %s", err)
+	}
+	dSet(d, "status", status.RuntimeStatus)
+	dSet(d, "failure_reason", status.FailureReason)
+
+	metrics, err := getNsxtL2VpnTunnelMetrics(vcdClient, edgeGatewayId, tunnel.NsxtL2VpnTunnel.ID)
+	if err != nil {
+		return diag.Errorf("[L2 VPN Tunnel status DS read] error retrieving L2 VPN Tunnel statistics: %s", err)
+	}
+
+	// The segments are matched to the stretched networks by the NSX-T ID of their backing network
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf("[L2 VPN Tunnel status DS read] "+errorRetrievingOrg, err)
+	}
+	networksByBackingId := make(map[string]stretchedNetworkInfo)
+	for _, stretched := range tunnel.NsxtL2VpnTunnel.StretchedNetworks {
+		network, err := org.GetOpenApiOrgVdcNetworkById(stretched.NetworkRef.ID)
+		if err != nil {
+			return diag.Errorf("[L2 VPN Tunnel status DS read] error retrieving stretched network '%s': %s", stretched.NetworkRef.ID, err)
+		}
+		networksByBackingId[network.OpenApiOrgVdcNetwork.BackingNetworkId] = stretchedNetworkInfo{
+			id:       network.OpenApiOrgVdcNetwork.ID,
+			name:     network.OpenApiOrgVdcNetwork.Name,
+			tunnelId: stretched.TunnelID,
+		}
+	}
+
+	err = d.Set("stretched_network", flattenNsxtL2VpnTunnelSegmentStatistics(metrics.segments(), networksByBackingId))
+	if err != nil {
+		return diag.Errorf("[L2 VPN Tunnel status DS read] error storing L2 VPN Tunnel statistics to schema: %s", err)
+	}
+
+	d.SetId(tunnel.NsxtL2VpnTunnel.ID)
+
+	return nil
+}
+
+// stretchedNetworkInfo identifies the Org VDC network backed by a stretched segment
+type stretchedNetworkInfo struct {
+	id       string
+	name     string
+	tunnelId int
+}
+
+// getNsxtL2VpnTunnelMetrics retrieves the statistics of the segments of an L2 VPN Tunnel. They are retrieved directly
+// from the OpenAPI endpoint, as the SDK only decodes the statistics of one segment
+func getNsxtL2VpnTunnelMetrics(vcdClient *VCDClient, edgeGatewayId, tunnelId string) (*nsxtL2VpnTunnelMetrics, error) {
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0,
+		fmt.Sprintf(nsxtL2VpnTunnelMetricsEndpoint, edgeGatewayId, tunnelId))
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &nsxtL2VpnTunnelMetrics{}
+	err = vcdClient.Client.OpenApiGetItem(nsxtL2VpnTunnelMetricsApiVersion, urlRef, nil, metrics, nil)
+	if err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// flattenNsxtL2VpnTunnelSegmentStatistics converts the segment statistics to the schema, adding the Org VDC network
+// backed by each segment, whose path ends with the backing network ID
+func flattenNsxtL2VpnTunnelSegmentStatistics(statistics []types.EdgeL2VpnTunnelStatistics, networksByBackingId map[string]stretchedNetworkInfo) []interface{} {
+	result := make([]interface{}, len(statistics))
+	for index, segment := range statistics {
+		pathElements := strings.Split(segment.SegmentPath, "/")
+		network := networksByBackingId[pathElements[len(pathElements)-1]]
+		result[index] = map[string]interface{}{
+			"network_id":            network.id,
+			"network_name":          network.name,
+			"tunnel_id":             network.tunnelId,
+			"segment_path":          segment.SegmentPath,
+			"bytes_in":              segment.BytesIn,
+			"bytes_out":             segment.BytesOut,
+			"packets_in":            segment.PacketsIn,
+			"packets_out":           segment.PacketsOut,
+			"bum_bytes_in":          segment.BumBytesIn,
+			"bum_bytes_out":         segment.BumBytesOut,
+			"bum_packets_in":        segment.BumPacketsIn,
+			"bum_packets_out":       segment.BumPacketsOut,
+			"packets_receive_error": segment.PacketsReceiveError,
+			"packets_sent_error":    segment.PacketsSentError,
+		}
+	}
+	return result
+}
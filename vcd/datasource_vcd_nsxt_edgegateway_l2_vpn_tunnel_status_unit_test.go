@@ -0,0 +1,72 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_nsxtL2VpnTunnelMetricsSegments(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     string
+		wantSegments int
+	}{
+		{
+			name:         "List",
+			response:     `{"tunnelStatistics":[{"segmentPath":"/infra/segments/a","bytesIn":10},{"segmentPath":"/infra/segments/b"}]}`,
+			wantSegments: 2,
+		},
+		{
+			name:         "SingleSegment",
+			response:     `{"segmentPath":"/infra/segments/a","bytesIn":10}`,
+			wantSegments: 1,
+		},
+		{
+			name:         "Empty",
+			response:     `{}`,
+			wantSegments: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := &nsxtL2VpnTunnelMetrics{}
+			err := json.Unmarshal([]byte(tt.response), metrics)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			segments := metrics.segments()
+			if len(segments) != tt.wantSegments {
+				t.Fatalf("expected %d segments, got %d", tt.wantSegments, len(segments))
+			}
+			if len(segments) > 0 && (segments[0].SegmentPath != "/infra/segments/a" || segments[0].BytesIn != 10) {
+				t.Errorf("unexpected first segment: %+v", segments[0])
+			}
+		})
+	}
+}
+
+func Test_flattenNsxtL2VpnTunnelSegmentStatistics(t *testing.T) {
+	metrics := &nsxtL2VpnTunnelMetrics{}
+	err := json.Unmarshal([]byte(`{"tunnelStatistics":[{"segmentPath":"/infra/segments/backing-a","packetsIn":5},{"segmentPath":"/infra/segments/unknown"}]}`), metrics)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	networks := map[string]stretchedNetworkInfo{
+		"backing-a": {id: "urn:vcloud:network:a", name: "net-a", tunnelId: 3},
+	}
+
+	result := flattenNsxtL2VpnTunnelSegmentStatistics(metrics.segments(), networks)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 segments, got %d", len(result))
+	}
+	first := result[0].(map[string]interface{})
+	if first["network_id"] != "urn:vcloud:network:a" || first["network_name"] != "net-a" || first["tunnel_id"] != 3 || first["packets_in"] != 5 {
+		t.Errorf("unexpected first segment: %v", first)
+	}
+	second := result[1].(map[string]interface{})
+	if second["network_id"] != "" || second["segment_path"] != "/infra/segments/unknown" {
+		t.Errorf("unexpected second segment: %v", second)
+	}
+}
@@ -0,0 +1,371 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+const (
+	// nsxtIpSecVpnTunnelMetricsApiVersion is the API version used to retrieve the statistics of an IPsec VPN Tunnel,
+	// which are available since the same version as the IPsec VPN Tunnel status
+	nsxtIpSecVpnTunnelMetricsApiVersion = "34.0"
+	nsxtIpSecVpnTunnelMetricsEndpoint   = "edgeGateways/%s/ipsec/tunnels/%s/metrics"
+)
+
+// nsxtIpSecVpnTunnelMetrics contains the statistics of all the Security Associations (SA) of an IPsec VPN Tunnel
+type nsxtIpSecVpnTunnelMetrics struct {
+	TunnelStatistics []nsxtIpSecVpnTunnelSaStatistics `json:"tunnelStatistics"`
+}
+
+// nsxtIpSecVpnTunnelSaStatistics contains the traffic statistics of a single Security Association, which is
+// negotiated for each pair of local and peer subnets of the tunnel
+type nsxtIpSecVpnTunnelSaStatistics struct {
+	LocalSubnet               string `json:"localSubnet"`
+	PeerSubnet                string `json:"peerSubnet"`
+	TunnelStatus              string `json:"tunnelStatus"`
+	TunnelDownReason          string `json:"tunnelDownReason"`
+	BytesIn                   int64  `json:"bytesIn"`
+	BytesOut                  int64  `json:"bytesOut"`
+	PacketsIn                 int64  `json:"packetsIn"`
+	PacketsOut                int64  `json:"packetsOut"`
+	DroppedPacketsIn          int64  `json:"droppedPacketsIn"`
+	DroppedPacketsOut         int64  `json:"droppedPacketsOut"`
+	PacketsReceivedOtherError int64  `json:"packetsReceivedOtherError"`
+	PacketsSentOtherError     int64  `json:"packetsSentOtherError"`
+	EncryptionFailures        int64  `json:"encryptionFailures"`
+	DecryptionFailures        int64  `json:"decryptionFailures"`
+	IntegrityFailures         int64  `json:"integrityFailures"`
+	ReplayErrors              int64  `json:"replayErrors"`
+	NoMatchingPolicyErrors    int64  `json:"nomatchingPolicyErrors"`
+	SaMismatchErrorsIn        int64  `json:"saMismatchErrorsIn"`
+	SaMismatchErrorsOut       int64  `json:"saMismatchErrorsOut"`
+	SeqNumberOverflowErrors   int64  `json:"seqNumberOverflowErrors"`
+}
+
+func datasourceVcdNsxtIpSecVpnTunnelStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdNsxtIpSecVpnTunnelStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"edge_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Edge gateway ID in which IP Sec VPN configuration is located",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of IP Sec VPN configuration",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Overall IPsec VPN Tunnel Status",
+			},
+			"ike_service_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status for the actual IKE Session for the given tunnel",
+			},
+			"ike_fail_reason": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Provides more details of failure if the IKE service is not UP",
+			},
+			"ike_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IKE version used to negotiate the tunnel",
+			},
+			"ike_encryption_algorithms": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Encryption algorithms offered during the IKE negotiation",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ike_digest_algorithms": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Secure hashing algorithms offered during the IKE negotiation",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ike_dh_groups": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Diffie-Hellman groups offered during the IKE negotiation",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tunnel_encryption_algorithms": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Encryption algorithms offered for the Security Associations of the tunnel",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tunnel_digest_algorithms": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Digest algorithms offered for the Security Associations of the tunnel",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tunnel_dh_groups": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Diffie-Hellman groups offered for the Security Associations of the tunnel",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"security_association": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Statistics of each Security Association of the tunnel",
+				Elem:        nsxtIpSecVpnTunnelSaStatisticsSchema,
+			},
+		},
+	}
+}
+
+var nsxtIpSecVpnTunnelSaStatisticsSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"local_subnet": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Local subnet of the Security Association",
+		},
+		"peer_subnet": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Peer subnet of the Security Association",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Status of the Security Association (UP, DOWN)",
+		},
+		"down_reason": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Reason for the Security Association being down",
+		},
+		"bytes_in": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of bytes received",
+		},
+		"bytes_out": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of bytes sent",
+		},
+		"packets_in": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets received",
+		},
+		"packets_out": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets sent",
+		},
+		"dropped_packets_in": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of received packets that were dropped",
+		},
+		"dropped_packets_out": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets to send that were dropped",
+		},
+		"packets_received_other_error": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets dropped while receiving for reasons not covered by other counters",
+		},
+		"packets_sent_other_error": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets dropped while sending for reasons not covered by other counters",
+		},
+		"encryption_failures": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets dropped because of encryption failures",
+		},
+		"decryption_failures": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets dropped because of decryption failures",
+		},
+		"integrity_failures": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets dropped because of integrity check failures",
+		},
+		"replay_errors": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets dropped because of replay check failures",
+		},
+		"no_matching_policy_errors": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets dropped because no matching policy was found",
+		},
+		"sa_mismatch_errors_in": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of received packets dropped because of Security Association mismatch",
+		},
+		"sa_mismatch_errors_out": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets to send dropped because of Security Association mismatch",
+		},
+		"seq_number_overflow_errors": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of packets dropped because of sequence number overflow",
+		},
+	},
+}
+
+func datasourceVcdNsxtIpSecVpnTunnelStatusRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	orgName := d.Get("org").(string)
+	edgeGatewayId := d.Get("edge_gateway_id").(string)
+
+	nsxtEdge, err := vcdClient.GetNsxtEdgeGatewayById(orgName, edgeGatewayId)
+	if err != nil {
+		return diag.Errorf("error retrieving Edge Gateway: %s", err)
+	}
+
+	ipSecVpnTunnelName := d.Get("name").(string)
+	ipSecVpnTunnel, err := nsxtEdge.GetIpSecVpnTunnelByName(ipSecVpnTunnelName)
+	if err != nil {
+		return diag.Errorf("error retrieving NSX-T IPsec VPN Tunnel configuration with name '%s': %s", ipSecVpnTunnelName, err)
+	}
+
+	tunnelStatus, err := ipSecVpnTunnel.GetStatus()
+	if err != nil {
+		return diag.Errorf("error reading NSX-T IPsec VPN Tunnel status: %s", err)
+	}
+	setNsxtIpSecVpnTunnelStatusData(d, tunnelStatus)
+
+	tunnelConnectionProperties, err := ipSecVpnTunnel.GetTunnelConnectionProperties()
+	if err != nil {
+		return diag.Errorf("error reading NSX-T IPsec VPN Tunnel Security Customization: %s", err)
+	}
+	err = setNsxtIpSecVpnTunnelAlgorithmData(d, tunnelConnectionProperties)
+	if err != nil {
+		return diag.Errorf("error storing NSX-T IPsec VPN Tunnel algorithms to schema: %s", err)
+	}
+
+	metrics, err := getNsxtIpSecVpnTunnelMetrics(vcdClient, edgeGatewayId, ipSecVpnTunnel.NsxtIpSecVpn.ID)
+	if err != nil {
+		return diag.Errorf("error reading NSX-T IPsec VPN Tunnel statistics: %s", err)
+	}
+	err = d.Set("security_association", flattenNsxtIpSecVpnTunnelSaStatistics(metrics.TunnelStatistics))
+	if err != nil {
+		return diag.Errorf("error storing NSX-T IPsec VPN Tunnel statistics to schema: %s", err)
+	}
+
+	d.SetId(ipSecVpnTunnel.NsxtIpSecVpn.ID)
+
+	return nil
+}
+
+// getNsxtIpSecVpnTunnelMetrics retrieves the Security Association statistics of an IPsec VPN Tunnel. They are not
+// available in the SDK, so they are retrieved directly from the OpenAPI endpoint
+func getNsxtIpSecVpnTunnelMetrics(vcdClient *VCDClient, edgeGatewayId, tunnelId string) (*nsxtIpSecVpnTunnelMetrics, error) {
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + nsxtIpSecVpnTunnelMetricsApiVersion) {
+		return nil, fmt.Errorf("NSX-T IPsec VPN Tunnel statistics require API %s+", nsxtIpSecVpnTunnelMetricsApiVersion)
+	}
+
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0,
+		fmt.Sprintf(nsxtIpSecVpnTunnelMetricsEndpoint, edgeGatewayId, tunnelId))
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &nsxtIpSecVpnTunnelMetrics{}
+	err = vcdClient.Client.OpenApiGetItem(nsxtIpSecVpnTunnelMetricsApiVersion, urlRef, nil, metrics, nil)
+	if err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// setNsxtIpSecVpnTunnelAlgorithmData stores the IKE version and the algorithms of the security profile that is
+// used to negotiate the tunnel, which is either the default one or the customized one
+func setNsxtIpSecVpnTunnelAlgorithmData(d *schema.ResourceData, tunnelConfig *types.NsxtIpSecVpnTunnelSecurityProfile) error {
+	dSet(d, "ike_version", tunnelConfig.IkeConfiguration.IkeVersion)
+
+	algorithms := map[string][]string{
+		"ike_encryption_algorithms":    tunnelConfig.IkeConfiguration.EncryptionAlgorithms,
+		"ike_digest_algorithms":        tunnelConfig.IkeConfiguration.DigestAlgorithms,
+		"ike_dh_groups":                tunnelConfig.IkeConfiguration.DhGroups,
+		"tunnel_encryption_algorithms": tunnelConfig.TunnelConfiguration.EncryptionAlgorithms,
+		"tunnel_digest_algorithms":     tunnelConfig.TunnelConfiguration.DigestAlgorithms,
+		"tunnel_dh_groups":             tunnelConfig.TunnelConfiguration.DhGroups,
+	}
+	for field, values := range algorithms {
+		err := d.Set(field, convertStringsToTypeSet(values))
+		if err != nil {
+			return fmt.Errorf("error storing '%s': %s", field, err)
+		}
+	}
+	return nil
+}
+
+func flattenNsxtIpSecVpnTunnelSaStatistics(statistics []nsxtIpSecVpnTunnelSaStatistics) []interface{} {
+	result := make([]interface{}, len(statistics))
+	for index, sa := range statistics {
+		result[index] = map[string]interface{}{
+			"local_subnet":                 sa.LocalSubnet,
+			"peer_subnet":                  sa.PeerSubnet,
+			"status":                       sa.TunnelStatus,
+			"down_reason":                  sa.TunnelDownReason,
+			"bytes_in":                     int(sa.BytesIn),
+			"bytes_out":                    int(sa.BytesOut),
+			"packets_in":                   int(sa.PacketsIn),
+			"packets_out":                  int(sa.PacketsOut),
+			"dropped_packets_in":           int(sa.DroppedPacketsIn),
+			"dropped_packets_out":          int(sa.DroppedPacketsOut),
+			"packets_received_other_error": int(sa.PacketsReceivedOtherError),
+			"packets_sent_other_error":     int(sa.PacketsSentOtherError),
+			"encryption_failures":          int(sa.EncryptionFailures),
+			"decryption_failures":          int(sa.DecryptionFailures),
+			"integrity_failures":           int(sa.IntegrityFailures),
+			"replay_errors":                int(sa.ReplayErrors),
+			"no_matching_policy_errors":    int(sa.NoMatchingPolicyErrors),
+			"sa_mismatch_errors_in":        int(sa.SaMismatchErrorsIn),
+			"sa_mismatch_errors_out":       int(sa.SaMismatchErrorsOut),
+			"seq_number_overflow_errors":   int(sa.SeqNumberOverflowErrors),
+		}
+	}
+	return result
+}
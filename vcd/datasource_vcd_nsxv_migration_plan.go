@@ -0,0 +1,214 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func datasourceVcdNsxvMigrationPlan() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdNsxvMigrationPlanRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"edge_gateway": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the NSX-V Edge Gateway to convert",
+			},
+			"output_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "hcl",
+				ValidateFunc: validation.StringInSlice([]string{"hcl", "json"}, false),
+				Description:  "Format of the generated configuration. One of 'hcl' (default) or 'json'",
+			},
+			"nsxt_edge_gateway_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the NSX-T Edge Gateway. Defaults to the name of the NSX-V Edge Gateway",
+			},
+			"nsxt_owner_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the NSX-T VDC or VDC Group owning the NSX-T Edge Gateway. A variable is used when not set",
+			},
+			"nsxt_external_network_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the NSX-T external network of the NSX-T Edge Gateway. A variable is used when not set",
+			},
+			"nsxt_alb_service_engine_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the ALB Service Engine Group for the converted virtual servers. A variable is used when not set",
+			},
+			"configuration": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Generated NSX-T configuration",
+			},
+			"resource_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of resources in the generated configuration",
+			},
+			"unsupported": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "NSX-V constructs which were not converted, or only partially converted",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the NSX-V construct, such as 'firewall_rule' or 'lb_pool'",
+						},
+						"source_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the NSX-V construct, if any",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the NSX-V construct",
+						},
+						"reason": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Reason why the construct was not fully converted",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdNsxvMigrationPlanRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	org, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	if err != nil {
+		return diag.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+	egw, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
+	if err != nil {
+		return diag.Errorf("[NSX-V migration plan] error retrieving Edge Gateway: %s", err)
+	}
+
+	source, err := getNsxvMigrationSource(vdc, egw)
+	if err != nil {
+		return diag.Errorf("[NSX-V migration plan] error reading Edge Gateway '%s': %s", egw.EdgeGateway.Name, err)
+	}
+	plan := buildNsxvMigrationPlan(*source, nsxvMigrationOptions{
+		Org:                  org.Org.Name,
+		EdgeGatewayName:      d.Get("nsxt_edge_gateway_name").(string),
+		OwnerId:              d.Get("nsxt_owner_id").(string),
+		ExternalNetworkId:    d.Get("nsxt_external_network_id").(string),
+		ServiceEngineGroupId: d.Get("nsxt_alb_service_engine_group_id").(string),
+	})
+
+	configuration := plan.renderHcl()
+	if d.Get("output_format").(string) == "json" {
+		configuration, err = plan.renderJson()
+		if err != nil {
+			return diag.Errorf("[NSX-V migration plan] error rendering JSON configuration: %s", err)
+		}
+	}
+	dSet(d, "configuration", configuration)
+	dSet(d, "resource_count", len(plan.Resources))
+
+	unsupported := make([]interface{}, len(plan.Unsupported))
+	for i, item := range plan.Unsupported {
+		unsupported[i] = map[string]interface{}{
+			"source_type": item.SourceType,
+			"source_id":   item.SourceId,
+			"name":        item.Name,
+			"reason":      item.Reason,
+		}
+	}
+	err = d.Set("unsupported", unsupported)
+	if err != nil {
+		return diag.Errorf("[NSX-V migration plan] error setting unsupported constructs: %s", err)
+	}
+
+	d.SetId(egw.EdgeGateway.ID)
+
+	return nil
+}
+
+// getNsxvMigrationSource reads the configuration of an NSX-V Edge Gateway and of its services
+func getNsxvMigrationSource(vdc *govcd.Vdc, egw *govcd.EdgeGateway) (*nsxvMigrationSource, error) {
+	source := &nsxvMigrationSource{EdgeGateway: egw.EdgeGateway}
+	var err error
+
+	source.FirewallRules, err = egw.GetAllNsxvFirewallRules()
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving firewall rules: %s", err)
+	}
+	firewallConfig, err := egw.GetFirewallConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving firewall configuration: %s", err)
+	}
+	if firewallConfig.Enabled {
+		source.FirewallDefaultPolicy = &firewallConfig.DefaultPolicy
+	}
+	source.NatRules, err = egw.GetNsxvNatRules()
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving NAT rules: %s", err)
+	}
+	source.IpSets, err = vdc.GetAllNsxvIpSets()
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving IP sets: %s", err)
+	}
+	source.DhcpRelay, err = egw.GetDhcpRelay()
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving DHCP relay: %s", err)
+	}
+
+	lbParams, err := egw.GetLBGeneralParams()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving load balancer settings: %s", err)
+	}
+	source.LoadBalancerEnabled = lbParams.Enabled
+	if !source.LoadBalancerEnabled {
+		return source, nil
+	}
+	source.LbVirtualServers, err = egw.GetLbVirtualServers()
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving load balancer virtual servers: %s", err)
+	}
+	source.LbPools, err = egw.GetLbServerPools()
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving load balancer server pools: %s", err)
+	}
+	source.LbAppProfiles, err = egw.GetLbAppProfiles()
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving load balancer application profiles: %s", err)
+	}
+	source.LbAppRules, err = egw.GetLbAppRules()
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving load balancer application rules: %s", err)
+	}
+	source.LbMonitors, err = egw.GetLbServiceMonitors()
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving load balancer service monitors: %s", err)
+	}
+	return source, nil
+}
@@ -0,0 +1,97 @@
+//go:build gateway || nat || ALL || functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// NSX-V based test
+func TestAccVcdNsxvMigrationPlanDS(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"EdgeGateway": testConfig.Networking.EdgeGateway,
+		"ExternalIp":  testConfig.Networking.ExternalIp,
+		"InternalIp":  testConfig.Networking.InternalIp,
+		"NetworkName": testConfig.Networking.ExternalNetwork,
+		"Tags":        "gateway nat",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdNsxvMigrationPlanDS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	if !edgeGatewayIsAdvanced(t) {
+		t.Skip(t.Name() + " requires advanced edge gateway to work")
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vcd_nsxv_migration_plan.hcl", "id", regexp.MustCompile(`^urn:vcloud:gateway:`)),
+					resource.TestMatchResourceAttr("data.vcd_nsxv_migration_plan.hcl", "configuration",
+						regexp.MustCompile(`resource "vcd_nsxt_edgegateway" "[\w-]+" {`)),
+					resource.TestMatchResourceAttr("data.vcd_nsxv_migration_plan.hcl", "configuration",
+						regexp.MustCompile(`resource "vcd_nsxt_nat_rule" "dnat_\d+" {`)),
+					resource.TestMatchResourceAttr("data.vcd_nsxv_migration_plan.hcl", "configuration",
+						regexp.MustCompile(`variable "nsxt_owner_id" {`)),
+					resource.TestMatchResourceAttr("data.vcd_nsxv_migration_plan.hcl", "resource_count", regexp.MustCompile(`^[1-9]\d*$`)),
+
+					resource.TestCheckResourceAttrPair("data.vcd_nsxv_migration_plan.hcl", "id", "data.vcd_nsxv_migration_plan.json", "id"),
+					resource.TestCheckResourceAttrPair("data.vcd_nsxv_migration_plan.hcl", "resource_count", "data.vcd_nsxv_migration_plan.json", "resource_count"),
+					resource.TestMatchResourceAttr("data.vcd_nsxv_migration_plan.json", "configuration",
+						regexp.MustCompile(`"vcd_nsxt_edgegateway":`)),
+					resource.TestMatchResourceAttr("data.vcd_nsxv_migration_plan.json", "configuration",
+						regexp.MustCompile(`"owner_id": "nsxt-owner-id"`)),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxvMigrationPlanDS = `
+resource "vcd_nsxv_dnat" "test" {
+  org          = "{{.Org}}"
+  vdc          = "{{.Vdc}}"
+  edge_gateway = "{{.EdgeGateway}}"
+
+  network_type = "ext"
+  network_name = "{{.NetworkName}}"
+
+  original_address   = "{{.ExternalIp}}"
+  translated_address = "{{.InternalIp}}"
+}
+
+data "vcd_nsxv_migration_plan" "hcl" {
+  org          = "{{.Org}}"
+  vdc          = "{{.Vdc}}"
+  edge_gateway = "{{.EdgeGateway}}"
+
+  depends_on = [vcd_nsxv_dnat.test]
+}
+
+data "vcd_nsxv_migration_plan" "json" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  edge_gateway  = "{{.EdgeGateway}}"
+  output_format = "json"
+  nsxt_owner_id = "nsxt-owner-id"
+
+  depends_on = [vcd_nsxv_dnat.test]
+}
+`
@@ -0,0 +1,154 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+const (
+	// orgLdapSearchApiVersion is the API version used to search users and groups through the LDAP connector of an Org
+	orgLdapSearchApiVersion = "36.0"
+	orgLdapSearchEndpoint   = "ldap/search/%s"
+)
+
+// orgLdapSearchResult is a user or group found by VCD in the LDAP server of an Org
+type orgLdapSearchResult struct {
+	Name              string `json:"name"`
+	FullName          string `json:"fullName"`
+	Email             string `json:"email"`
+	DistinguishedName string `json:"distinguishedName"`
+}
+
+func datasourceVcdOrgLdapSearch() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdOrgLdapSearchRead,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Organization ID",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"user", "group"}, false),
+				Description:  "What to search in LDAP. One of 'user' or 'group'",
+			},
+			"filter": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Search text, matched by VCD against the user name or group name attributes of the LDAP connector",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the matching users or groups, as used by vcd_org_user and vcd_org_group",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"result": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching users or groups",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the user or group",
+						},
+						"full_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Full name of the user",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Email address of the user",
+						},
+						"distinguished_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Distinguished name (DN) of the user or group",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdOrgLdapSearchRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	orgId := d.Get("org_id").(string)
+	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgId)
+	if err != nil {
+		return diag.Errorf("[Org LDAP search] unable to find organization %s: %s", orgId, err)
+	}
+
+	searchType := d.Get("type").(string)
+	filter := d.Get("filter").(string)
+	results, err := searchOrgLdap(vcdClient, adminOrg, searchType, filter)
+	if err != nil {
+		return diag.Errorf("[Org LDAP search] %s", err)
+	}
+
+	names := make([]string, len(results))
+	resultList := make([]interface{}, len(results))
+	for i, result := range results {
+		names[i] = result.Name
+		resultList[i] = map[string]interface{}{
+			"name":               result.Name,
+			"full_name":          result.FullName,
+			"email":              result.Email,
+			"distinguished_name": result.DistinguishedName,
+		}
+	}
+	dSet(d, "names", names)
+	err = d.Set("result", resultList)
+	if err != nil {
+		return diag.Errorf("[Org LDAP search] error setting result: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s", adminOrg.AdminOrg.ID, searchType, filter))
+	return nil
+}
+
+// searchOrgLdap returns the users or groups (depending on searchType) matching the filter in the LDAP server of the
+// given Org. The search is not available in the SDK, so it is run directly against the OpenAPI endpoint
+func searchOrgLdap(vcdClient *VCDClient, adminOrg *govcd.AdminOrg, searchType, filter string) ([]orgLdapSearchResult, error) {
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + orgLdapSearchApiVersion) {
+		return nil, fmt.Errorf("LDAP search requires API %s+", orgLdapSearchApiVersion)
+	}
+
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, fmt.Sprintf(orgLdapSearchEndpoint, searchType))
+	if err != nil {
+		return nil, err
+	}
+	queryParams := url.Values{}
+	queryParams.Set("q", filter)
+
+	// The search runs with the LDAP connector of the Org in the tenant context
+	var tenantContext map[string]string
+	if vcdClient.Client.IsSysAdmin {
+		tenantContext = map[string]string{
+			types.HeaderTenantContext: extractUuid(adminOrg.AdminOrg.ID),
+			types.HeaderAuthContext:   adminOrg.AdminOrg.Name,
+		}
+	}
+
+	var results []orgLdapSearchResult
+	err = vcdClient.Client.OpenApiGetAllItems(orgLdapSearchApiVersion, urlRef, queryParams, &results, tenantContext)
+	if err != nil {
+		return nil, fmt.Errorf("error searching LDAP %ss matching '%s' in Org %s: %s", searchType, filter, adminOrg.AdminOrg.Name, err)
+	}
+	return results, nil
+}
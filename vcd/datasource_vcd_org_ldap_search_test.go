@@ -0,0 +1,144 @@
+//go:build user || ldap || functional || ALL
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdOrgLdapSearch configures the LDAP identity provider using vcd_org_ldap, then searches users and groups
+// with vcd_org_ldap_search and imports users in bulk with the 'ldap_user_filter' of vcd_org_group. The filter and the
+// role of the group are then changed, to check that imported users are replaced and get the new role
+// Note: This test requires an existing LDAP server and its IP set in testConfig.Networking.LdapServer
+func TestAccVcdOrgLdapSearch(t *testing.T) {
+	preTestChecks(t)
+	skipIfNotSysAdmin(t)
+	skipTestForServiceAccountAndApiToken(t)
+
+	if testConfig.Networking.LdapServer == "" {
+		t.Skip("TestAccVcdOrgLdapSearch requires a working LDAP server (set the IP in testConfig.Networking.LdapServer)")
+		return
+	}
+
+	var params = StringMap{
+		"OrgName":      testConfig.VCD.Org,
+		"LdapServerIp": testConfig.Networking.LdapServer,
+		"RoleName":     govcd.OrgUserRoleVappAuthor,
+		"UserFilter":   "leela",
+		"Tags":         "user ldap",
+		"FuncName":     t.Name() + "-Step0",
+	}
+	testParamsNotEmpty(t, params)
+
+	// The LDAP search runs during plan, so the LDAP identity provider must be configured in a previous step
+	ldapSetupConfig := templateFill(testAccOrgLdap, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 0 (LDAP server configuration): %s", ldapSetupConfig)
+
+	params["FuncName"] = t.Name() + "-Step1"
+	searchConfigText := templateFill(testAccOrgLdap+testAccVcdOrgLdapSearch, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", searchConfigText)
+
+	params["FuncName"] = t.Name() + "-Step2"
+	params["UserFilter"] = "fry"
+	filterConfigText := templateFill(testAccOrgLdap+testAccVcdOrgLdapSearch, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", filterConfigText)
+
+	params["FuncName"] = t.Name() + "-Step3"
+	params["RoleName"] = govcd.OrgUserRoleCatalogAuthor
+	roleConfigText := templateFill(testAccOrgLdap+testAccVcdOrgLdapSearch, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 3: %s", roleConfigText)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	ldapResourceDef := "vcd_org_ldap." + testConfig.VCD.Org
+	// Note: don't run this test in parallel, as it would clash with TestAccVcdOrgLdap and TestAccVcdOrgGroup
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckVcdGroupDestroy("admin_staff"),
+			testAccCheckVcdUserDestroy("fry"),
+			testAccCheckOrgLdapDestroy(ldapResourceDef),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: ldapSetupConfig,
+				Check:  testAccCheckOrgLdapExists(ldapResourceDef),
+			},
+			{
+				Config: searchConfigText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.vcd_org_ldap_search.users", "names.*", "leela"),
+					resource.TestCheckResourceAttrSet("data.vcd_org_ldap_search.users", "result.0.distinguished_name"),
+					resource.TestCheckTypeSetElemAttr("data.vcd_org_ldap_search.groups", "names.*", "admin_staff"),
+
+					resource.TestCheckResourceAttr("vcd_org_group.bulk", "ldap_user_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr("vcd_org_group.bulk", "ldap_user_names.*", "leela"),
+					resource.TestCheckResourceAttr("data.vcd_org_user.imported", "name", "leela"),
+					resource.TestCheckResourceAttr("data.vcd_org_user.imported", "is_external", "true"),
+					resource.TestCheckResourceAttr("data.vcd_org_user.imported", "role", govcd.OrgUserRoleVappAuthor),
+				),
+			},
+			{
+				// The user that doesn't match the new filter is deleted, and the new one is imported
+				Config: filterConfigText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_org_group.bulk", "ldap_user_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr("vcd_org_group.bulk", "ldap_user_names.*", "fry"),
+					testAccCheckVcdUserDestroy("leela"),
+					resource.TestCheckResourceAttr("data.vcd_org_user.imported", "name", "fry"),
+					resource.TestCheckResourceAttr("data.vcd_org_user.imported", "is_external", "true"),
+					resource.TestCheckResourceAttr("data.vcd_org_user.imported", "role", govcd.OrgUserRoleVappAuthor),
+				),
+			},
+			{
+				// The imported user gets the new role of the group
+				Config: roleConfigText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_org_group.bulk", "role", govcd.OrgUserRoleCatalogAuthor),
+					resource.TestCheckResourceAttr("vcd_org_group.bulk", "ldap_user_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr("vcd_org_group.bulk", "ldap_user_names.*", "fry"),
+					resource.TestCheckResourceAttr("data.vcd_org_user.imported", "name", "fry"),
+					resource.TestCheckResourceAttr("data.vcd_org_user.imported", "role", govcd.OrgUserRoleCatalogAuthor),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdOrgLdapSearch = `
+data "vcd_org_ldap_search" "users" {
+  org_id = vcd_org_ldap.{{.OrgName}}.org_id
+  type   = "user"
+  filter = "leela"
+}
+
+data "vcd_org_ldap_search" "groups" {
+  org_id = vcd_org_ldap.{{.OrgName}}.org_id
+  type   = "group"
+  filter = "admin"
+}
+
+resource "vcd_org_group" "bulk" {
+  provider_type    = "INTEGRATED"
+  name             = "admin_staff"
+  role             = "{{.RoleName}}"
+  ldap_user_filter = "{{.UserFilter}}"
+
+  depends_on = [
+    vcd_org_ldap.{{.OrgName}}
+  ]
+}
+
+data "vcd_org_user" "imported" {
+  name = "{{.UserFilter}}"
+
+  depends_on = [vcd_org_group.bulk]
+}
+`
@@ -62,6 +62,12 @@ func datasourceVcdOrgSaml() *schema.Resource {
 				Computed:    true,
 				Description: "Optional role attribute name",
 			},
+			"signing_certificate": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Signing certificates of the identity provider, as found in the metadata stored in VCD",
+				Elem:        samlSigningCertificateSchema,
+			},
 		},
 	}
 }
@@ -0,0 +1,124 @@
+package vcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdOrgSettings() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdOrgSettingsRead,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Organization ID",
+			},
+			"email": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Email and SMTP settings of the Organization",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"use_system_default_smtp_server": { // IsDefaultSmtpServer
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the SMTP server of the system is used",
+						},
+						"use_system_default_sender": { // IsDefaultOrgEmail
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the sender address of the system is used",
+						},
+						"from_email_address": { // FromEmailAddress
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Email address of the sender of the notifications",
+						},
+						"default_subject_prefix": { // DefaultSubjectPrefix
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Prefix of the subject of the notifications",
+						},
+						"alert_all_org_admins": { // IsAlertEmailToAllAdmins
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the alerts are sent to all the Organization administrators",
+						},
+						"alert_email_to": { // AlertEmailTo
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Email addresses that receive the alerts",
+						},
+						"smtp_server": { // SmtpServerSettings
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "SMTP server used by the Organization",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"host": { // Host
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Host name or IP address of the SMTP server",
+									},
+									"port": { // Port
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "Port of the SMTP server",
+									},
+									"secure_mode": { // SecureMode
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Connection security of the SMTP server",
+									},
+									"username": { // Username
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "User name for the SMTP server authentication",
+									},
+									"password": { // Password
+										Type:        schema.TypeString,
+										Computed:    true,
+										Sensitive:   true,
+										Description: "Password for the SMTP server authentication. This value is never returned by VCD",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"password_policy": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Account lockout policy of the Organization",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_lockout_enabled": { // AccountLockoutEnabled
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether accounts are locked after too many invalid logins",
+						},
+						"invalid_logins_before_lockout": { // InvalidLoginsBeforeLockout
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of invalid logins that lock the account",
+						},
+						"account_lockout_interval_minutes": { // AccountLockoutIntervalMinutes
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Time in minutes during which the account stays locked",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdOrgSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdOrgSettingsRead(ctx, d, meta, "datasource")
+}
@@ -23,6 +23,10 @@ type resourceRef struct {
 	href         string
 	parent       string
 	importId     bool
+	// ancestors, when set, replace the ancestors of the list for this entity
+	ancestors []string
+	// importIdentifier, when set, is used in the import ID instead of the name or the ID
+	importIdentifier string
 }
 
 type vappNetworkType int
@@ -84,18 +88,27 @@ func datasourceVcdResourceList() *schema.Resource {
 				Default:     "name",
 				Description: "How the list should be built",
 				ValidateFunc: validation.StringInSlice([]string{
-					"name",      // The list will contain only the entity name
-					"id",        // The list will contain only the entity ID
-					"href",      // The list will contain only the entity HREF
-					"import",    // The list will contain the terraform import command
-					"name_id",   // The list will contain name + ID for each item
-					"hierarchy", // The list will contain parent names + resource name for each item
+					"name",         // The list will contain only the entity name
+					"id",           // The list will contain only the entity ID
+					"href",         // The list will contain only the entity HREF
+					"import",       // The list will contain the terraform import command
+					"import_block", // The list will contain the Terraform 1.5+ import block
+					"name_id",      // The list will contain name + ID for each item
+					"hierarchy",    // The list will contain parent names + resource name for each item
 				}, true),
 			},
 			"import_file_name": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "File where to store the import info - Only used with 'import' list mode ",
+				Description: "File where to store the import info - Only used with 'import' and 'import_block' list modes",
+			},
+			"recursive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "If true, also lists the resources contained in the listed ones (e.g. VDCs, vApps, VMs and networks " +
+					"of an organization). Supported for 'vcd_org', 'vcd_org_vdc', 'vcd_vdc_group', 'vcd_catalog', 'vcd_vapp', " +
+					"'vcd_edgegateway', 'vcd_nsxt_edgegateway' and 'vcd_nsxt_network_dhcp'",
 			},
 			"name_regex": {
 				Type:         schema.TypeString,
@@ -1022,6 +1035,43 @@ func vmList(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (list []s
 	return genericResourceList(d, "vcd_vm", []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
+// vmSnapshotList lists the VMs that have a snapshot. When "parent" is set, only the VMs of that vApp are checked
+func vmSnapshotList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	org, vdc, err := client.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
+	if err != nil {
+		return list, err
+	}
+
+	vappName := d.Get("parent").(string)
+	vms, err := vdc.QueryVmList(types.VmQueryFilterOnlyDeployed)
+	if err != nil {
+		return nil, err
+	}
+	var items []resourceRef
+	for _, vm := range vms {
+		if vappName != "" && vappName != vm.ContainerName {
+			continue
+		}
+		snapshot, err := getCurrentSnapshot(client, &snapshotTarget{href: vm.HREF})
+		if err != nil {
+			return nil, err
+		}
+		if snapshot == nil {
+			continue
+		}
+		// Without 'parent', the VMs come from different vApps
+		items = append(items, resourceRef{
+			name:      vm.Name,
+			id:        "urn:vcloud:vm:" + extractUuid(vm.HREF),
+			href:      vm.HREF,
+			ancestors: []string{org.Org.Name, vdc.Vdc.Name, vm.ContainerName},
+		})
+	}
+	return genericResourceList(d, "vcd_vm_snapshot", []string{org.Org.Name, vdc.Vdc.Name, vappName}, items)
+}
+
 func vappNetworkList(d *schema.ResourceData, vnt vappNetworkType, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
@@ -1116,7 +1166,6 @@ func genericResourceList(d *schema.ResourceData, resType string, ancestors []str
 	importFile := d.Get("import_file_name").(string)
 	nameRegex := d.Get("name_regex").(string)
 	var importData strings.Builder
-	importData.WriteString(fmt.Sprintf("# Generated by vcd_resource_list - %s\n", time.Now().Format(time.RFC3339)))
 	var reName *regexp.Regexp
 	if nameRegex != "" {
 		reName, err = regexp.Compile(nameRegex)
@@ -1130,6 +1179,10 @@ func genericResourceList(d *schema.ResourceData, resType string, ancestors []str
 		if ref.resourceType != "" {
 			resourceType = ref.resourceType
 		}
+		refAncestors := ancestors
+		if ref.ancestors != nil {
+			refAncestors = ref.ancestors
+		}
 		if reName != nil {
 			// If the regular expression doesn't match, the resource is skipped from the list
 			if reName.FindString(ref.name) == "" {
@@ -1145,45 +1198,32 @@ func genericResourceList(d *schema.ResourceData, resType string, ancestors []str
 			list = append(list, ref.name+nameIdSeparator+ref.id)
 		case "hierarchy":
 			if ref.parent != "" {
-				list = append(list, strings.Join(ancestors, nameIdSeparator)+
+				list = append(list, strings.Join(refAncestors, nameIdSeparator)+
 					nameIdSeparator+ref.parent+
 					nameIdSeparator+ref.name)
 			} else {
-				list = append(list, strings.Join(ancestors, nameIdSeparator)+nameIdSeparator+ref.name)
+				list = append(list, strings.Join(refAncestors, nameIdSeparator)+nameIdSeparator+ref.name)
 			}
 		case "href":
 			list = append(list, ref.href)
 		case "import":
-			identifier := ref.name
-			if ref.importId {
-				identifier = ref.id
-			}
+			identifier := resourceRefImportIdentifier(ref)
 			list = append(list, fmt.Sprintf("terraform import %s.%s %s%s%s",
 				resourceType,
 				ref.name,
-				strings.Join(ancestors, ImportSeparator),
+				strings.Join(refAncestors, ImportSeparator),
 				ImportSeparator,
 				identifier))
-
-			ancestorsText := ""
-			if len(ancestors) > 0 {
-				ancestorsText = strings.Join(ancestors, ImportSeparator) + ImportSeparator
-			}
-			importData.WriteString(fmt.Sprintf("# Import directive for %s %s%s \n", resourceType, ancestorsText, ref.name))
-			importData.WriteString("import {\n")
-			importData.WriteString(fmt.Sprintf("  to = %s.%s-%s\n", resourceType, ref.name, idTail(ref.id)))
-			if len(ancestors) > 0 {
-				importData.WriteString(fmt.Sprintf("  id = \"%s%s%s\"\n",
-					strings.Join(ancestors, ImportSeparator), ImportSeparator, identifier))
-			} else {
-				importData.WriteString(fmt.Sprintf("  id = \"%s\"\n", identifier))
-			}
-			importData.WriteString("}\n\n")
+			importData.WriteString(importBlock(resourceType, refAncestors, ref))
+		case "import_block":
+			block := importBlock(resourceType, refAncestors, ref)
+			list = append(list, block)
+			importData.WriteString(block)
 		}
 	}
 
-	if importFile != "" && listMode == "import" {
-		err = os.WriteFile(importFile, []byte(importData.String()), 0600)
+	if importFile != "" && (listMode == "import" || listMode == "import_block") {
+		err = writeImportFile(importFile, importData.String())
 		if err != nil {
 			return nil, err
 		}
@@ -1191,6 +1231,50 @@ func genericResourceList(d *schema.ResourceData, resType string, ancestors []str
 	return list, nil
 }
 
+// importBlock returns the Terraform 1.5+ import block for the given resource
+func importBlock(resourceType string, ancestors []string, ref resourceRef) string {
+	identifier := resourceRefImportIdentifier(ref)
+	ancestorsText := ""
+	if len(ancestors) > 0 {
+		ancestorsText = strings.Join(ancestors, ImportSeparator) + ImportSeparator
+	}
+	var block strings.Builder
+	block.WriteString(fmt.Sprintf("# Import directive for %s %s%s \n", resourceType, ancestorsText, ref.name))
+	block.WriteString("import {\n")
+	block.WriteString(fmt.Sprintf("  to = %s.%s\n", resourceType, importResourceName(ref.name, ref.id)))
+	block.WriteString(fmt.Sprintf("  id = \"%s%s\"\n", ancestorsText, identifier))
+	block.WriteString("}\n\n")
+	return block.String()
+}
+
+// resourceRefImportIdentifier returns the identifier of the resource in its import ID
+func resourceRefImportIdentifier(ref resourceRef) string {
+	switch {
+	case ref.importIdentifier != "":
+		return ref.importIdentifier
+	case ref.importId:
+		return ref.id
+	default:
+		return ref.name
+	}
+}
+
+// importResourceName returns a valid Terraform resource name made of the entity name and the tail of its ID.
+// Characters not allowed in a Terraform identifier are replaced by underscores
+func importResourceName(name, id string) string {
+	resourceName := regexp.MustCompile(`[^a-zA-Z0-9_-]`).ReplaceAllString(name, "_") + "-" + idTail(id)
+	if !regexp.MustCompile(`^[a-zA-Z_]`).MatchString(resourceName) {
+		resourceName = "_" + resourceName
+	}
+	return resourceName
+}
+
+// writeImportFile writes the given import blocks to fileName, preceded by a header
+func writeImportFile(fileName, importBlocks string) error {
+	header := fmt.Sprintf("# Generated by vcd_resource_list - %s\n", time.Now().Format(time.RFC3339))
+	return os.WriteFile(fileName, []byte(header+importBlocks), 0600)
+}
+
 func idTail(id string) string {
 	if id == "" {
 		return ""
@@ -1376,7 +1460,7 @@ func ipsetList(d *schema.ResourceData, meta interface{}) (list []string, err err
 			parent: vdc.Vdc.Name,
 		})
 	}
-	return genericResourceList(d, "vcd_ipset", []string{org.Org.Name, vdc.Vdc.Name}, items)
+	return genericResourceList(d, "vcd_nsxv_ip_set", []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
 func nsxvNatRuleList(natType string, d *schema.ResourceData, meta interface{}) (list []string, err error) {
@@ -1404,145 +1488,1539 @@ func nsxvNatRuleList(natType string, d *schema.ResourceData, meta interface{}) (
 	return genericResourceList(d, "vcd_lb_app_profile", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
 }
 
-func getResourcesList() ([]string, error) {
-	var list []string
-	resources := globalResourceMap
-	for resource := range resources {
-		list = append(list, resource)
+// getNsxtEdgeGatewayDetails retrieves the NSX-T edge gateway named in "parent", which belongs to the VDC or VDC group named in "vdc"
+func getNsxtEdgeGatewayDetails(d *schema.ResourceData, meta interface{}) (orgName string, vdcOrVdcGroupName string, egw *govcd.NsxtEdgeGateway, err error) {
+	client := meta.(*VCDClient)
+
+	adminOrg, err := client.GetAdminOrgFromResource(d)
+	if err != nil {
+		return "", "", nil, err
 	}
-	// Returns the list of resources in alphabetical order, to keep a consistent state
-	sort.Strings(list)
-	return list, nil
+	vdcOrVdcGroupName = d.Get("vdc").(string)
+	if vdcOrVdcGroupName == "" {
+		vdcOrVdcGroupName = client.Vdc
+	}
+	edgeGatewayName := d.Get("parent").(string)
+	if edgeGatewayName == "" {
+		return "", "", nil, fmt.Errorf(`edge gateway name (as "parent") is required for this task`)
+	}
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(client, adminOrg.AdminOrg.Name, vdcOrVdcGroupName)
+	if err != nil {
+		return "", "", nil, err
+	}
+	egw, err = vdcOrVdcGroup.GetNsxtEdgeGatewayByName(edgeGatewayName)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("error retrieving NSX-T edge gateway '%s': %s", edgeGatewayName, err)
+	}
+	return adminOrg.AdminOrg.Name, vdcOrVdcGroupName, egw, nil
 }
 
-func datasourceVcdResourceListRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func nsxtNatRuleList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	orgName, vdcOrVdcGroupName, edgeGateway, err := getNsxtEdgeGatewayDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
 
-	requested := d.Get("resource_type").(string)
-	var err error
-	var list []string
-	switch requested {
-	// Note: do not try to get the data sources list, as it would result in a circular reference
-	case "resource", "resources":
-		list, err = getResourcesList()
-	case "vcd_multisite_site_association":
-		list, err = getSiteAssociationList(d, meta, "vcd_multisite_site_association")
-	case "vcd_multisite_org_association":
-		list, err = getOrgAssociationList(d, meta, "vcd_multisite_org_association")
-	case "vcd_org", "org", "orgs":
-		list, err = getOrgList(d, meta, "vcd_org")
-	case "vcd_org_ldap", "vcd_org_saml":
-		list, err = getOrgList(d, meta, requested)
-	case "vcd_provider_vdc", "provider_vdc":
-		list, err = getPvdcList(d, meta)
-	case "vcd_distributed_switch":
-		list, err = distributedSwitchList(d, meta)
-	case "vcd_nsxt_transport_zone":
-		list, err = transportZoneList(d, meta)
-	case "vcd_importable_port_group":
-		list, err = importablePortGroupList(d, meta)
-	case "vcd_network_pool":
-		list, err = networkPoolList(d, meta)
-	case "vcd_vcenter":
-		list, err = vcenterList(d, meta)
-	case "vcd_nsxt_manager":
-		list, err = nsxtManagerList(d, meta)
-	case "vcd_external_network", "external_network", "external_networks":
-		list, err = externalNetworkList(d, meta)
-	case "vcd_org_vdc", "vdc", "vdcs":
-		list, err = vdcList(d, meta, "vcd_org_vdc")
-	case "vcd_vdc_group":
-		list, err = getVdcGroups(d, meta)
-	case "vcd_org_vdc_access_control":
-		list, err = vdcList(d, meta, "vcd_org_vdc_access_control")
-	case "vcd_catalog", "catalog", "catalogs", "vcd_subscribed_catalog":
-		list, err = catalogList(d, meta, "vcd_catalog")
-	case "vcd_catalog_access_control":
-		list, err = catalogList(d, meta, "vcd_catalog_access_control")
-	case "vcd_catalog_item", "catalog_item", "catalog_items", "catalogitem", "catalogitems":
-		list, err = catalogItemList(d, meta, "vcd_catalog_item")
-	case "vcd_catalog_vapp_template", "vapp_template":
-		list, err = vappTemplateList(d, meta)
-	case "vcd_catalog_media", "catalog_media", "media_items", "mediaitems", "mediaitem":
-		list, err = catalogItemList(d, meta, "vcd_catalog_media")
-	case "vcd_independent_disk", "disk", "disks":
-		list, err = diskList(d, meta)
-	case "vcd_vapp", "vapp", "vapps", "vcd_cloned_vapp":
-		list, err = vappList(d, meta, "vcd_vapp")
-	case "vcd_vapp_access_control":
-		list, err = vappList(d, meta, "vcd_vapp_access_control")
-	case "vcd_vapp_vm", "vapp_vm", "vapp_vms":
-		list, err = vmList(d, meta, vappVmType)
-	case "vcd_vapp_network", "vapp_network", "vapp_networks":
-		list, err = vappNetworkList(d, vntVappNetwork, meta)
-	case "vcd_vapp_org_network", "vapp_org_network", "vapp_org_networks":
-		list, err = vappNetworkList(d, vntVappOrgNetwork, meta)
-	case "vcd_vapp_all_network", "vapp_all_network", "vapp_all_networks":
-		list, err = vappNetworkList(d, vntVappAllNetworks, meta)
-	case "vcd_vm", "standalone_vm":
-		list, err = vmList(d, meta, standaloneVmType)
-	case "vcd_all_vm", "vm", "vms":
-		list, err = vmList(d, meta, "all")
-	case "vcd_org_user", "org_user", "user", "users":
-		list, err = orgUserList(d, meta)
-	case "vcd_edgegateway", "edge_gateway", "edge", "edgegateway":
-		list, err = getEdgeGatewayList(d, meta, "vcd_edgegateway")
-	case "vcd_edgegateway_settings":
-		list, err = getEdgeGatewayList(d, meta, "vcd_edgegateway_settings")
-	case "vcd_nsxt_edgegateway", "nsxt_edge_gateway", "nsxt_edge", "nsxt_edgegateway":
-		list, err = getNsxtEdgeGatewayList(d, meta)
-	case "vcd_lb_server_pool", "lb_server_pool":
-		list, err = lbServerPoolList(d, meta)
-	case "vcd_lb_service_monitor", "lb_service_monitor":
-		list, err = lbServiceMonitorList(d, meta)
-	case "vcd_lb_virtual_server", "lb_virtual_server":
-		list, err = lbVirtualServerList(d, meta)
-	case "vcd_lb_app_rule", "lb_app_rule":
-		list, err = lbAppRuleList(d, meta)
-	case "vcd_lb_app_profile", "lb_app_profile":
-		list, err = lbAppProfileList(d, meta)
-	case "vcd_nsxv_firewall_rule", "nsxv_firewall_rule":
-		list, err = nsxvFirewallList(d, meta)
-	case "vcd_ipset", "ipset":
-		list, err = ipsetList(d, meta)
-	case "vcd_nsxv_dnat", "nsxv_dnat":
-		list, err = nsxvNatRuleList("dnat", d, meta)
-	case "vcd_nsxv_snat", "nsxv_snat":
-		list, err = nsxvNatRuleList("snat", d, meta)
-	case "vcd_network_isolated", "vcd_network_direct", "vcd_network_routed",
-		"network", "networks", "network_direct", "network_routed", "network_isolated":
-		list, err = networkList(d, meta)
-	case "vcd_network_routed_v2", "vcd_network_isolated_v2", "vcd_nsxt_network_imported":
-		list, err = orgNetworkListV2(d, meta)
-	case "vcd_right", "rights":
-		list, err = rightsList(d, meta)
-	case "vcd_rights_bundle", "rights_bundle":
-		list, err = rightsBundlesList(d, meta)
-	case "vcd_role", "roles":
-		list, err = rolesList(d, meta)
-	case "vcd_global_role", "global_roles":
-		list, err = globalRolesList(d, meta)
-	case "vcd_library_certificate":
-		list, err = libraryCertificateList(d, meta)
-	case "vcd_org_vdc_template":
-		list, err = vdcTemplateList(d, meta)
+	natRules, err := edgeGateway.GetAllNatRules(nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, rule := range natRules {
+		items = append(items, resourceRef{
+			name:     rule.NsxtNatRule.Name,
+			id:       rule.NsxtNatRule.ID,
+			parent:   edgeGateway.EdgeGateway.Name,
+			importId: true, // NAT rule names are not unique
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_nat_rule", []string{orgName, vdcOrVdcGroupName, edgeGateway.EdgeGateway.Name}, items)
+}
 
-		//// place holder to remind of what needs to be implemented
-		//	case "edgegateway_vpn",
-		//		"independent_disk",
-		//		"inserted_media":
-		//		list, err = []string{"not implemented yet"}, nil
-	default:
-		return diag.FromErr(fmt.Errorf("unhandled resource type '%s'", requested))
+// nsxtFirewallList returns the firewall of an NSX-T edge gateway, which holds all its firewall rules
+func nsxtFirewallList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	orgName, vdcOrVdcGroupName, edgeGateway, err := getNsxtEdgeGatewayDetails(d, meta)
+	if err != nil {
+		return list, err
 	}
 
+	fwRules, err := edgeGateway.GetNsxtFirewall()
 	if err != nil {
-		return diag.FromErr(err)
+		return list, err
 	}
-	err = d.Set("list", list)
+	var items []resourceRef
+	if fwRules.NsxtFirewallRuleContainer != nil && len(fwRules.NsxtFirewallRuleContainer.UserDefinedRules) > 0 {
+		items = append(items, resourceRef{
+			name:   edgeGateway.EdgeGateway.Name,
+			id:     edgeGateway.EdgeGateway.ID,
+			parent: vdcOrVdcGroupName,
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_firewall", []string{orgName, vdcOrVdcGroupName}, items)
+}
+
+// nsxtEdgeGatewayChildList lists the resources of the given type contained in the NSX-T edge gateway named in "parent".
+// getRefs retrieves them from the edge gateway
+func nsxtEdgeGatewayChildList(d *schema.ResourceData, meta interface{}, resourceType string,
+	getRefs func(client *VCDClient, egw *govcd.NsxtEdgeGateway) ([]resourceRef, error)) ([]string, error) {
+	orgName, vdcOrVdcGroupName, edgeGateway, err := getNsxtEdgeGatewayDetails(d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, err
 	}
-	d.SetId(d.Get("name").(string))
+	items, err := getRefs(meta.(*VCDClient), edgeGateway)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].parent = edgeGateway.EdgeGateway.Name
+	}
+	return genericResourceList(d, resourceType, []string{orgName, vdcOrVdcGroupName, edgeGateway.EdgeGateway.Name}, items)
+}
 
-	return diag.Diagnostics{}
+// nsxtEdgeGatewaySettingList lists the NSX-T edge gateway named in "parent" as a resource of the given type, which
+// configures a feature of the whole edge gateway. The edge gateway is only listed when isConfigured is true
+func nsxtEdgeGatewaySettingList(d *schema.ResourceData, meta interface{}, resourceType string,
+	isConfigured func(egw *govcd.NsxtEdgeGateway) (bool, error)) ([]string, error) {
+	orgName, vdcOrVdcGroupName, edgeGateway, err := getNsxtEdgeGatewayDetails(d, meta)
+	if err != nil {
+		return nil, err
+	}
+	configured, err := isConfigured(edgeGateway)
+	if err != nil {
+		return nil, err
+	}
+	var items []resourceRef
+	if configured {
+		items = append(items, resourceRef{
+			name:   edgeGateway.EdgeGateway.Name,
+			id:     edgeGateway.EdgeGateway.ID,
+			parent: vdcOrVdcGroupName,
+		})
+	}
+	return genericResourceList(d, resourceType, []string{orgName, vdcOrVdcGroupName}, items)
+}
+
+func nsxtFirewallGroupList(d *schema.ResourceData, meta interface{}, resourceType, firewallGroupType string) ([]string, error) {
+	return nsxtEdgeGatewayChildList(d, meta, resourceType, func(_ *VCDClient, egw *govcd.NsxtEdgeGateway) ([]resourceRef, error) {
+		firewallGroups, err := egw.GetAllNsxtFirewallGroups(nil, firewallGroupType)
+		if err != nil {
+			return nil, err
+		}
+		var items []resourceRef
+		for _, firewallGroup := range firewallGroups {
+			items = append(items, resourceRef{
+				name: firewallGroup.NsxtFirewallGroup.Name,
+				id:   firewallGroup.NsxtFirewallGroup.ID,
+			})
+		}
+		return items, nil
+	})
+}
+
+func nsxtAlbPoolList(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	return nsxtEdgeGatewayChildList(d, meta, "vcd_nsxt_alb_pool", func(client *VCDClient, egw *govcd.NsxtEdgeGateway) ([]resourceRef, error) {
+		pools, err := client.GetAllAlbPoolSummaries(egw.EdgeGateway.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		var items []resourceRef
+		for _, pool := range pools {
+			items = append(items, resourceRef{
+				name: pool.NsxtAlbPool.Name,
+				id:   pool.NsxtAlbPool.ID,
+			})
+		}
+		return items, nil
+	})
+}
+
+func nsxtAlbVirtualServiceList(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	return nsxtEdgeGatewayChildList(d, meta, "vcd_nsxt_alb_virtual_service", func(client *VCDClient, egw *govcd.NsxtEdgeGateway) ([]resourceRef, error) {
+		virtualServices, err := client.GetAllAlbVirtualServiceSummaries(egw.EdgeGateway.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		var items []resourceRef
+		for _, virtualService := range virtualServices {
+			items = append(items, resourceRef{
+				name: virtualService.NsxtAlbVirtualService.Name,
+				id:   virtualService.NsxtAlbVirtualService.ID,
+			})
+		}
+		return items, nil
+	})
+}
+
+// nsxtAlbVirtualServiceHttpPolicyList lists the NSX-T ALB Virtual Services that have rules in the given HTTP policy
+func nsxtAlbVirtualServiceHttpPolicyList(d *schema.ResourceData, meta interface{}, resourceType, endpoint string) ([]string, error) {
+	return nsxtEdgeGatewayChildList(d, meta, resourceType, func(client *VCDClient, egw *govcd.NsxtEdgeGateway) ([]resourceRef, error) {
+		if client.Client.APIVCDMaxVersionIs("< " + albVsHttpPolicyApiVersion) {
+			return nil, nil
+		}
+		virtualServices, err := client.GetAllAlbVirtualServiceSummaries(egw.EdgeGateway.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		var items []resourceRef
+		for _, virtualService := range virtualServices {
+			policy := struct {
+				Values []interface{} `json:"values"`
+			}{}
+			err = getAlbVsHttpPolicy(client, virtualService.NsxtAlbVirtualService.ID, endpoint, &policy)
+			if err != nil {
+				return nil, err
+			}
+			if len(policy.Values) == 0 {
+				continue
+			}
+			items = append(items, resourceRef{
+				name: virtualService.NsxtAlbVirtualService.Name,
+				id:   virtualService.NsxtAlbVirtualService.ID,
+			})
+		}
+		return items, nil
+	})
+}
+
+func nsxtAlbEdgeGatewayServiceEngineGroupList(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	return nsxtEdgeGatewayChildList(d, meta, "vcd_nsxt_alb_edgegateway_service_engine_group", func(client *VCDClient, egw *govcd.NsxtEdgeGateway) ([]resourceRef, error) {
+		queryParams := url.Values{}
+		queryParams.Add("filter", fmt.Sprintf("gatewayRef.id==%s", egw.EdgeGateway.ID))
+		assignments, err := client.GetAllAlbServiceEngineGroupAssignments(queryParams)
+		if err != nil {
+			return nil, err
+		}
+		var items []resourceRef
+		for _, assignment := range assignments {
+			items = append(items, resourceRef{
+				name: assignment.NsxtAlbServiceEngineGroupAssignment.ServiceEngineGroupRef.Name,
+				id:   assignment.NsxtAlbServiceEngineGroupAssignment.ID,
+			})
+		}
+		return items, nil
+	})
+}
+
+func nsxtStaticRouteList(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	return nsxtEdgeGatewayChildList(d, meta, "vcd_nsxt_edgegateway_static_route", func(_ *VCDClient, egw *govcd.NsxtEdgeGateway) ([]resourceRef, error) {
+		staticRoutes, err := egw.GetAllStaticRoutes(nil)
+		if err != nil {
+			return nil, err
+		}
+		var items []resourceRef
+		for _, staticRoute := range staticRoutes {
+			items = append(items, resourceRef{
+				name: staticRoute.NsxtEdgeGatewayStaticRoute.Name,
+				id:   staticRoute.NsxtEdgeGatewayStaticRoute.ID,
+			})
+		}
+		return items, nil
+	})
+}
+
+func nsxtBgpNeighborList(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	return nsxtEdgeGatewayChildList(d, meta, "vcd_nsxt_edgegateway_bgp_neighbor", func(_ *VCDClient, egw *govcd.NsxtEdgeGateway) ([]resourceRef, error) {
+		neighbors, err := egw.GetAllBgpNeighbors(nil)
+		if err != nil {
+			return nil, err
+		}
+		var items []resourceRef
+		for _, neighbor := range neighbors {
+			// BGP neighbors are identified by their IP address
+			items = append(items, resourceRef{
+				name: neighbor.EdgeBgpNeighbor.NeighborAddress,
+				id:   neighbor.EdgeBgpNeighbor.ID,
+			})
+		}
+		return items, nil
+	})
+}
+
+func nsxtBgpIpPrefixListList(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	return nsxtEdgeGatewayChildList(d, meta, "vcd_nsxt_edgegateway_bgp_ip_prefix_list", func(_ *VCDClient, egw *govcd.NsxtEdgeGateway) ([]resourceRef, error) {
+		prefixLists, err := egw.GetAllBgpIpPrefixLists(nil)
+		if err != nil {
+			return nil, err
+		}
+		var items []resourceRef
+		for _, prefixList := range prefixLists {
+			items = append(items, resourceRef{
+				name: prefixList.EdgeBgpIpPrefixList.Name,
+				id:   prefixList.EdgeBgpIpPrefixList.ID,
+			})
+		}
+		return items, nil
+	})
+}
+
+func nsxtIpSecVpnTunnelList(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	return nsxtEdgeGatewayChildList(d, meta, "vcd_nsxt_ipsec_vpn_tunnel", func(_ *VCDClient, egw *govcd.NsxtEdgeGateway) ([]resourceRef, error) {
+		tunnels, err := egw.GetAllIpSecVpnTunnels(nil)
+		if err != nil {
+			return nil, err
+		}
+		var items []resourceRef
+		for _, tunnel := range tunnels {
+			items = append(items, resourceRef{
+				name:     tunnel.NsxtIpSecVpn.Name,
+				id:       tunnel.NsxtIpSecVpn.ID,
+				importId: true, // IPsec VPN tunnel names are not unique
+			})
+		}
+		return items, nil
+	})
+}
+
+func nsxtL2VpnTunnelList(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	return nsxtEdgeGatewayChildList(d, meta, "vcd_nsxt_edgegateway_l2_vpn_tunnel", func(_ *VCDClient, egw *govcd.NsxtEdgeGateway) ([]resourceRef, error) {
+		tunnels, err := egw.GetAllL2VpnTunnels(nil)
+		if err != nil {
+			return nil, err
+		}
+		var items []resourceRef
+		for _, tunnel := range tunnels {
+			items = append(items, resourceRef{
+				name: tunnel.NsxtL2VpnTunnel.Name,
+				id:   tunnel.NsxtL2VpnTunnel.ID,
+			})
+		}
+		return items, nil
+	})
+}
+
+// getVdcOrVdcGroupForList retrieves the VDC or VDC group named in "parent" or, when it is empty, in "vdc".
+// Only one of the returned VDC and VDC group is set
+func getVdcOrVdcGroupForList(d *schema.ResourceData, meta interface{}) (*govcd.AdminOrg, *govcd.Vdc, *govcd.VdcGroup, error) {
+	client := meta.(*VCDClient)
+	adminOrg, err := client.GetAdminOrgFromResource(d)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	vdcOrVdcGroupName := firstNonEmpty(d.Get("parent").(string), d.Get("vdc").(string), client.Vdc)
+	if vdcOrVdcGroupName == "" {
+		return nil, nil, nil, fmt.Errorf("VDC or VDC group name not given either as 'vdc' or 'parent' field")
+	}
+	vdc, err := adminOrg.GetVDCByName(vdcOrVdcGroupName, false)
+	if err == nil {
+		return adminOrg, vdc, nil, nil
+	}
+	if !govcd.ContainsNotFound(err) {
+		return nil, nil, nil, fmt.Errorf("error retrieving VDC '%s': %s", vdcOrVdcGroupName, err)
+	}
+	vdcGroup, err := adminOrg.GetVdcGroupByName(vdcOrVdcGroupName)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("neither a VDC or a VDC group found with name '%s': %s", vdcOrVdcGroupName, err)
+	}
+	return adminOrg, nil, vdcGroup, nil
+}
+
+// getVdcGroupForList retrieves the VDC group named in "parent" or, when it is empty, in "vdc"
+func getVdcGroupForList(d *schema.ResourceData, meta interface{}, resourceType string) (*govcd.AdminOrg, *govcd.VdcGroup, error) {
+	adminOrg, vdc, vdcGroup, err := getVdcOrVdcGroupForList(d, meta)
+	if err != nil {
+		return nil, nil, err
+	}
+	if vdc != nil {
+		return nil, nil, fmt.Errorf("'%s' belongs to VDC groups, while '%s' is a VDC", resourceType, vdc.Vdc.Name)
+	}
+	return adminOrg, vdcGroup, nil
+}
+
+func nsxtAppPortProfileList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	adminOrg, vdc, vdcGroup, err := getVdcOrVdcGroupForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	org, err := client.GetOrgByName(adminOrg.AdminOrg.Name)
+	if err != nil {
+		return list, err
+	}
+	contextName, contextId := "", ""
+	if vdc != nil {
+		contextName, contextId = vdc.Vdc.Name, vdc.Vdc.ID
+	} else {
+		contextName, contextId = vdcGroup.VdcGroup.Name, vdcGroup.VdcGroup.Id
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("filter", "_context=="+contextId)
+	appPortProfiles, err := org.GetAllNsxtAppPortProfiles(queryParams, types.ApplicationPortProfileScopeTenant)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, appPortProfile := range appPortProfiles {
+		items = append(items, resourceRef{
+			name:   appPortProfile.NsxtAppPortProfile.Name,
+			id:     appPortProfile.NsxtAppPortProfile.ID,
+			parent: contextName,
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_app_port_profile", []string{adminOrg.AdminOrg.Name, contextName}, items)
+}
+
+// nsxtNetworkDhcpList lists the NSX-T Org VDC networks of a VDC or VDC group that have DHCP configured
+func nsxtNetworkDhcpList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	adminOrg, vdc, vdcGroup, err := getVdcOrVdcGroupForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	var networks []*govcd.OpenApiOrgVdcNetwork
+	var parentName string
+	if vdc != nil {
+		parentName = vdc.Vdc.Name
+		networks, err = vdc.GetAllOpenApiOrgVdcNetworks(nil)
+	} else {
+		parentName = vdcGroup.VdcGroup.Name
+		networks, err = vdcGroup.GetAllOpenApiOrgVdcNetworks(nil)
+	}
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, network := range networks {
+		// Imported networks don't have DHCP
+		if network.OpenApiOrgVdcNetwork.NetworkType == types.OrgVdcNetworkTypeOpaque {
+			continue
+		}
+		dhcp, err := network.GetOpenApiOrgVdcNetworkDhcp()
+		if err != nil {
+			return list, fmt.Errorf("error retrieving DHCP of network '%s': %s", network.OpenApiOrgVdcNetwork.Name, err)
+		}
+		if len(dhcp.OpenApiOrgVdcNetworkDhcp.DhcpPools) == 0 &&
+			(dhcp.OpenApiOrgVdcNetworkDhcp.Enabled == nil || !*dhcp.OpenApiOrgVdcNetworkDhcp.Enabled) {
+			continue
+		}
+		items = append(items, resourceRef{
+			name:   network.OpenApiOrgVdcNetwork.Name,
+			id:     network.OpenApiOrgVdcNetwork.ID,
+			parent: parentName,
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_network_dhcp", []string{adminOrg.AdminOrg.Name, parentName}, items)
+}
+
+// nsxtNetworkDhcpBindingList lists the DHCP bindings of the network named in "parent", which belongs to the VDC or
+// VDC group named in "vdc"
+func nsxtNetworkDhcpBindingList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	adminOrg, err := client.GetAdminOrgFromResource(d)
+	if err != nil {
+		return list, err
+	}
+	vdcOrVdcGroupName := firstNonEmpty(d.Get("vdc").(string), client.Vdc)
+	networkName := d.Get("parent").(string)
+	if networkName == "" {
+		return list, fmt.Errorf(`network name (as "parent") is required for this task`)
+	}
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(client, adminOrg.AdminOrg.Name, vdcOrVdcGroupName)
+	if err != nil {
+		return list, err
+	}
+	network, err := vdcOrVdcGroup.GetOpenApiOrgVdcNetworkByName(networkName)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving network '%s': %s", networkName, err)
+	}
+	bindings, err := network.GetAllOpenApiOrgVdcNetworkDhcpBindings(nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, binding := range bindings {
+		items = append(items, resourceRef{
+			name:   binding.OpenApiOrgVdcNetworkDhcpBinding.Name,
+			id:     binding.OpenApiOrgVdcNetworkDhcpBinding.ID,
+			parent: networkName,
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_network_dhcp_binding", []string{adminOrg.AdminOrg.Name, vdcOrVdcGroupName, networkName}, items)
+}
+
+func nsxtDynamicSecurityGroupList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	adminOrg, vdcGroup, err := getVdcGroupForList(d, meta, "vcd_nsxt_dynamic_security_group")
+	if err != nil {
+		return list, err
+	}
+	org, err := client.GetOrgByName(adminOrg.AdminOrg.Name)
+	if err != nil {
+		return list, err
+	}
+	queryParams := url.Values{}
+	queryParams.Add("filter", "ownerRef.id=="+vdcGroup.VdcGroup.Id)
+	securityGroups, err := org.GetAllNsxtFirewallGroups(queryParams, types.FirewallGroupTypeVmCriteria)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, securityGroup := range securityGroups {
+		items = append(items, resourceRef{
+			name:   securityGroup.NsxtFirewallGroup.Name,
+			id:     securityGroup.NsxtFirewallGroup.ID,
+			parent: vdcGroup.VdcGroup.Name,
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_dynamic_security_group", []string{adminOrg.AdminOrg.Name, vdcGroup.VdcGroup.Name}, items)
+}
+
+// nsxtDistributedFirewallList returns the distributed firewall of a VDC group, which holds all its rules
+func nsxtDistributedFirewallList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	adminOrg, vdcGroup, err := getVdcGroupForList(d, meta, "vcd_nsxt_distributed_firewall")
+	if err != nil {
+		return list, err
+	}
+	firewall, err := vdcGroup.GetDistributedFirewall()
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	if firewall.DistributedFirewallRuleContainer != nil && len(firewall.DistributedFirewallRuleContainer.Values) > 0 {
+		items = append(items, resourceRef{
+			name:   vdcGroup.VdcGroup.Name,
+			id:     vdcGroup.VdcGroup.Id,
+			parent: adminOrg.AdminOrg.Name,
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_distributed_firewall", []string{adminOrg.AdminOrg.Name}, items)
+}
+
+func nsxtDistributedFirewallRuleList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	adminOrg, vdcGroup, err := getVdcGroupForList(d, meta, "vcd_nsxt_distributed_firewall_rule")
+	if err != nil {
+		return list, err
+	}
+	firewall, err := vdcGroup.GetDistributedFirewall()
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	if firewall.DistributedFirewallRuleContainer != nil {
+		for _, rule := range firewall.DistributedFirewallRuleContainer.Values {
+			items = append(items, resourceRef{
+				name:   rule.Name,
+				id:     rule.ID,
+				parent: vdcGroup.VdcGroup.Name,
+			})
+		}
+	}
+	return genericResourceList(d, "vcd_nsxt_distributed_firewall_rule", []string{adminOrg.AdminOrg.Name, vdcGroup.VdcGroup.Name}, items)
+}
+
+func orgGroupList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	adminOrg, err := client.GetAdminOrgFromResource(d)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	if adminOrg.AdminOrg.Groups != nil {
+		for _, group := range adminOrg.AdminOrg.Groups.Group {
+			items = append(items, resourceRef{
+				name:   group.Name,
+				id:     group.ID,
+				href:   group.HREF,
+				parent: adminOrg.AdminOrg.Name,
+			})
+		}
+	}
+	return genericResourceList(d, "vcd_org_group", []string{adminOrg.AdminOrg.Name}, items)
+}
+
+func securityTagList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	org, err := client.GetOrgFromResource(d)
+	if err != nil {
+		return list, err
+	}
+	tags, err := org.GetAllSecurityTagValues(nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, tag := range tags {
+		// Security tags have no ID: the tag itself identifies them
+		items = append(items, resourceRef{
+			name:   tag.Tag,
+			id:     tag.Tag,
+			parent: org.Org.Name,
+		})
+	}
+	return genericResourceList(d, "vcd_security_tag", []string{org.Org.Name}, items)
+}
+
+// orgSettingsList returns the settings of an Org, which are identified by the Org name
+func orgSettingsList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	adminOrg, err := client.GetAdminOrgFromResource(d)
+	if err != nil {
+		return list, err
+	}
+	items := []resourceRef{{
+		name: adminOrg.AdminOrg.Name,
+		id:   adminOrg.AdminOrg.ID,
+	}}
+	return genericResourceList(d, "vcd_org_settings", nil, items)
+}
+
+// orgIdentityProviderList returns the Orgs that have the LDAP, SAML or OpenID Connect settings configured, depending
+// on resType. These settings are identified by the Org name. When "org" is set, only that Org is checked
+func orgIdentityProviderList(d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	orgList, err := client.VCDClient.GetOrgList()
+	if err != nil {
+		return list, err
+	}
+	orgName := d.Get("org").(string)
+	var items []resourceRef
+	for _, org := range orgList.Org {
+		if orgName != "" && !strings.EqualFold(org.Name, orgName) {
+			continue
+		}
+		adminOrg, err := client.GetAdminOrgByName(org.Name)
+		if err != nil {
+			return list, err
+		}
+		configured := false
+		switch resType {
+		case "vcd_org_ldap":
+			settings, err := adminOrg.GetLdapConfiguration()
+			if err != nil {
+				return list, fmt.Errorf("error retrieving LDAP settings of Org '%s': %s", org.Name, err)
+			}
+			configured = settings.OrgLdapMode != "" && settings.OrgLdapMode != types.LdapModeNone
+		case "vcd_org_saml":
+			settings, err := adminOrg.GetFederationSettings()
+			if err != nil {
+				return list, fmt.Errorf("error retrieving SAML settings of Org '%s': %s", org.Name, err)
+			}
+			configured = settings.Enabled || settings.SAMLMetadata != ""
+		case "vcd_org_oidc":
+			settings, err := adminOrg.GetOpenIdConnectSettings()
+			if err != nil {
+				return list, fmt.Errorf("error retrieving OpenID Connect settings of Org '%s': %s", org.Name, err)
+			}
+			configured = settings.Enabled || settings.IssuerId != ""
+		}
+		if !configured {
+			continue
+		}
+		items = append(items, resourceRef{
+			name: org.Name,
+			id:   adminOrg.AdminOrg.ID,
+			href: org.HREF,
+		})
+	}
+	return genericResourceList(d, resType, nil, items)
+}
+
+// nsxvDistributedFirewallList returns the NSX-V VDC when its distributed firewall is enabled
+func nsxvDistributedFirewallList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	org, vdc, err := client.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	if vdc.IsNsxv() {
+		enabled, err := govcd.NewNsxvDistributedFirewall(&client.Client, vdc.Vdc.ID).IsEnabled()
+		if err != nil {
+			return list, err
+		}
+		if enabled {
+			items = append(items, resourceRef{
+				name:   vdc.Vdc.Name,
+				id:     vdc.Vdc.ID,
+				href:   vdc.Vdc.HREF,
+				parent: org.Org.Name,
+			})
+		}
+	}
+	return genericResourceList(d, "vcd_nsxv_distributed_firewall", []string{org.Org.Name}, items)
+}
+
+// nsxtNetworkProfileList returns the network profile of an NSX-T VDC, which is identified by the VDC name
+func nsxtNetworkProfileList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	org, vdc, err := client.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	if vdc.IsNsxt() {
+		items = append(items, resourceRef{
+			name:   vdc.Vdc.Name,
+			id:     vdc.Vdc.ID,
+			href:   vdc.Vdc.HREF,
+			parent: org.Org.Name,
+		})
+	}
+	return genericResourceList(d, "vcd_org_vdc_nsxt_network_profile", []string{org.Org.Name}, items)
+}
+
+// computePolicyList returns the VM compute policies of the given type ("sizing", "placement" or "vgpu")
+func computePolicyList(d *schema.ResourceData, meta interface{}, resType, policyType string) (list []string, err error) {
+	client := meta.(*VCDClient)
+	filter, err := computePolicyFilter(client, policyType)
+	if err != nil {
+		return list, err
+	}
+	queryParams := url.Values{}
+	queryParams.Add("filter", filter)
+	policies, err := client.VCDClient.GetAllVdcComputePoliciesV2(queryParams)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, policy := range policies {
+		items = append(items, resourceRef{
+			name:     policy.VdcComputePolicyV2.Name,
+			id:       policy.VdcComputePolicyV2.ID,
+			importId: true,
+		})
+	}
+	return genericResourceList(d, resType, nil, items)
+}
+
+// ipSpaceList returns the IP Spaces. Private IP Spaces are imported with the name of their Org
+func ipSpaceList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	ipSpaces, err := client.GetAllIpSpaceSummaries(nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, ipSpace := range ipSpaces {
+		item := resourceRef{
+			name: ipSpace.IpSpace.Name,
+			id:   ipSpace.IpSpace.ID,
+		}
+		if ipSpace.IpSpace.OrgRef != nil && ipSpace.IpSpace.OrgRef.Name != "" {
+			item.parent = ipSpace.IpSpace.OrgRef.Name
+			item.ancestors = []string{ipSpace.IpSpace.OrgRef.Name}
+		}
+		items = append(items, item)
+	}
+	return genericResourceList(d, "vcd_ip_space", nil, items)
+}
+
+func externalNetworkV2List(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	if !client.Client.IsSysAdmin {
+		return list, fmt.Errorf("external network V2 list requires system administrator privileges")
+	}
+	externalNetworks, err := govcd.GetAllExternalNetworksV2(client.VCDClient, nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, externalNetwork := range externalNetworks {
+		items = append(items, resourceRef{
+			name: externalNetwork.ExternalNetwork.Name,
+			id:   externalNetwork.ExternalNetwork.ID,
+		})
+	}
+	return genericResourceList(d, "vcd_external_network_v2", nil, items)
+}
+
+// apiTokenList returns the API tokens of the current user, which are the only ones that can be imported
+func apiTokenList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	sessionInfo, err := client.Client.GetSessionInfo()
+	if err != nil {
+		return list, fmt.Errorf("error getting username: %s", err)
+	}
+	queryParams := url.Values{}
+	queryParams.Add("filter", fmt.Sprintf("(owner.name==%s;(type==PROXY,type==REFRESH))", sessionInfo.User.Name))
+	tokens, err := client.GetAllTokens(queryParams)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, token := range tokens {
+		items = append(items, resourceRef{
+			name: token.Token.Name,
+			id:   token.Token.ID,
+		})
+	}
+	return genericResourceList(d, "vcd_api_token", nil, items)
+}
+
+func serviceAccountList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	org, err := client.GetOrgFromResource(d)
+	if err != nil {
+		return list, err
+	}
+	serviceAccounts, err := org.GetAllServiceAccounts(nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, serviceAccount := range serviceAccounts {
+		items = append(items, resourceRef{
+			name:   serviceAccount.ServiceAccount.Name,
+			id:     serviceAccount.ServiceAccount.ID,
+			parent: org.Org.Name,
+		})
+	}
+	return genericResourceList(d, "vcd_service_account", []string{org.Org.Name}, items)
+}
+
+// rdeImportIdentifier returns the import ID of an RDE Interface or Type, made of vendor, nss and version, followed
+// by the given names
+func rdeImportIdentifier(vendor, nss, version string, names ...string) string {
+	return strings.Join(append([]string{vendor, nss, version}, names...), ImportSeparator)
+}
+
+func rdeInterfaceList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	rdeInterfaces, err := client.GetAllDefinedInterfaces(nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, rdeInterface := range rdeInterfaces {
+		di := rdeInterface.DefinedInterface
+		items = append(items, resourceRef{
+			name:             di.Name,
+			id:               di.ID,
+			importIdentifier: rdeImportIdentifier(di.Vendor, di.Nss, di.Version),
+		})
+	}
+	return genericResourceList(d, "vcd_rde_interface", nil, items)
+}
+
+func rdeInterfaceBehaviorList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	rdeInterfaces, err := client.GetAllDefinedInterfaces(nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, rdeInterface := range rdeInterfaces {
+		di := rdeInterface.DefinedInterface
+		behaviors, err := rdeInterface.GetAllBehaviors(nil)
+		if err != nil {
+			return list, fmt.Errorf("error retrieving the Behaviors of RDE Interface '%s': %s", di.ID, err)
+		}
+		for _, behavior := range behaviors {
+			items = append(items, resourceRef{
+				name:             behavior.Name,
+				id:               behavior.ID,
+				parent:           di.Name,
+				importIdentifier: rdeImportIdentifier(di.Vendor, di.Nss, di.Version, behavior.Name),
+			})
+		}
+	}
+	return genericResourceList(d, "vcd_rde_interface_behavior", nil, items)
+}
+
+func rdeTypeList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	rdeTypes, err := client.GetAllRdeTypes(nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, rdeType := range rdeTypes {
+		det := rdeType.DefinedEntityType
+		items = append(items, resourceRef{
+			name:             det.Name,
+			id:               det.ID,
+			importIdentifier: rdeImportIdentifier(det.Vendor, det.Nss, det.Version),
+		})
+	}
+	return genericResourceList(d, "vcd_rde_type", nil, items)
+}
+
+// rdeTypeBehaviorList returns the Behaviors of the RDE Types ("vcd_rde_type_behavior") or the Behaviors that have
+// access controls ("vcd_rde_type_behavior_acl")
+func rdeTypeBehaviorList(d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
+	client := meta.(*VCDClient)
+	rdeTypes, err := client.GetAllRdeTypes(nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, rdeType := range rdeTypes {
+		det := rdeType.DefinedEntityType
+		behaviors, err := rdeType.GetAllBehaviors(nil)
+		if err != nil {
+			return list, fmt.Errorf("error retrieving the Behaviors of RDE Type '%s': %s", det.ID, err)
+		}
+		withAccessControls := make(map[string]bool)
+		if resType == "vcd_rde_type_behavior_acl" {
+			accessControls, err := rdeType.GetAllBehaviorsAccessControls(nil)
+			if err != nil {
+				return list, fmt.Errorf("error retrieving the Behavior access controls of RDE Type '%s': %s", det.ID, err)
+			}
+			for _, accessControl := range accessControls {
+				withAccessControls[accessControl.BehaviorId] = true
+			}
+		}
+		for _, behavior := range behaviors {
+			if resType == "vcd_rde_type_behavior_acl" && !withAccessControls[behavior.ID] {
+				continue
+			}
+			items = append(items, resourceRef{
+				name:             behavior.Name,
+				id:               behavior.ID,
+				parent:           det.Name,
+				importIdentifier: rdeImportIdentifier(det.Vendor, det.Nss, det.Version, behavior.Name),
+			})
+		}
+	}
+	return genericResourceList(d, resType, nil, items)
+}
+
+// rdeList returns the Runtime Defined Entities of all the RDE Types, which are imported by ID
+func rdeList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	rdeTypes, err := client.GetAllRdeTypes(nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, rdeType := range rdeTypes {
+		rdes, err := rdeType.GetAllRdes(nil)
+		if err != nil {
+			return list, fmt.Errorf("error retrieving the RDEs of type '%s': %s", rdeType.DefinedEntityType.ID, err)
+		}
+		for _, rde := range rdes {
+			items = append(items, resourceRef{
+				name:     rde.DefinedEntity.Name,
+				id:       rde.DefinedEntity.ID,
+				parent:   rdeType.DefinedEntityType.Name,
+				importId: true,
+			})
+		}
+	}
+	return genericResourceList(d, "vcd_rde", nil, items)
+}
+
+// resourceListChild describes a resource type listed below a parent entity when "recursive" is set
+type resourceListChild struct {
+	resourceType string
+	// parentField is the scope field that receives the name of the parent entity: "org", "vdc" or "parent"
+	parentField string
+	// backing restricts the child to NSX-V ("nsxv") or NSX-T ("nsxt") VDCs. Empty for any VDC
+	backing string
+	// minApiVersion skips the child when VCD doesn't support the API version needed to list it
+	minApiVersion string
+	// sysAdminOnly skips the child when the provider is not connected as System Administrator
+	sysAdminOnly bool
+}
+
+// resourceListTree contains the resource types that are listed below each entity in a recursive listing
+var resourceListTree = map[string][]resourceListChild{
+	"vcd_org": {
+		{resourceType: "vcd_org_user", parentField: "org"},
+		{resourceType: "vcd_catalog", parentField: "org"},
+		{resourceType: "vcd_org_vdc", parentField: "org"},
+		{resourceType: "vcd_vdc_group", parentField: "org"},
+		{resourceType: "vcd_org_group", parentField: "org"},
+		{resourceType: "vcd_org_settings", parentField: "org"},
+		{resourceType: "vcd_org_ldap", parentField: "org", sysAdminOnly: true},
+		{resourceType: "vcd_org_saml", parentField: "org", sysAdminOnly: true},
+		{resourceType: "vcd_org_oidc", parentField: "org"},
+		{resourceType: "vcd_service_account", parentField: "org"},
+		{resourceType: "vcd_org_vdc_access_control", parentField: "org"},
+		{resourceType: "vcd_catalog_access_control", parentField: "org"},
+	},
+	"vcd_catalog": {
+		{resourceType: "vcd_catalog_vapp_template", parentField: "parent"},
+		{resourceType: "vcd_catalog_media", parentField: "parent"},
+	},
+	"vcd_org_vdc": {
+		{resourceType: "vcd_vapp", parentField: "vdc"},
+		{resourceType: "vcd_vm", parentField: "vdc"},
+		{resourceType: "vcd_independent_disk", parentField: "vdc"},
+		{resourceType: "vcd_vapp_access_control", parentField: "vdc"},
+		{resourceType: "vcd_network_routed", parentField: "vdc", backing: "nsxv"},
+		{resourceType: "vcd_network_isolated", parentField: "vdc", backing: "nsxv"},
+		{resourceType: "vcd_network_direct", parentField: "vdc", backing: "nsxv"},
+		{resourceType: "vcd_edgegateway", parentField: "vdc", backing: "nsxv"},
+		{resourceType: "vcd_nsxv_ip_set", parentField: "vdc", backing: "nsxv"},
+		{resourceType: "vcd_nsxv_distributed_firewall", parentField: "vdc", backing: "nsxv"},
+		{resourceType: "vcd_network_routed_v2", parentField: "vdc", backing: "nsxt"},
+		{resourceType: "vcd_network_isolated_v2", parentField: "vdc", backing: "nsxt"},
+		{resourceType: "vcd_nsxt_network_imported", parentField: "vdc", backing: "nsxt"},
+		{resourceType: "vcd_nsxt_edgegateway", parentField: "vdc", backing: "nsxt"},
+		{resourceType: "vcd_nsxt_app_port_profile", parentField: "vdc", backing: "nsxt"},
+		{resourceType: "vcd_nsxt_network_dhcp", parentField: "vdc", backing: "nsxt"},
+		{resourceType: "vcd_org_vdc_nsxt_network_profile", parentField: "vdc", backing: "nsxt"},
+	},
+	"vcd_vdc_group": {
+		{resourceType: "vcd_nsxt_edgegateway", parentField: "parent"},
+		{resourceType: "vcd_nsxt_app_port_profile", parentField: "parent"},
+		{resourceType: "vcd_nsxt_network_dhcp", parentField: "parent"},
+		{resourceType: "vcd_nsxt_dynamic_security_group", parentField: "parent"},
+		{resourceType: "vcd_nsxt_distributed_firewall", parentField: "parent"},
+		{resourceType: "vcd_nsxt_distributed_firewall_rule", parentField: "parent"},
+	},
+	"vcd_nsxt_network_dhcp": {
+		{resourceType: "vcd_nsxt_network_dhcp_binding", parentField: "parent"},
+	},
+	"vcd_vapp": {
+		{resourceType: "vcd_vapp_vm", parentField: "parent"},
+		{resourceType: "vcd_vapp_network", parentField: "parent"},
+		{resourceType: "vcd_vapp_org_network", parentField: "parent"},
+		{resourceType: "vcd_vm_snapshot", parentField: "parent"},
+	},
+	"vcd_edgegateway": {
+		{resourceType: "vcd_nsxv_firewall_rule", parentField: "parent"},
+		{resourceType: "vcd_nsxv_dnat", parentField: "parent"},
+		{resourceType: "vcd_nsxv_snat", parentField: "parent"},
+		{resourceType: "vcd_lb_server_pool", parentField: "parent"},
+		{resourceType: "vcd_lb_service_monitor", parentField: "parent"},
+		{resourceType: "vcd_lb_virtual_server", parentField: "parent"},
+		{resourceType: "vcd_lb_app_rule", parentField: "parent"},
+		{resourceType: "vcd_lb_app_profile", parentField: "parent"},
+	},
+	"vcd_nsxt_edgegateway": {
+		{resourceType: "vcd_nsxt_nat_rule", parentField: "parent"},
+		{resourceType: "vcd_nsxt_firewall", parentField: "parent"},
+		{resourceType: "vcd_nsxt_ip_set", parentField: "parent"},
+		{resourceType: "vcd_nsxt_security_group", parentField: "parent"},
+		{resourceType: "vcd_nsxt_edgegateway_static_route", parentField: "parent", minApiVersion: "37.0"},
+		{resourceType: "vcd_nsxt_edgegateway_bgp_configuration", parentField: "parent"},
+		{resourceType: "vcd_nsxt_edgegateway_bgp_neighbor", parentField: "parent"},
+		{resourceType: "vcd_nsxt_edgegateway_bgp_ip_prefix_list", parentField: "parent"},
+		{resourceType: "vcd_nsxt_edgegateway_dhcp_forwarding", parentField: "parent", minApiVersion: "36.1"},
+		{resourceType: "vcd_nsxt_edgegateway_dhcpv6", parentField: "parent", minApiVersion: "37.0"},
+		{resourceType: "vcd_nsxt_edgegateway_dns", parentField: "parent", minApiVersion: "37.0"},
+		{resourceType: "vcd_nsxt_edgegateway_rate_limiting", parentField: "parent", minApiVersion: "36.2"},
+		{resourceType: "vcd_nsxt_route_advertisement", parentField: "parent"},
+		{resourceType: "vcd_nsxt_ipsec_vpn_tunnel", parentField: "parent"},
+		{resourceType: "vcd_nsxt_edgegateway_l2_vpn_tunnel", parentField: "parent", minApiVersion: "37.0"},
+		{resourceType: "vcd_nsxt_alb_settings", parentField: "parent"},
+		{resourceType: "vcd_nsxt_alb_edgegateway_service_engine_group", parentField: "parent"},
+		{resourceType: "vcd_nsxt_alb_pool", parentField: "parent"},
+		{resourceType: "vcd_nsxt_alb_virtual_service", parentField: "parent"},
+		{resourceType: "vcd_nsxt_alb_virtual_service_http_req_rules", parentField: "parent"},
+		{resourceType: "vcd_nsxt_alb_virtual_service_http_resp_rules", parentField: "parent"},
+		{resourceType: "vcd_nsxt_alb_virtual_service_http_sec_rules", parentField: "parent"},
+	},
+}
+
+// resourceListScope contains the names used to locate the resources of a recursive listing
+type resourceListScope struct {
+	org    string
+	vdc    string
+	parent string
+}
+
+// child returns the scope of the resources contained in the entity with the given name
+func (scope resourceListScope) child(parentField, name string) resourceListScope {
+	switch parentField {
+	case "org":
+		return resourceListScope{org: name}
+	case "vdc":
+		return resourceListScope{org: scope.org, vdc: name}
+	default:
+		// The entity becomes the parent. A previous parent (a VDC group) takes the place of the VDC
+		return resourceListScope{org: scope.org, vdc: firstNonEmpty(scope.vdc, scope.parent), parent: name}
+	}
+}
+
+// recursiveResourceList lists the resources of the requested type and, recursively, the ones they contain.
+// The name filter only applies to the requested type
+func recursiveResourceList(d *schema.ResourceData, meta interface{}, requested string) ([]string, error) {
+	if _, ok := resourceListTree[requested]; !ok {
+		var supported []string
+		for resourceType := range resourceListTree {
+			supported = append(supported, resourceType)
+		}
+		sort.Strings(supported)
+		return nil, fmt.Errorf("'recursive' is not supported for resource type '%s'. Supported types: %s", requested, strings.Join(supported, ", "))
+	}
+	listMode := d.Get("list_mode").(string)
+	importFile := d.Get("import_file_name").(string)
+	if importFile != "" && listMode != "import_block" {
+		return nil, fmt.Errorf("'import_file_name' requires 'list_mode = \"import_block\"' when 'recursive' is set")
+	}
+
+	scope := resourceListScope{
+		org:    d.Get("org").(string),
+		vdc:    d.Get("vdc").(string),
+		parent: d.Get("parent").(string),
+	}
+	list, err := resourceListWithChildren(d, meta, requested, scope, d.Get("name_regex").(string))
+	if err != nil {
+		return nil, err
+	}
+	if importFile != "" {
+		err = writeImportFile(importFile, strings.Join(list, ""))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// resourceListWithChildren lists the resources of the given type within scope, followed by the resources they contain
+func resourceListWithChildren(d *schema.ResourceData, meta interface{}, resourceType string, scope resourceListScope, nameRegex string) ([]string, error) {
+	list, err := getResourceListByType(newResourceListData(d, resourceType, scope, d.Get("list_mode").(string), nameRegex), meta, resourceType)
+	if err != nil {
+		return nil, fmt.Errorf("error listing '%s': %s", resourceType, err)
+	}
+	children := resourceListTree[resourceType]
+	if len(children) == 0 {
+		return list, nil
+	}
+
+	names, err := getResourceListByType(newResourceListData(d, resourceType, scope, "name", nameRegex), meta, resourceType)
+	if err != nil {
+		return nil, fmt.Errorf("error listing '%s': %s", resourceType, err)
+	}
+	for _, name := range names {
+		backing := ""
+		if resourceType == "vcd_org_vdc" {
+			backing = "nsxv"
+			_, vdc, err := meta.(*VCDClient).GetOrgAndVdc(scope.org, name)
+			if err != nil {
+				return nil, err
+			}
+			if vdc.IsNsxt() {
+				backing = "nsxt"
+			}
+		}
+		for _, child := range children {
+			if child.backing != "" && child.backing != backing {
+				continue
+			}
+			if child.minApiVersion != "" && meta.(*VCDClient).Client.APIVCDMaxVersionIs("< "+child.minApiVersion) {
+				continue
+			}
+			if child.sysAdminOnly && !meta.(*VCDClient).Client.IsSysAdmin {
+				continue
+			}
+			childList, err := resourceListWithChildren(d, meta, child.resourceType, scope.child(child.parentField, name), "")
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, childList...)
+		}
+	}
+	return list, nil
+}
+
+// newResourceListData creates the data used to list a single resource type during a recursive listing
+func newResourceListData(d *schema.ResourceData, resourceType string, scope resourceListScope, listMode, nameRegex string) *schema.ResourceData {
+	data := datasourceVcdResourceList().Data(nil)
+	dSet(data, "name", d.Get("name").(string))
+	dSet(data, "resource_type", resourceType)
+	dSet(data, "org", scope.org)
+	dSet(data, "vdc", scope.vdc)
+	dSet(data, "parent", scope.parent)
+	dSet(data, "list_mode", listMode)
+	dSet(data, "name_regex", nameRegex)
+	dSet(data, "name_id_separator", d.Get("name_id_separator").(string))
+	return data
+}
+
+func getResourcesList() ([]string, error) {
+	var list []string
+	resources := globalResourceMap
+	for resource := range resources {
+		list = append(list, resource)
+	}
+	// Returns the list of resources in alphabetical order, to keep a consistent state
+	sort.Strings(list)
+	return list, nil
+}
+
+func datasourceVcdResourceListRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	requested := d.Get("resource_type").(string)
+	var err error
+	var list []string
+	if d.Get("recursive").(bool) {
+		list, err = recursiveResourceList(d, meta, requested)
+	} else {
+		list, err = getResourceListByType(d, meta, requested)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("list", list)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(d.Get("name").(string))
+
+	return diag.Diagnostics{}
+}
+
+// resourceListUnsupportedTypes contains the resource types that can be imported, but can't be listed by
+// vcd_resource_list, with the reason. They must be imported using the ID described in their documentation
+var resourceListUnsupportedTypes = map[string]string{
+	"vcd_api_filter":                                   resourceListNotImplemented,
+	"vcd_cse_kubernetes_cluster":                       resourceListNotImplemented,
+	"vcd_dse_registry_configuration":                   resourceListNotImplemented,
+	"vcd_dse_solution_publish":                         resourceListNotImplemented,
+	"vcd_external_endpoint":                            resourceListNotImplemented,
+	"vcd_ip_space_custom_quota":                        resourceListNotImplemented,
+	"vcd_ip_space_ip_allocation":                       resourceListNotImplemented,
+	"vcd_ip_space_uplink":                              resourceListNotImplemented,
+	"vcd_nsxt_alb_cloud":                               resourceListNotImplemented,
+	"vcd_nsxt_alb_controller":                          resourceListNotImplemented,
+	"vcd_nsxt_alb_service_engine_group":                resourceListNotImplemented,
+	"vcd_nsxt_global_default_segment_profile_template": resourceListNotImplemented,
+	"vcd_nsxt_network_segment_profile":                 resourceListNotImplemented,
+	"vcd_nsxt_segment_profile_template":                resourceListNotImplemented,
+	"vcd_nsxv_dhcp_relay":                              resourceListNotImplemented,
+	"vcd_solution_add_on":                              resourceListNotImplemented,
+	"vcd_solution_add_on_instance":                     resourceListNotImplemented,
+	"vcd_solution_add_on_instance_publish":             resourceListNotImplemented,
+	"vcd_solution_landing_zone":                        resourceListNotImplemented,
+	"vcd_ui_plugin":                                    resourceListNotImplemented,
+	"vcd_vapp_firewall_rules":                          "the import ID 'list@org.vdc.vapp' lists the vApp networks",
+	"vcd_vapp_nat_rules":                               "the import ID 'list@org.vdc.vapp' lists the vApp networks",
+	"vcd_vapp_static_routing":                          "the import ID 'list@org.vdc.vapp' lists the vApp networks",
+	"vcd_vm_affinity_rule":                             resourceListNotImplemented,
+	"vcd_vm_internal_disk":                             "the import ID 'list@org.vdc.vapp.vm' lists the internal disks",
+}
+
+const resourceListNotImplemented = "listing is not implemented for this type"
+
+// resourceLister returns the list of resources of one type, using the scope and list mode in d
+type resourceLister func(d *schema.ResourceData, meta interface{}) ([]string, error)
+
+// getResourceListByType returns the list of resources of the requested type, using the scope and list mode in d
+func getResourceListByType(d *schema.ResourceData, meta interface{}, requested string) ([]string, error) {
+	lister := getResourceLister(requested)
+	if lister == nil {
+		if reason, found := resourceListUnsupportedTypes[requested]; found {
+			return nil, fmt.Errorf("resource type '%s' is not supported by vcd_resource_list: %s", requested, reason)
+		}
+		return nil, fmt.Errorf("unhandled resource type '%s'", requested)
+	}
+	return lister(d, meta)
+}
+
+// getResourceLister returns the function listing the requested resource type, or nil when the type is not handled
+func getResourceLister(requested string) resourceLister {
+	switch requested {
+	// Note: do not try to get the data sources list, as it would result in a circular reference
+	case "resource", "resources":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return getResourcesList()
+		}
+	case "vcd_multisite_site_association":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return getSiteAssociationList(d, meta, "vcd_multisite_site_association")
+		}
+	case "vcd_multisite_org_association":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return getOrgAssociationList(d, meta, "vcd_multisite_org_association")
+		}
+	case "vcd_org", "org", "orgs":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return getOrgList(d, meta, "vcd_org")
+		}
+	case "vcd_org_ldap", "vcd_org_saml", "vcd_org_oidc":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return orgIdentityProviderList(d, meta, requested)
+		}
+	case "vcd_provider_vdc", "provider_vdc":
+		return getPvdcList
+	case "vcd_distributed_switch":
+		return distributedSwitchList
+	case "vcd_nsxt_transport_zone":
+		return transportZoneList
+	case "vcd_importable_port_group":
+		return importablePortGroupList
+	case "vcd_network_pool":
+		return networkPoolList
+	case "vcd_vcenter":
+		return vcenterList
+	case "vcd_nsxt_manager":
+		return nsxtManagerList
+	case "vcd_external_network", "external_network", "external_networks":
+		return externalNetworkList
+	case "vcd_org_vdc", "vdc", "vdcs":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return vdcList(d, meta, "vcd_org_vdc")
+		}
+	case "vcd_vdc_group":
+		return getVdcGroups
+	case "vcd_org_vdc_access_control":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return vdcList(d, meta, "vcd_org_vdc_access_control")
+		}
+	case "vcd_catalog", "catalog", "catalogs", "vcd_subscribed_catalog":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return catalogList(d, meta, "vcd_catalog")
+		}
+	case "vcd_catalog_access_control":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return catalogList(d, meta, "vcd_catalog_access_control")
+		}
+	case "vcd_catalog_item", "catalog_item", "catalog_items", "catalogitem", "catalogitems":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return catalogItemList(d, meta, "vcd_catalog_item")
+		}
+	case "vcd_catalog_vapp_template", "vapp_template":
+		return vappTemplateList
+	case "vcd_catalog_media", "catalog_media", "media_items", "mediaitems", "mediaitem":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return catalogItemList(d, meta, "vcd_catalog_media")
+		}
+	case "vcd_independent_disk", "disk", "disks":
+		return diskList
+	case "vcd_vapp", "vapp", "vapps", "vcd_cloned_vapp":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return vappList(d, meta, "vcd_vapp")
+		}
+	case "vcd_vapp_access_control":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return vappList(d, meta, "vcd_vapp_access_control")
+		}
+	case "vcd_vapp_vm", "vapp_vm", "vapp_vms":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return vmList(d, meta, vappVmType)
+		}
+	case "vcd_vapp_network", "vapp_network", "vapp_networks":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return vappNetworkList(d, vntVappNetwork, meta)
+		}
+	case "vcd_vapp_org_network", "vapp_org_network", "vapp_org_networks":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return vappNetworkList(d, vntVappOrgNetwork, meta)
+		}
+	case "vcd_vapp_all_network", "vapp_all_network", "vapp_all_networks":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return vappNetworkList(d, vntVappAllNetworks, meta)
+		}
+	case "vcd_vm", "standalone_vm":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return vmList(d, meta, standaloneVmType)
+		}
+	case "vcd_all_vm", "vm", "vms":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return vmList(d, meta, "all")
+		}
+	case "vcd_vm_snapshot", "vm_snapshot", "vm_snapshots":
+		return vmSnapshotList
+	case "vcd_org_user", "org_user", "user", "users":
+		return orgUserList
+	case "vcd_edgegateway", "edge_gateway", "edge", "edgegateway":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return getEdgeGatewayList(d, meta, "vcd_edgegateway")
+		}
+	case "vcd_edgegateway_settings":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return getEdgeGatewayList(d, meta, "vcd_edgegateway_settings")
+		}
+	case "vcd_nsxt_edgegateway", "nsxt_edge_gateway", "nsxt_edge", "nsxt_edgegateway":
+		return getNsxtEdgeGatewayList
+	case "vcd_lb_server_pool", "lb_server_pool":
+		return lbServerPoolList
+	case "vcd_lb_service_monitor", "lb_service_monitor":
+		return lbServiceMonitorList
+	case "vcd_lb_virtual_server", "lb_virtual_server":
+		return lbVirtualServerList
+	case "vcd_lb_app_rule", "lb_app_rule":
+		return lbAppRuleList
+	case "vcd_lb_app_profile", "lb_app_profile":
+		return lbAppProfileList
+	case "vcd_nsxv_firewall_rule", "nsxv_firewall_rule":
+		return nsxvFirewallList
+	case "vcd_nsxv_ip_set", "vcd_ipset", "ipset":
+		return ipsetList
+	case "vcd_nsxv_dnat", "nsxv_dnat":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxvNatRuleList("dnat", d, meta)
+		}
+	case "vcd_nsxv_snat", "nsxv_snat":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxvNatRuleList("snat", d, meta)
+		}
+	case "vcd_network_isolated", "vcd_network_direct", "vcd_network_routed",
+		"network", "networks", "network_direct", "network_routed", "network_isolated":
+		return networkList
+	case "vcd_network_routed_v2", "vcd_network_isolated_v2", "vcd_nsxt_network_imported":
+		return orgNetworkListV2
+	case "vcd_right", "rights":
+		return rightsList
+	case "vcd_rights_bundle", "rights_bundle":
+		return rightsBundlesList
+	case "vcd_role", "roles":
+		return rolesList
+	case "vcd_global_role", "global_roles":
+		return globalRolesList
+	case "vcd_library_certificate":
+		return libraryCertificateList
+	case "vcd_org_vdc_template":
+		return vdcTemplateList
+	case "vcd_nsxt_nat_rule", "nsxt_nat_rule":
+		return nsxtNatRuleList
+	case "vcd_nsxt_firewall", "nsxt_firewall":
+		return nsxtFirewallList
+	case "vcd_nsxt_ip_set", "nsxt_ip_set":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtFirewallGroupList(d, meta, "vcd_nsxt_ip_set", types.FirewallGroupTypeIpSet)
+		}
+	case "vcd_nsxt_security_group", "nsxt_security_group":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtFirewallGroupList(d, meta, "vcd_nsxt_security_group", types.FirewallGroupTypeSecurityGroup)
+		}
+	case "vcd_nsxt_alb_settings":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtEdgeGatewaySettingList(d, meta, requested, func(egw *govcd.NsxtEdgeGateway) (bool, error) {
+				albSettings, err := egw.GetAlbSettings()
+				if err != nil {
+					return false, err
+				}
+				return albSettings.Enabled, nil
+			})
+		}
+	case "vcd_nsxt_alb_pool":
+		return nsxtAlbPoolList
+	case "vcd_nsxt_alb_virtual_service":
+		return nsxtAlbVirtualServiceList
+	case "vcd_nsxt_alb_virtual_service_http_req_rules":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtAlbVirtualServiceHttpPolicyList(d, meta, requested, albVsHttpRequestRulesEndpoint)
+		}
+	case "vcd_nsxt_alb_virtual_service_http_resp_rules":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtAlbVirtualServiceHttpPolicyList(d, meta, requested, albVsHttpResponseRulesEndpoint)
+		}
+	case "vcd_nsxt_alb_virtual_service_http_sec_rules":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtAlbVirtualServiceHttpPolicyList(d, meta, requested, albVsHttpSecurityRulesEndpoint)
+		}
+	case "vcd_nsxt_alb_edgegateway_service_engine_group":
+		return nsxtAlbEdgeGatewayServiceEngineGroupList
+	case "vcd_nsxt_edgegateway_static_route":
+		return nsxtStaticRouteList
+	case "vcd_nsxt_edgegateway_bgp_configuration":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtEdgeGatewaySettingList(d, meta, requested, func(egw *govcd.NsxtEdgeGateway) (bool, error) {
+				bgpConfig, err := egw.GetBgpConfiguration()
+				if err != nil {
+					return false, err
+				}
+				return bgpConfig.Enabled, nil
+			})
+		}
+	case "vcd_nsxt_edgegateway_bgp_neighbor":
+		return nsxtBgpNeighborList
+	case "vcd_nsxt_edgegateway_bgp_ip_prefix_list":
+		return nsxtBgpIpPrefixListList
+	case "vcd_nsxt_edgegateway_dhcp_forwarding":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtEdgeGatewaySettingList(d, meta, requested, func(egw *govcd.NsxtEdgeGateway) (bool, error) {
+				dhcpForwarder, err := egw.GetDhcpForwarder()
+				if err != nil {
+					return false, err
+				}
+				return dhcpForwarder.Enabled, nil
+			})
+		}
+	case "vcd_nsxt_edgegateway_dhcpv6":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtEdgeGatewaySettingList(d, meta, requested, func(egw *govcd.NsxtEdgeGateway) (bool, error) {
+				slaacProfile, err := egw.GetSlaacProfile()
+				if err != nil {
+					return false, err
+				}
+				return slaacProfile.Enabled, nil
+			})
+		}
+	case "vcd_nsxt_edgegateway_dns":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtEdgeGatewaySettingList(d, meta, requested, func(egw *govcd.NsxtEdgeGateway) (bool, error) {
+				dns, err := egw.GetDnsConfig()
+				if err != nil {
+					return false, err
+				}
+				return dns.NsxtEdgeGatewayDns.Enabled, nil
+			})
+		}
+	case "vcd_nsxt_edgegateway_rate_limiting":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtEdgeGatewaySettingList(d, meta, requested, func(egw *govcd.NsxtEdgeGateway) (bool, error) {
+				qos, err := egw.GetQoS()
+				if err != nil {
+					return false, err
+				}
+				return qos.IngressProfile != nil || qos.EgressProfile != nil, nil
+			})
+		}
+	case "vcd_nsxt_route_advertisement":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxtEdgeGatewaySettingList(d, meta, requested, func(egw *govcd.NsxtEdgeGateway) (bool, error) {
+				routeAdvertisement, err := egw.GetNsxtRouteAdvertisement()
+				if err != nil {
+					return false, err
+				}
+				return routeAdvertisement.Enable, nil
+			})
+		}
+	case "vcd_nsxt_ipsec_vpn_tunnel":
+		return nsxtIpSecVpnTunnelList
+	case "vcd_nsxt_edgegateway_l2_vpn_tunnel":
+		return nsxtL2VpnTunnelList
+	case "vcd_nsxt_app_port_profile":
+		return nsxtAppPortProfileList
+	case "vcd_nsxt_network_dhcp":
+		return nsxtNetworkDhcpList
+	case "vcd_nsxt_network_dhcp_binding":
+		return nsxtNetworkDhcpBindingList
+	case "vcd_nsxt_dynamic_security_group":
+		return nsxtDynamicSecurityGroupList
+	case "vcd_nsxt_distributed_firewall":
+		return nsxtDistributedFirewallList
+	case "vcd_nsxt_distributed_firewall_rule":
+		return nsxtDistributedFirewallRuleList
+	case "vcd_org_group", "org_group":
+		return orgGroupList
+	case "vcd_security_tag":
+		return securityTagList
+	case "vcd_org_settings":
+		return orgSettingsList
+	case "vcd_nsxv_distributed_firewall":
+		return nsxvDistributedFirewallList
+	case "vcd_org_vdc_nsxt_network_profile":
+		return nsxtNetworkProfileList
+	case "vcd_vm_sizing_policy":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return computePolicyList(d, meta, "vcd_vm_sizing_policy", "sizing")
+		}
+	case "vcd_vm_placement_policy":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return computePolicyList(d, meta, "vcd_vm_placement_policy", "placement")
+		}
+	case "vcd_vm_vgpu_policy":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return computePolicyList(d, meta, "vcd_vm_vgpu_policy", "vgpu")
+		}
+	case "vcd_ip_space":
+		return ipSpaceList
+	case "vcd_external_network_v2":
+		return externalNetworkV2List
+	case "vcd_api_token":
+		return apiTokenList
+	case "vcd_service_account":
+		return serviceAccountList
+	case "vcd_rde_interface":
+		return rdeInterfaceList
+	case "vcd_rde_interface_behavior":
+		return rdeInterfaceBehaviorList
+	case "vcd_rde_type":
+		return rdeTypeList
+	case "vcd_rde_type_behavior", "vcd_rde_type_behavior_acl":
+		return func(d *schema.ResourceData, meta interface{}) ([]string, error) {
+			return rdeTypeBehaviorList(d, meta, requested)
+		}
+	case "vcd_rde":
+		return rdeList
+	default:
+		return nil
+	}
 }
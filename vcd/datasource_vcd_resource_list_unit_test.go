@@ -0,0 +1,187 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Test_importResourceName checks that the resource names used in import blocks are valid Terraform identifiers
+func Test_importResourceName(t *testing.T) {
+	tests := []struct {
+		name       string
+		entityName string
+		id         string
+		want       string
+	}{
+		{
+			name:       "simple name",
+			entityName: "my-vm",
+			id:         "urn:vcloud:vm:aa59ed22-b7c7-4b3d-9131-82b7d2ae9e04",
+			want:       "my-vm-82b7d2ae9e04",
+		},
+		{
+			name:       "name with spaces and dots",
+			entityName: "my vm.example.com",
+			id:         "urn:vcloud:vm:aa59ed22-b7c7-4b3d-9131-82b7d2ae9e04",
+			want:       "my_vm_example_com-82b7d2ae9e04",
+		},
+		{
+			name:       "name starting with a digit",
+			entityName: "10.10.1.0-net",
+			id:         "urn:vcloud:network:c8d4b4ef-0a5e-4f2e-8d3f-1d2b4c5a6e7f",
+			want:       "_10_10_1_0-net-1d2b4c5a6e7f",
+		},
+		{
+			name:       "empty ID",
+			entityName: "catalog",
+			id:         "",
+			want:       "catalog-",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := importResourceName(tt.entityName, tt.id)
+			if got != tt.want {
+				t.Errorf("importResourceName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_importBlock checks the import blocks built for entities with and without ancestors
+func Test_importBlock(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		ancestors    []string
+		ref          resourceRef
+		want         string
+	}{
+		{
+			name:         "no ancestors",
+			resourceType: "vcd_org",
+			ref:          resourceRef{name: "my-org", id: "urn:vcloud:org:11111111-2222-3333-4444-555555555555"},
+			want: "# Import directive for vcd_org my-org \n" +
+				"import {\n" +
+				"  to = vcd_org.my-org-555555555555\n" +
+				"  id = \"my-org\"\n" +
+				"}\n\n",
+		},
+		{
+			name:         "with ancestors",
+			resourceType: "vcd_vapp_vm",
+			ancestors:    []string{"my-org", "my-vdc", "my-vapp"},
+			ref:          resourceRef{name: "my vm", id: "urn:vcloud:vm:11111111-2222-3333-4444-555555555555"},
+			want: "# Import directive for vcd_vapp_vm my-org.my-vdc.my-vapp.my vm \n" +
+				"import {\n" +
+				"  to = vcd_vapp_vm.my_vm-555555555555\n" +
+				"  id = \"my-org.my-vdc.my-vapp.my vm\"\n" +
+				"}\n\n",
+		},
+		{
+			name:         "import by ID",
+			resourceType: "vcd_nsxt_nat_rule",
+			ancestors:    []string{"my-org", "my-vdc", "my-edge"},
+			ref:          resourceRef{name: "dnat", id: "8a5f6b2c-1d3e-4f70-9a8b-0c1d2e3f4a5b", importId: true},
+			want: "# Import directive for vcd_nsxt_nat_rule my-org.my-vdc.my-edge.dnat \n" +
+				"import {\n" +
+				"  to = vcd_nsxt_nat_rule.dnat-0c1d2e3f4a5b\n" +
+				"  id = \"my-org.my-vdc.my-edge.8a5f6b2c-1d3e-4f70-9a8b-0c1d2e3f4a5b\"\n" +
+				"}\n\n",
+		},
+		{
+			name:         "import identifier",
+			resourceType: "vcd_rde_type",
+			ref: resourceRef{name: "kubernetes", id: "urn:vcloud:type:vmware:kubernetes:1.0.0",
+				importIdentifier: rdeImportIdentifier("vmware", "kubernetes", "1.0.0")},
+			want: "# Import directive for vcd_rde_type kubernetes \n" +
+				"import {\n" +
+				"  to = vcd_rde_type.kubernetes-0\n" +
+				"  id = \"vmware.kubernetes.1.0.0\"\n" +
+				"}\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := importBlock(tt.resourceType, tt.ancestors, tt.ref)
+			if got != tt.want {
+				t.Errorf("importBlock() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_resourceListImportableTypes checks that every resource type that can be imported is either listed by
+// vcd_resource_list or explicitly marked as unsupported, and that the recursive listing only contains handled types
+func Test_resourceListImportableTypes(t *testing.T) {
+	for resourceType, resource := range globalResourceMap {
+		reason, unsupported := resourceListUnsupportedTypes[resourceType]
+		handled := getResourceLister(resourceType) != nil
+		if resource.Importer == nil {
+			if unsupported {
+				t.Errorf("resource type '%s' can't be imported, but is marked as unsupported (%s)", resourceType, reason)
+			}
+			continue
+		}
+		if !handled && !unsupported {
+			t.Errorf("resource type '%s' can be imported, but is neither listed nor marked as unsupported", resourceType)
+		}
+		if handled && unsupported {
+			t.Errorf("resource type '%s' is listed, but is also marked as unsupported (%s)", resourceType, reason)
+		}
+	}
+	for resourceType := range resourceListUnsupportedTypes {
+		if _, found := globalResourceMap[resourceType]; !found {
+			t.Errorf("unsupported type '%s' is not a resource", resourceType)
+		}
+	}
+
+	for parentType, children := range resourceListTree {
+		if getResourceLister(parentType) == nil {
+			t.Errorf("recursive parent type '%s' is not handled", parentType)
+		}
+		for _, child := range children {
+			if getResourceLister(child.resourceType) == nil {
+				t.Errorf("child type '%s' of '%s' is not handled", child.resourceType, parentType)
+			}
+			if resource, found := globalResourceMap[child.resourceType]; !found || resource.Importer == nil {
+				t.Errorf("child type '%s' of '%s' is not an importable resource", child.resourceType, parentType)
+			}
+		}
+	}
+}
+
+// Test_genericResourceListAncestors checks that the ancestors of an entity replace the ones of the list in the
+// import identifiers, as for VM snapshots listed from different vApps
+func Test_genericResourceListAncestors(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, datasourceVcdResourceList().Schema, map[string]interface{}{
+		"name":          "snapshots",
+		"resource_type": "vcd_vm_snapshot",
+		"list_mode":     "import",
+	})
+	refs := []resourceRef{
+		{name: "vm1", id: "urn:vcloud:vm:1", ancestors: []string{"my-org", "my-vdc", "vapp1"}},
+		{name: "vm1", id: "urn:vcloud:vm:2", ancestors: []string{"my-org", "my-vdc", "vapp2"}},
+		{name: "vm3", id: "urn:vcloud:vm:3"},
+	}
+	got, err := genericResourceList(d, "vcd_vm_snapshot", []string{"my-org", "my-vdc", "vapp3"}, refs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []string{
+		"terraform import vcd_vm_snapshot.vm1 my-org.my-vdc.vapp1.vm1",
+		"terraform import vcd_vm_snapshot.vm1 my-org.my-vdc.vapp2.vm1",
+		"terraform import vcd_vm_snapshot.vm3 my-org.my-vdc.vapp3.vm3",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("genericResourceList() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("genericResourceList()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
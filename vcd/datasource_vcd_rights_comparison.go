@@ -0,0 +1,207 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+var rightsComparisonSourceTypes = []string{"role", "global_role", "rights_bundle", "rights"}
+
+func rightsComparisonSourceSchema(label string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: fmt.Sprintf("The %s set of rights to compare", label),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(rightsComparisonSourceTypes, false),
+					Description:  "Type of the rights container. One of 'role', 'global_role', 'rights_bundle' or 'rights'",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of the role, global role or rights bundle. Not used when type is 'rights'",
+				},
+				"rights": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "Set of rights to compare. Only used when type is 'rights'",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdRightsComparison() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdRightsComparisonRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use for roles, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"first":  rightsComparisonSourceSchema("first"),
+			"second": rightsComparisonSourceSchema("second"),
+			"only_in_first": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Rights that are in the first set and not in the second one",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"only_in_second": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Rights that are in the second set and not in the first one",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"intersection": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Rights that are in both sets",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"union": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Rights that are in any of the sets",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func datasourceVcdRightsComparisonRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	var sets [2][]string
+	var labels [2]string
+	for i, key := range []string{"first", "second"} {
+		source := d.Get(key + ".0").(map[string]interface{})
+		sourceType := source["type"].(string)
+		sourceName := source["name"].(string)
+		labels[i] = sourceType + ":" + sourceName
+		rights, err := getRightsForComparison(d, vcdClient, sourceType, sourceName, source["rights"].(*schema.Set))
+		if err != nil {
+			return diag.Errorf("[rights comparison read] error retrieving %s rights: %s", key, err)
+		}
+		sets[i] = rights
+	}
+
+	onlyInFirst, onlyInSecond, intersection, union := compareRights(sets[0], sets[1])
+	for key, value := range map[string][]string{
+		"only_in_first":  onlyInFirst,
+		"only_in_second": onlyInSecond,
+		"intersection":   intersection,
+		"union":          union,
+	} {
+		err := d.Set(key, convertStringsToTypeSet(value))
+		if err != nil {
+			return diag.Errorf("[rights comparison read] error setting %s: %s", key, err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("first='%s',second='%s'", labels[0], labels[1]))
+	return nil
+}
+
+// getRightsForComparison returns the names of the rights of the given role, global role or rights bundle
+func getRightsForComparison(d *schema.ResourceData, vcdClient *VCDClient, sourceType, name string, rights *schema.Set) ([]string, error) {
+	if sourceType == "rights" {
+		if name != "" {
+			return nil, fmt.Errorf("'name' can't be used with type 'rights'")
+		}
+		return convertSchemaSetToSliceOfStrings(rights), nil
+	}
+	if name == "" {
+		return nil, fmt.Errorf("'name' is required with type '%s'", sourceType)
+	}
+	if rights.Len() > 0 {
+		return nil, fmt.Errorf("'rights' can only be used with type 'rights'")
+	}
+
+	var containerRights []*types.Right
+	switch sourceType {
+	case "role":
+		adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
+		if err != nil {
+			return nil, fmt.Errorf(errorRetrievingOrg, err)
+		}
+		role, err := adminOrg.GetRoleByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving role %s: %s", name, err)
+		}
+		containerRights, err = role.GetRights(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving rights of role %s: %s", name, err)
+		}
+	case "global_role":
+		globalRole, err := vcdClient.Client.GetGlobalRoleByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving global role %s: %s", name, err)
+		}
+		containerRights, err = globalRole.GetRights(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving rights of global role %s: %s", name, err)
+		}
+	case "rights_bundle":
+		rightsBundle, err := vcdClient.Client.GetRightsBundleByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving rights bundle %s: %s", name, err)
+		}
+		containerRights, err = rightsBundle.GetRights(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving rights of rights bundle %s: %s", name, err)
+		}
+	}
+
+	result := make([]string, len(containerRights))
+	for i, right := range containerRights {
+		result[i] = right.Name
+	}
+	return result, nil
+}
+
+// compareRights returns the rights only in the first set, the rights only in the second set, the rights in both sets
+// and the rights in any set, all sorted by name
+func compareRights(first, second []string) ([]string, []string, []string, []string) {
+	inFirst := make(map[string]bool, len(first))
+	for _, right := range first {
+		inFirst[right] = true
+	}
+	inSecond := make(map[string]bool, len(second))
+	for _, right := range second {
+		inSecond[right] = true
+	}
+
+	onlyInFirst := make(map[string]bool)
+	intersection := make(map[string]bool)
+	union := make(map[string]bool)
+	for right := range inFirst {
+		union[right] = true
+		if inSecond[right] {
+			intersection[right] = true
+		} else {
+			onlyInFirst[right] = true
+		}
+	}
+	onlyInSecond := make(map[string]bool)
+	for right := range inSecond {
+		union[right] = true
+		if !inFirst[right] {
+			onlyInSecond[right] = true
+		}
+	}
+	return sortedKeys(onlyInFirst), sortedKeys(onlyInSecond), sortedKeys(intersection), sortedKeys(union)
+}
@@ -0,0 +1,97 @@
+//go:build role || ALL || functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdRightsComparisonDS(t *testing.T) {
+	preTestChecks(t)
+	skipIfNotSysAdmin(t)
+
+	var params = StringMap{
+		"Org":      testConfig.VCD.Org,
+		"RoleName": "vApp Author",
+		"FuncName": t.Name(),
+		"Tags":     "role",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdRightsComparisonDS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vcd_least_privilege_rights.pipeline", "rights.#", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckTypeSetElemAttr("data.vcd_least_privilege_rights.pipeline", "rights.*", "vApp: Create / Reconfigure"),
+					resource.TestCheckTypeSetElemAttr("data.vcd_least_privilege_rights.pipeline", "rights.*", "Catalog: View Private and Shared Catalogs"),
+					resource.TestCheckResourceAttr("data.vcd_least_privilege_rights.pipeline", "unknown_resource_types.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.vcd_least_privilege_rights.pipeline", "unknown_resource_types.*", "vcd_org_vdc"),
+					resource.TestCheckResourceAttr("data.vcd_least_privilege_rights.pipeline", "unavailable_rights.#", "0"),
+
+					// The global role is published to the Org as a role with the same rights
+					resource.TestCheckResourceAttr("data.vcd_rights_comparison.global-vs-org", "only_in_first.#", "0"),
+					resource.TestCheckResourceAttr("data.vcd_rights_comparison.global-vs-org", "only_in_second.#", "0"),
+					resource.TestMatchResourceAttr("data.vcd_rights_comparison.global-vs-org", "intersection.#", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckResourceAttrPair("data.vcd_rights_comparison.global-vs-org", "intersection.#",
+						"data.vcd_rights_comparison.global-vs-org", "union.#"),
+					resource.TestCheckResourceAttrPair("data.vcd_rights_comparison.global-vs-org", "union.#",
+						"data.vcd_global_role.author", "rights.#"),
+
+					resource.TestCheckResourceAttrSet("data.vcd_rights_comparison.pipeline-vs-org", "only_in_first.#"),
+					resource.TestCheckResourceAttrSet("data.vcd_rights_comparison.pipeline-vs-org", "only_in_second.#"),
+					resource.TestCheckTypeSetElemAttr("data.vcd_rights_comparison.pipeline-vs-org", "intersection.*", "vApp: Create / Reconfigure"),
+					resource.TestMatchResourceAttr("data.vcd_rights_comparison.pipeline-vs-org", "union.#", regexp.MustCompile(`^[1-9]\d*$`)),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdRightsComparisonDS = `
+data "vcd_global_role" "author" {
+  name = "{{.RoleName}}"
+}
+
+data "vcd_least_privilege_rights" "pipeline" {
+  resource_types = ["vcd_vapp", "vcd_vapp_vm", "data.vcd_catalog", "vcd_org_vdc"]
+}
+
+data "vcd_rights_comparison" "global-vs-org" {
+  org = "{{.Org}}"
+  first {
+    type = "global_role"
+    name = "{{.RoleName}}"
+  }
+  second {
+    type = "role"
+    name = "{{.RoleName}}"
+  }
+}
+
+data "vcd_rights_comparison" "pipeline-vs-org" {
+  org = "{{.Org}}"
+  first {
+    type   = "rights"
+    rights = data.vcd_least_privilege_rights.pipeline.rights
+  }
+  second {
+    type = "role"
+    name = "{{.RoleName}}"
+  }
+}
+`
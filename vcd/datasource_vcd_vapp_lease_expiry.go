@@ -0,0 +1,174 @@
+package vcd
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func datasourceVcdVappLeaseExpiry() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdVappLeaseExpiryRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"expiring_within_sec": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "The vApps with any lease expiring within this number of seconds are listed in " +
+					"'expiring'. With 0 (default), only vApps with expired leases are listed",
+			},
+			"vapp": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Lease details of all vApps in the VDC",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the vApp",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the vApp",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the vApp",
+						},
+						"runtime_lease_in_sec": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Duration of the runtime lease, in seconds. 0 means that the lease never expires",
+						},
+						"storage_lease_in_sec": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Duration of the storage lease, in seconds. 0 means that the lease never expires",
+						},
+						"runtime_lease_expiration": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Expiration date of the runtime lease. Empty when the lease doesn't expire or the vApp is not deployed",
+						},
+						"storage_lease_expiration": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Expiration date of the storage lease. Empty when the lease doesn't expire",
+						},
+						"runtime_lease_remaining_sec": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Seconds left before the runtime lease expires. Negative when already expired, 0 when there is no expiration date",
+						},
+						"storage_lease_remaining_sec": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Seconds left before the storage lease expires. Negative when already expired, 0 when there is no expiration date",
+						},
+					},
+				},
+			},
+			"expiring": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the vApps with any lease expiring within 'expiring_within_sec'",
+			},
+		},
+	}
+}
+
+func datasourceVcdVappLeaseExpiryRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	if err != nil {
+		return diag.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+
+	now := time.Now()
+	expiringWithin := d.Get("expiring_within_sec").(int)
+	var vapps []map[string]interface{}
+	var expiring []string
+	for _, vappRef := range vdc.GetVappList() {
+		vapp, err := vdc.GetVAppByHref(vappRef.HREF)
+		if err != nil {
+			return diag.Errorf("[vApp lease expiry read] error retrieving vApp '%s': %s", vappRef.Name, err)
+		}
+		lease, err := vapp.GetLease()
+		if err != nil {
+			return diag.Errorf("[vApp lease expiry read] error retrieving lease of vApp '%s': %s", vappRef.Name, err)
+		}
+		status, err := vapp.GetStatus()
+		if err != nil {
+			return diag.Errorf("[vApp lease expiry read] error retrieving status of vApp '%s': %s", vappRef.Name, err)
+		}
+		vappLease, isExpiring, err := flattenVappLeaseExpiry(vapp.VApp.Name, vapp.VApp.ID, status, lease, expiringWithin, now)
+		if err != nil {
+			return diag.Errorf("[vApp lease expiry read] vApp '%s': %s", vappRef.Name, err)
+		}
+		vapps = append(vapps, vappLease)
+		if isExpiring {
+			expiring = append(expiring, vapp.VApp.Name)
+		}
+	}
+
+	err = d.Set("vapp", vapps)
+	if err != nil {
+		return diag.Errorf("[vApp lease expiry read] error setting vApp leases: %s", err)
+	}
+	err = d.Set("expiring", convertStringsToTypeSet(expiring))
+	if err != nil {
+		return diag.Errorf("[vApp lease expiry read] error setting expiring vApps: %s", err)
+	}
+
+	d.SetId(vdc.Vdc.ID)
+
+	return nil
+}
+
+// flattenVappLeaseExpiry converts the lease of a vApp into a 'vapp' block, and tells whether any of the leases expires
+// within the given number of seconds
+func flattenVappLeaseExpiry(name, id, status string, lease *types.LeaseSettingsSection, expiringWithin int, now time.Time) (map[string]interface{}, bool, error) {
+	runtimeRemaining, runtimeExpires, err := vappLeaseRemainingSeconds(lease.DeploymentLeaseExpiration, now)
+	if err != nil {
+		return nil, false, err
+	}
+	storageRemaining, storageExpires, err := vappLeaseRemainingSeconds(lease.StorageLeaseExpiration, now)
+	if err != nil {
+		return nil, false, err
+	}
+	vappLease := map[string]interface{}{
+		"name":                        name,
+		"id":                          id,
+		"status":                      status,
+		"runtime_lease_in_sec":        lease.DeploymentLeaseInSeconds,
+		"storage_lease_in_sec":        lease.StorageLeaseInSeconds,
+		"runtime_lease_expiration":    lease.DeploymentLeaseExpiration,
+		"storage_lease_expiration":    lease.StorageLeaseExpiration,
+		"runtime_lease_remaining_sec": runtimeRemaining,
+		"storage_lease_remaining_sec": storageRemaining,
+	}
+	isExpiring := (runtimeExpires && runtimeRemaining <= expiringWithin) ||
+		(storageExpires && storageRemaining <= expiringWithin)
+	return vappLease, isExpiring, nil
+}
@@ -0,0 +1,135 @@
+package vcd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// vmMksTicket is the ticket returned by the 'screen:acquireMksTicket' action of a VM, which allows opening a WebMKS
+// console connection to the VM
+type vmMksTicket struct {
+	XMLName xml.Name `xml:"MksTicket"`
+	Host    string   `xml:"Host"`
+	Vmx     string   `xml:"Vmx"`
+	Ticket  string   `xml:"Ticket"`
+	Port    int      `xml:"Port"`
+}
+
+// vmScreenTicket is the ticket returned by the 'screen:acquireTicket' action of a VM, used by the VMware Remote
+// Console (VMRC)
+type vmScreenTicket struct {
+	XMLName xml.Name `xml:"ScreenTicket"`
+	Value   string   `xml:",chardata"`
+}
+
+func datasourceVcdVmConsoleTicket() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdVmConsoleTicketRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vm_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the VM. The VM must be powered on",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Host of the console proxy to connect to",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Port of the console proxy to connect to",
+			},
+			"vmx": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "VMX reference of the VM, as returned with the MKS ticket",
+			},
+			"ticket": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "WebMKS ticket",
+			},
+			"websocket_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "WebMKS URL which includes the ticket, to be used by a WebMKS client",
+			},
+			"screen_ticket": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Screen ticket to be used by the VMware Remote Console (VMRC)",
+			},
+		},
+	}
+}
+
+func datasourceVcdVmConsoleTicketRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf("[VM console ticket read] error retrieving Org: %s", err)
+	}
+
+	vmId := d.Get("vm_id").(string)
+	vm, err := org.QueryVmById(vmId)
+	if err != nil {
+		return diag.Errorf("[VM console ticket read] error retrieving VM '%s': %s", vmId, err)
+	}
+
+	mksTicket := &vmMksTicket{}
+	err = acquireVmConsoleTicket(vcdClient, vm, types.RelScreenAcquireMksTicket, mksTicket)
+	if err != nil {
+		return diag.Errorf("[VM console ticket read] error acquiring MKS ticket for VM '%s': %s", vm.VM.Name, err)
+	}
+	screenTicket := &vmScreenTicket{}
+	err = acquireVmConsoleTicket(vcdClient, vm, types.RelScreenAcquireTicket, screenTicket)
+	if err != nil {
+		return diag.Errorf("[VM console ticket read] error acquiring screen ticket for VM '%s': %s", vm.VM.Name, err)
+	}
+
+	dSet(d, "host", mksTicket.Host)
+	dSet(d, "port", mksTicket.Port)
+	dSet(d, "vmx", mksTicket.Vmx)
+	dSet(d, "ticket", mksTicket.Ticket)
+	dSet(d, "websocket_url", vmConsoleWebsocketUrl(mksTicket))
+	dSet(d, "screen_ticket", screenTicket.Value)
+
+	d.SetId(vm.VM.ID)
+
+	return nil
+}
+
+// acquireVmConsoleTicket runs one of the screen ticket actions of a VM, which are only available while the VM is
+// powered on
+func acquireVmConsoleTicket(vcdClient *VCDClient, vm *govcd.VM, rel string, ticket interface{}) error {
+	link := vm.VM.Link.Find(func(link *types.Link) bool { return link.Rel == rel })
+	if link == nil {
+		return fmt.Errorf("the VM doesn't allow the '%s' action. The VM must be powered on", rel)
+	}
+	_, err := vcdClient.Client.ExecuteRequest(link.HREF, http.MethodPost, "", "error acquiring ticket: %s", nil, ticket)
+	return err
+}
+
+// vmConsoleWebsocketUrl returns the URL used by WebMKS clients to open a console connection with the given ticket
+func vmConsoleWebsocketUrl(ticket *vmMksTicket) string {
+	return fmt.Sprintf("wss://%s/%d;%s", ticket.Host, ticket.Port, ticket.Ticket)
+}
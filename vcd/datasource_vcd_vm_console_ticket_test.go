@@ -0,0 +1,71 @@
+//go:build vm || ALL || functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdVmConsoleTicketDS(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":      testConfig.VCD.Org,
+		"Vdc":      testConfig.Nsxt.Vdc,
+		"VmName":   t.Name(),
+		"FuncName": t.Name(),
+		"Tags":     "vm",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdVmConsoleTicketDS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	dataSourceName := "data.vcd_vm_console_ticket.console"
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", "vcd_vm.vm", "id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "host"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "port"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "ticket"),
+					resource.TestMatchResourceAttr(dataSourceName, "websocket_url", regexp.MustCompile(`^wss://.+/\d+;.+`)),
+					resource.TestCheckResourceAttrSet(dataSourceName, "screen_ticket"),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdVmConsoleTicketDS = `
+resource "vcd_vm" "vm" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VmName}}"
+
+  computer_name    = "console-vm"
+  power_on         = true
+  memory           = 1024
+  cpus             = 1
+  cpu_cores        = 1
+  os_type          = "sles10_64Guest"
+  hardware_version = "vmx-14"
+}
+
+data "vcd_vm_console_ticket" "console" {
+  org   = "{{.Org}}"
+  vm_id = vcd_vm.vm.id
+}
+`
@@ -0,0 +1,41 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+// Test_vmConsoleTickets checks the parsing of the console tickets returned by VCD and the WebMKS URL built from them
+func Test_vmConsoleTickets(t *testing.T) {
+	mksTicketXml := `<MksTicket xmlns="http://www.vmware.com/vcloud/v1.5">
+    <Host>vcd.example.com</Host>
+    <Vmx>vm-1234</Vmx>
+    <Ticket>cst-abcdef</Ticket>
+    <Port>443</Port>
+</MksTicket>`
+	var mksTicket vmMksTicket
+	err := xml.Unmarshal([]byte(mksTicketXml), &mksTicket)
+	if err != nil {
+		t.Fatalf("error parsing MKS ticket: %s", err)
+	}
+	want := vmMksTicket{XMLName: mksTicket.XMLName, Host: "vcd.example.com", Vmx: "vm-1234", Ticket: "cst-abcdef", Port: 443}
+	if mksTicket != want {
+		t.Errorf("expected MKS ticket %+v, got %+v", want, mksTicket)
+	}
+	wantUrl := "wss://vcd.example.com/443;cst-abcdef"
+	if url := vmConsoleWebsocketUrl(&mksTicket); url != wantUrl {
+		t.Errorf("expected WebMKS URL '%s', got '%s'", wantUrl, url)
+	}
+
+	screenTicketXml := `<ScreenTicket xmlns="http://www.vmware.com/vcloud/v1.5">mks://vcd.example.com/vm-1234/ticket</ScreenTicket>`
+	var screenTicket vmScreenTicket
+	err = xml.Unmarshal([]byte(screenTicketXml), &screenTicket)
+	if err != nil {
+		t.Fatalf("error parsing screen ticket: %s", err)
+	}
+	if screenTicket.Value != "mks://vcd.example.com/vm-1234/ticket" {
+		t.Errorf("unexpected screen ticket '%s'", screenTicket.Value)
+	}
+}
@@ -0,0 +1,78 @@
+package vcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdVmSnapshot() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdVmSnapshotRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vapp_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The vApp owning the snapshot",
+			},
+			"vm_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The VM owning the snapshot. When empty, the snapshot of the whole vApp is retrieved",
+			},
+			"exists": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the vApp or VM has a snapshot",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation date of the snapshot",
+			},
+			"powered_on": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the snapshot was taken while the VM was powered on",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the snapshot, in bytes",
+			},
+		},
+	}
+}
+
+func datasourceVcdVmSnapshotRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	target, err := getSnapshotTarget(vcdClient, d)
+	if err != nil {
+		return diag.Errorf("[vm snapshot DS read] %s", err)
+	}
+
+	snapshot, err := getCurrentSnapshot(vcdClient, target)
+	if err != nil {
+		return diag.Errorf("[vm snapshot DS read] error retrieving snapshot of %s: %s", snapshotTargetDescription(d), err)
+	}
+
+	dSet(d, "exists", snapshot != nil)
+	if snapshot != nil {
+		setSnapshotData(d, snapshot)
+	}
+	d.SetId(target.id)
+	return nil
+}
@@ -0,0 +1,184 @@
+package vcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	vcdtypes "github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// privateKeyApiTokenId is the key of the private data where the ID of the API token is kept between Open and Close
+const privateKeyApiTokenId = "api_token_id"
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure = &ephemeralVcdApiToken{}
+	_ ephemeral.EphemeralResourceWithClose     = &ephemeralVcdApiToken{}
+)
+
+// ephemeralVcdApiToken creates an API token that is only available during the Terraform operation, and that is
+// deleted from VCD when Terraform doesn't need it anymore. Unlike the vcd_api_token resource, the token is never
+// saved to a file or to the state
+type ephemeralVcdApiToken struct {
+	vcdClient *VCDClient
+}
+
+type ephemeralVcdApiTokenModel struct {
+	Name        types.String `tfsdk:"name"`
+	Id          types.String `tfsdk:"id"`
+	ApiToken    types.String `tfsdk:"api_token"`
+	AccessToken types.String `tfsdk:"access_token"`
+	TokenType   types.String `tfsdk:"token_type"`
+	ExpiresIn   types.Int64  `tfsdk:"expires_in"`
+}
+
+func newEphemeralVcdApiToken() ephemeral.EphemeralResource {
+	return &ephemeralVcdApiToken{}
+}
+
+func (e *ephemeralVcdApiToken) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (e *ephemeralVcdApiToken) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates an API token that is deleted when Terraform no longer needs it",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of API token",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the API token",
+			},
+			"api_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "API token, usable in the 'api_token' argument of the provider",
+			},
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Bearer token of the session opened with the API token",
+			},
+			"token_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Type of the access token",
+			},
+			"expires_in": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Seconds before the access token expires",
+			},
+		},
+	}
+}
+
+func (e *ephemeralVcdApiToken) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.vcdClient = frameworkVcdClient(req.ProviderData, &resp.Diagnostics)
+}
+
+func (e *ephemeralVcdApiToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model ephemeralVcdApiTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, refresh, err := createEphemeralApiToken(e.vcdClient, model.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("[API token open] error creating API token", err.Error())
+		return
+	}
+	privateTokenId, err := json.Marshal(token.Token.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("[API token open] error storing API token ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyApiTokenId, privateTokenId)...)
+
+	model.Id = types.StringValue(token.Token.ID)
+	model.ApiToken = types.StringValue(refresh.RefreshToken)
+	model.AccessToken = types.StringValue(refresh.AccessToken)
+	model.TokenType = types.StringValue(refresh.TokenType)
+	model.ExpiresIn = types.Int64Value(int64(refresh.ExpiresIn))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+func (e *ephemeralVcdApiToken) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	tokenId, diags := getPrivateApiTokenId(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || tokenId == "" {
+		return
+	}
+	err := deleteEphemeralApiToken(e.vcdClient, tokenId)
+	if err != nil {
+		resp.Diagnostics.AddError("[API token close] error deleting API token", err.Error())
+	}
+}
+
+// createEphemeralApiToken creates an API token for the current user and retrieves its value, which can only be
+// read once
+func createEphemeralApiToken(vcdClient *VCDClient, tokenName string) (*govcd.Token, *vcdtypes.ApiTokenRefresh, error) {
+	if vcdClient == nil {
+		return nil, nil, fmt.Errorf("the provider is not configured")
+	}
+
+	// System Admin can't create API tokens outside SysOrg,
+	// just as Org admins can't create API tokens in other Orgs
+	org := vcdClient.SysOrg
+	if org == "" {
+		org = vcdClient.Org
+	}
+
+	token, err := vcdClient.CreateToken(org, tokenName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refresh, err := token.GetInitialApiToken()
+	if err != nil {
+		// The token value can't be retrieved anymore, so the token is useless
+		if deleteErr := token.Delete(); deleteErr != nil {
+			return nil, nil, fmt.Errorf("error getting refresh token from API token: %s. Additionally, the API token '%s' could not be removed: %s",
+				err, token.Token.ID, deleteErr)
+		}
+		return nil, nil, fmt.Errorf("error getting refresh token from API token: %s", err)
+	}
+	return token, refresh, nil
+}
+
+// deleteEphemeralApiToken removes an API token created by createEphemeralApiToken. A token that is already gone is
+// not considered an error
+func deleteEphemeralApiToken(vcdClient *VCDClient, tokenId string) error {
+	if vcdClient == nil {
+		return fmt.Errorf("the provider is not configured")
+	}
+	token, err := vcdClient.GetTokenById(tokenId)
+	if govcd.ContainsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting API token '%s': %s", tokenId, err)
+	}
+	return token.Delete()
+}
+
+// getPrivateApiTokenId retrieves the ID of the API token stored in the private data during Open
+func getPrivateApiTokenId(ctx context.Context, req ephemeral.CloseRequest) (string, diag.Diagnostics) {
+	value, diags := req.Private.GetKey(ctx, privateKeyApiTokenId)
+	if diags.HasError() || len(value) == 0 {
+		return "", diags
+	}
+	var tokenId string
+	err := json.Unmarshal(value, &tokenId)
+	if err != nil {
+		diags.AddError("error reading API token ID", err.Error())
+	}
+	return tokenId, diags
+}
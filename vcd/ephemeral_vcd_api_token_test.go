@@ -0,0 +1,94 @@
+//go:build api || ALL || functional
+
+package vcd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccVcdEphemeralApiToken checks that the ephemeral API and session tokens are created during the operation
+// and removed from VCD once Terraform doesn't need them anymore.
+// Ephemeral resources require Terraform 1.10+
+func TestAccVcdEphemeralApiToken(t *testing.T) {
+	preTestChecks(t)
+	skipTestForServiceAccountAndApiToken(t)
+
+	if checkVersion(testConfig.Provider.ApiVersion, "< 36.1") {
+		t.Skipf("API tokens require VCD 10.3.1+ (API v36.1+)")
+	}
+
+	var params = StringMap{
+		"TokenName": t.Name(),
+		"Org":       testConfig.VCD.Org,
+		"FuncName":  t.Name(),
+		"Tags":      "api",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdEphemeralApiToken, params)
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccMuxProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vcd_org.test", "name", testConfig.VCD.Org),
+					testAccCheckEphemeralApiTokensRemoved(params["TokenName"].(string)),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+// testAccCheckEphemeralApiTokensRemoved checks that neither the given API token nor any temporary session token
+// are left in VCD after the ephemeral resources are closed
+func testAccCheckEphemeralApiTokensRemoved(tokenName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*VCDClient)
+
+		tokens, err := conn.GetAllTokens(nil)
+		if err != nil {
+			return fmt.Errorf("error retrieving API tokens: %s", err)
+		}
+		var leftovers []string
+		for _, token := range tokens {
+			if token.Token.Name == tokenName || strings.HasPrefix(token.Token.Name, "terraform-session-") {
+				leftovers = append(leftovers, token.Token.Name)
+			}
+		}
+		if len(leftovers) > 0 {
+			return fmt.Errorf("API tokens still exist after closing the ephemeral resources: %v", leftovers)
+		}
+		return nil
+	}
+}
+
+// #nosec G101 -- No hardcoded credentials here
+const testAccVcdEphemeralApiToken = `
+ephemeral "vcd_api_token" "test" {
+  name = "{{.TokenName}}"
+}
+
+ephemeral "vcd_session_token" "test" {
+}
+
+locals {
+  token_types = [ephemeral.vcd_api_token.test.token_type, ephemeral.vcd_session_token.test.token_type]
+}
+
+data "vcd_org" "test" {
+  name = "{{.Org}}"
+}
+`
@@ -0,0 +1,227 @@
+package vcd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/yaml"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralVcdCseKubeconfig{}
+
+// ephemeralVcdCseKubeconfig retrieves the Kubeconfig of a CSE Kubernetes cluster without storing it in the state,
+// unlike the 'kubeconfig' attribute of vcd_cse_kubernetes_cluster. The connection details of the current context are
+// also returned, so that they can be used directly in the configuration of the kubernetes and helm providers
+type ephemeralVcdCseKubeconfig struct {
+	vcdClient *VCDClient
+}
+
+type ephemeralVcdCseKubeconfigModel struct {
+	ClusterId            types.String `tfsdk:"cluster_id"`
+	Kubeconfig           types.String `tfsdk:"kubeconfig"`
+	Host                 types.String `tfsdk:"host"`
+	ClusterCaCertificate types.String `tfsdk:"cluster_ca_certificate"`
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+	Token                types.String `tfsdk:"token"`
+}
+
+func newEphemeralVcdCseKubeconfig() ephemeral.EphemeralResource {
+	return &ephemeralVcdCseKubeconfig{}
+}
+
+func (e *ephemeralVcdCseKubeconfig) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cse_kubeconfig"
+}
+
+func (e *ephemeralVcdCseKubeconfig) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the Kubeconfig of a provisioned Kubernetes cluster created with Container Service Extension",
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the Kubernetes cluster",
+			},
+			"kubeconfig": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The contents of the Kubeconfig of the Kubernetes cluster",
+			},
+			"host": schema.StringAttribute{
+				Computed:    true,
+				Description: "Address of the Kubernetes API server of the current context",
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Computed:    true,
+				Description: "PEM-encoded CA certificate of the Kubernetes API server of the current context",
+			},
+			"client_certificate": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM-encoded client certificate of the user of the current context",
+			},
+			"client_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM-encoded client key of the user of the current context",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Bearer token of the user of the current context, if any",
+			},
+		},
+	}
+}
+
+func (e *ephemeralVcdCseKubeconfig) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.vcdClient = frameworkVcdClient(req.ProviderData, &resp.Diagnostics)
+}
+
+func (e *ephemeralVcdCseKubeconfig) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model ephemeralVcdCseKubeconfigModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if e.vcdClient == nil {
+		resp.Diagnostics.AddError("[CSE Kubeconfig open] the provider is not configured", "")
+		return
+	}
+
+	clusterId := model.ClusterId.ValueString()
+	cluster, err := e.vcdClient.CseGetKubernetesClusterById(clusterId)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("[CSE Kubeconfig open] could not retrieve the Kubernetes cluster with ID '%s'", clusterId), err.Error())
+		return
+	}
+	kubeconfig, err := cluster.GetKubeconfig(false)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("[CSE Kubeconfig open] error getting Kubeconfig for the Kubernetes cluster with ID '%s'", clusterId), err.Error())
+		return
+	}
+	connection, err := parseKubeconfig(kubeconfig)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("[CSE Kubeconfig open] error reading Kubeconfig of the Kubernetes cluster with ID '%s'", clusterId), err.Error())
+		return
+	}
+
+	model.Kubeconfig = types.StringValue(kubeconfig)
+	model.Host = types.StringValue(connection.host)
+	model.ClusterCaCertificate = types.StringValue(connection.clusterCaCertificate)
+	model.ClientCertificate = types.StringValue(connection.clientCertificate)
+	model.ClientKey = types.StringValue(connection.clientKey)
+	model.Token = types.StringValue(connection.token)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+// kubeconfigConnection contains the connection details of a Kubeconfig context
+type kubeconfigConnection struct {
+	host                 string
+	clusterCaCertificate string
+	clientCertificate    string
+	clientKey            string
+	token                string
+}
+
+// kubeconfigFile contains the subset of the Kubeconfig file format needed to obtain the connection details
+type kubeconfigFile struct {
+	CurrentContext string `json:"current-context"`
+	Clusters       []struct {
+		Name    string `json:"name"`
+		Cluster struct {
+			Server                   string `json:"server"`
+			CertificateAuthorityData string `json:"certificate-authority-data"`
+		} `json:"cluster"`
+	} `json:"clusters"`
+	Contexts []struct {
+		Name    string `json:"name"`
+		Context struct {
+			Cluster string `json:"cluster"`
+			User    string `json:"user"`
+		} `json:"context"`
+	} `json:"contexts"`
+	Users []struct {
+		Name string `json:"name"`
+		User struct {
+			ClientCertificateData string `json:"client-certificate-data"`
+			ClientKeyData         string `json:"client-key-data"`
+			Token                 string `json:"token"`
+		} `json:"user"`
+	} `json:"users"`
+}
+
+// parseKubeconfig returns the connection details of the current context of the given Kubeconfig. When no current
+// context is set, the first context is used. Certificates and keys are returned decoded, in PEM format
+func parseKubeconfig(kubeconfig string) (*kubeconfigConnection, error) {
+	var file kubeconfigFile
+	err := yaml.Unmarshal([]byte(kubeconfig), &file)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Kubeconfig: %s", err)
+	}
+	if len(file.Contexts) == 0 {
+		return nil, fmt.Errorf("the Kubeconfig doesn't have any context")
+	}
+
+	contextIndex := 0
+	if file.CurrentContext != "" {
+		contextIndex = -1
+		for i, kubeContext := range file.Contexts {
+			if kubeContext.Name == file.CurrentContext {
+				contextIndex = i
+				break
+			}
+		}
+		if contextIndex < 0 {
+			return nil, fmt.Errorf("the current context '%s' was not found in the Kubeconfig", file.CurrentContext)
+		}
+	}
+	kubeContext := file.Contexts[contextIndex].Context
+
+	connection := &kubeconfigConnection{}
+	clusterFound := false
+	for _, cluster := range file.Clusters {
+		if cluster.Name != kubeContext.Cluster {
+			continue
+		}
+		clusterFound = true
+		connection.host = cluster.Cluster.Server
+		connection.clusterCaCertificate, err = decodeKubeconfigData(cluster.Cluster.CertificateAuthorityData)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding the CA certificate of cluster '%s': %s", cluster.Name, err)
+		}
+		break
+	}
+	if !clusterFound {
+		return nil, fmt.Errorf("the cluster '%s' was not found in the Kubeconfig", kubeContext.Cluster)
+	}
+
+	for _, user := range file.Users {
+		if user.Name != kubeContext.User {
+			continue
+		}
+		connection.clientCertificate, err = decodeKubeconfigData(user.User.ClientCertificateData)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding the client certificate of user '%s': %s", user.Name, err)
+		}
+		connection.clientKey, err = decodeKubeconfigData(user.User.ClientKeyData)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding the client key of user '%s': %s", user.Name, err)
+		}
+		connection.token = user.User.Token
+		return connection, nil
+	}
+	return nil, fmt.Errorf("the user '%s' was not found in the Kubeconfig", kubeContext.User)
+}
+
+func decodeKubeconfigData(data string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
@@ -0,0 +1,101 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func Test_parseKubeconfig(t *testing.T) {
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	kubeconfigTemplate := `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: %s
+    server: https://other.example.com:6443
+  name: other
+- cluster:
+    certificate-authority-data: %s
+    server: https://10.10.10.10:6443
+  name: test
+contexts:
+- context:
+    cluster: other
+    user: other-admin
+  name: other-admin@other
+- context:
+    cluster: test
+    user: test-admin
+  name: test-admin@test
+current-context: %s
+kind: Config
+preferences: {}
+users:
+- name: test-admin
+  user:
+    client-certificate-data: %s
+    client-key-data: %s
+- name: other-admin
+  user:
+    token: other-token
+`
+	kubeconfig := func(currentContext string) string {
+		return fmt.Sprintf(kubeconfigTemplate, encode("other-ca"), encode("test-ca"), currentContext, encode("test-cert"), encode("test-key"))
+	}
+
+	tests := []struct {
+		name       string
+		kubeconfig string
+		want       *kubeconfigConnection
+		wantErr    bool
+	}{
+		{
+			name:       "current context",
+			kubeconfig: kubeconfig("test-admin@test"),
+			want: &kubeconfigConnection{
+				host:                 "https://10.10.10.10:6443",
+				clusterCaCertificate: "test-ca",
+				clientCertificate:    "test-cert",
+				clientKey:            "test-key",
+			},
+		},
+		{
+			name:       "no current context uses the first context",
+			kubeconfig: kubeconfig(`""`),
+			want: &kubeconfigConnection{
+				host:                 "https://other.example.com:6443",
+				clusterCaCertificate: "other-ca",
+				token:                "other-token",
+			},
+		},
+		{
+			name:       "unknown current context",
+			kubeconfig: kubeconfig("missing"),
+			wantErr:    true,
+		},
+		{
+			name:       "no contexts",
+			kubeconfig: "apiVersion: v1\nkind: Config\n",
+			wantErr:    true,
+		},
+		{
+			name:       "invalid YAML",
+			kubeconfig: "clusters: [",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseKubeconfig(tt.kubeconfig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseKubeconfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKubeconfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package vcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure = &ephemeralVcdSessionToken{}
+	_ ephemeral.EphemeralResourceWithClose     = &ephemeralVcdSessionToken{}
+)
+
+// ephemeralVcdSessionToken opens a new session for the current user and returns its bearer token.
+// The session is opened with a temporary API token, which is deleted when Terraform no longer needs the session,
+// so that the session can't be renewed and expires following the session timeouts of VCD
+type ephemeralVcdSessionToken struct {
+	vcdClient *VCDClient
+}
+
+type ephemeralVcdSessionTokenModel struct {
+	Org         types.String `tfsdk:"org"`
+	AccessToken types.String `tfsdk:"access_token"`
+	TokenType   types.String `tfsdk:"token_type"`
+	ExpiresIn   types.Int64  `tfsdk:"expires_in"`
+}
+
+func newEphemeralVcdSessionToken() ephemeral.EphemeralResource {
+	return &ephemeralVcdSessionToken{}
+}
+
+func (e *ephemeralVcdSessionToken) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session_token"
+}
+
+func (e *ephemeralVcdSessionToken) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Opens a short-lived session for the user of the provider and returns its bearer token",
+		Attributes: map[string]schema.Attribute{
+			"org": schema.StringAttribute{
+				Computed:    true,
+				Description: "Organization of the session",
+			},
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Bearer token of the session, usable in the 'token' argument of the provider with 'auth_type = \"token\"'",
+			},
+			"token_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Type of the access token",
+			},
+			"expires_in": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Seconds before the access token expires",
+			},
+		},
+	}
+}
+
+func (e *ephemeralVcdSessionToken) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.vcdClient = frameworkVcdClient(req.ProviderData, &resp.Diagnostics)
+}
+
+func (e *ephemeralVcdSessionToken) Open(ctx context.Context, _ ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	tokenName := fmt.Sprintf("terraform-session-%d", time.Now().UnixNano())
+	token, refresh, err := createEphemeralApiToken(e.vcdClient, tokenName)
+	if err != nil {
+		resp.Diagnostics.AddError("[session token open] error creating temporary API token", err.Error())
+		return
+	}
+	privateTokenId, err := json.Marshal(token.Token.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("[session token open] error storing API token ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyApiTokenId, privateTokenId)...)
+
+	org := ""
+	if token.Token.Org != nil {
+		org = token.Token.Org.Name
+	}
+	model := ephemeralVcdSessionTokenModel{
+		Org:         types.StringValue(org),
+		AccessToken: types.StringValue(refresh.AccessToken),
+		TokenType:   types.StringValue(refresh.TokenType),
+		ExpiresIn:   types.Int64Value(int64(refresh.ExpiresIn)),
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+func (e *ephemeralVcdSessionToken) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	tokenId, diags := getPrivateApiTokenId(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || tokenId == "" {
+		return
+	}
+	err := deleteEphemeralApiToken(e.vcdClient, tokenId)
+	if err != nil {
+		resp.Diagnostics.AddError("[session token close] error deleting temporary API token", err.Error())
+	}
+}
@@ -0,0 +1,95 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &functionHrefToId{}
+
+// hrefUrnTypes contains the URN types that can't be derived by lower-casing the entity name found in the HREF
+var hrefUrnTypes = map[string]string{
+	"edgegateway":       "gateway",
+	"vdcstorageprofile": "vdcstorageProfile",
+}
+
+// functionHrefToId implements provider::vcd::href_to_id
+type functionHrefToId struct{}
+
+func newFunctionHrefToId() function.Function {
+	return &functionHrefToId{}
+}
+
+func (f *functionHrefToId) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "href_to_id"
+}
+
+func (f *functionHrefToId) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts an HREF into a URN",
+		Description: "Returns the VCD URN of the entity referenced by an HREF, such as " +
+			"'https://vcd.example.com/api/vApp/vm-<uuid>' which becomes 'urn:vcloud:vm:<uuid>'",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "href",
+				Description: "HREF of the entity",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionHrefToId) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var href string
+	resp.Error = req.Arguments.Get(ctx, &href)
+	if resp.Error != nil {
+		return
+	}
+	id, err := hrefToId(href)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, id)
+}
+
+// hrefToId converts an HREF into the URN of the entity it references. OpenAPI HREFs already contain the URN, which
+// is returned as is. In legacy API HREFs, the entity type is either the prefix of the UUID ('/api/vApp/vm-<uuid>')
+// or the path element that precedes it ('/api/vdc/<uuid>')
+func hrefToId(href string) (string, error) {
+	parsedHref, err := url.Parse(href)
+	if err != nil {
+		return "", fmt.Errorf("'%s' is not a valid HREF: %s", href, err)
+	}
+	pathElements := strings.Split(strings.Trim(parsedHref.Path, "/"), "/")
+
+	uuidRegex := getUuidRegex("", "$")
+	for i := len(pathElements) - 1; i >= 0; i-- {
+		element := pathElements[i]
+		uuid := uuidRegex.FindString(element)
+		if uuid == "" {
+			continue
+		}
+		if strings.HasPrefix(element, "urn:vcloud:") {
+			return element, nil
+		}
+
+		entityType := strings.TrimSuffix(strings.TrimSuffix(element, uuid), "-")
+		if entityType == "" && i > 0 {
+			entityType = pathElements[i-1]
+		}
+		if entityType == "" {
+			break
+		}
+		entityType = strings.ToLower(entityType)
+		if urnType, ok := hrefUrnTypes[entityType]; ok {
+			entityType = urnType
+		}
+		return uuidToUrn(entityType, uuid), nil
+	}
+	return "", fmt.Errorf("no entity reference found in '%s'", href)
+}
@@ -0,0 +1,121 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &functionIpRangeContains{}
+
+// functionIpRangeContains implements provider::vcd::ip_range_contains
+type functionIpRangeContains struct{}
+
+func newFunctionIpRangeContains() function.Function {
+	return &functionIpRangeContains{}
+}
+
+func (f *functionIpRangeContains) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ip_range_contains"
+}
+
+func (f *functionIpRangeContains) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether an IP address belongs to an IP range",
+		Description: "Returns true when the IP address is part of the IP range, which can be either a CIDR " +
+			"('192.168.1.0/24') or a range of addresses ('192.168.1.10-192.168.1.20'). Both IPv4 and IPv6 are supported",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "ip_range",
+				Description: "CIDR or range of addresses, with the start and end addresses separated by '-'",
+			},
+			function.StringParameter{
+				Name:        "ip",
+				Description: "IP address to check",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *functionIpRangeContains) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ipRange, ip string
+	resp.Error = req.Arguments.Get(ctx, &ipRange, &ip)
+	if resp.Error != nil {
+		return
+	}
+	startAddress, endAddress, err := parseIpRange(ipRange)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	address, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("'%s' is not a valid IP address", ip))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, ipRangeContains(startAddress, endAddress, address))
+}
+
+// parseIpRange returns the first and last addresses of a CIDR or of a range of addresses separated by '-'
+func parseIpRange(ipRange string) (netip.Addr, netip.Addr, error) {
+	ipRange = strings.TrimSpace(ipRange)
+	if strings.Contains(ipRange, "/") {
+		prefix, err := netip.ParsePrefix(ipRange)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("'%s' is not a valid CIDR: %s", ipRange, err)
+		}
+		prefix = prefix.Masked()
+		return prefix.Addr(), lastAddressOfPrefix(prefix), nil
+	}
+
+	startText, endText, isRange := strings.Cut(ipRange, "-")
+	if !isRange {
+		endText = startText
+	}
+	startAddress, err := netip.ParseAddr(strings.TrimSpace(startText))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("'%s' is not a valid IP range: %s", ipRange, err)
+	}
+	endAddress, err := netip.ParseAddr(strings.TrimSpace(endText))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("'%s' is not a valid IP range: %s", ipRange, err)
+	}
+	if startAddress.Is4() != endAddress.Is4() {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("'%s' mixes IPv4 and IPv6 addresses", ipRange)
+	}
+	if endAddress.Less(startAddress) {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("the end address of '%s' is lower than the start address", ipRange)
+	}
+	return startAddress, endAddress, nil
+}
+
+// lastAddressOfPrefix returns the last address of a masked prefix, by setting all its host bits
+func lastAddressOfPrefix(prefix netip.Prefix) netip.Addr {
+	addressBytes := prefix.Addr().As16()
+	firstHostBit := prefix.Bits()
+	if prefix.Addr().Is4() {
+		// IPv4 addresses are stored in the last 4 bytes of the 16 bytes representation
+		firstHostBit += 96
+	}
+	for bit := firstHostBit; bit < 128; bit++ {
+		addressBytes[bit/8] |= 1 << (7 - bit%8)
+	}
+	lastAddress := netip.AddrFrom16(addressBytes)
+	if prefix.Addr().Is4() {
+		return lastAddress.Unmap()
+	}
+	return lastAddress
+}
+
+// ipRangeContains returns true if the address is between the start and end addresses, included
+func ipRangeContains(startAddress, endAddress, address netip.Addr) bool {
+	address = address.Unmap()
+	if address.Is4() != startAddress.Is4() {
+		return false
+	}
+	return startAddress.Compare(address) <= 0 && address.Compare(endAddress) <= 0
+}
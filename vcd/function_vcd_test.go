@@ -0,0 +1,72 @@
+//go:build api || ALL || functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdProviderFunctions checks the provider functions, which require Terraform 1.8+
+func TestAccVcdProviderFunctions(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Uuid":     "b5fbb4b4-9b3a-4d0c-a2a5-1e3c4d5e6f70",
+		"FuncName": t.Name(),
+		"Tags":     "api",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdProviderFunctions, params)
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccMuxProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("uuid", params["Uuid"].(string)),
+					resource.TestCheckOutput("urn", "urn:vcloud:vdcGroup:"+params["Uuid"].(string)),
+					resource.TestCheckOutput("vm_id", "urn:vcloud:vm:"+params["Uuid"].(string)),
+					resource.TestCheckOutput("gateway_id", "urn:vcloud:gateway:"+params["Uuid"].(string)),
+					resource.TestCheckOutput("in_cidr", "true"),
+					resource.TestCheckOutput("in_range", "false"),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdProviderFunctions = `
+output "uuid" {
+  value = provider::vcd::urn_to_uuid("urn:vcloud:vm:{{.Uuid}}")
+}
+
+output "urn" {
+  value = provider::vcd::uuid_to_urn("vdcGroup", "{{.Uuid}}")
+}
+
+output "vm_id" {
+  value = provider::vcd::href_to_id("https://vcd.example.com/api/vApp/vm-{{.Uuid}}")
+}
+
+output "gateway_id" {
+  value = provider::vcd::href_to_id("https://vcd.example.com/cloudapi/1.0.0/edgeGateways/urn:vcloud:gateway:{{.Uuid}}")
+}
+
+output "in_cidr" {
+  value = provider::vcd::ip_range_contains("10.10.0.0/16", "10.10.200.1")
+}
+
+output "in_range" {
+  value = provider::vcd::ip_range_contains("10.10.0.10-10.10.0.20", "10.10.0.21")
+}
+`
@@ -0,0 +1,113 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func Test_urnToUuid(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "urn:vcloud:vm:b5fbb4b4-9b3a-4d0c-a2a5-1e3c4d5e6f70", want: "b5fbb4b4-9b3a-4d0c-a2a5-1e3c4d5e6f70"},
+		{input: "https://vcd.example.com/api/vApp/vm-b5fbb4b4-9b3a-4d0c-a2a5-1e3c4d5e6f70", want: "b5fbb4b4-9b3a-4d0c-a2a5-1e3c4d5e6f70"},
+		{input: "b5fbb4b4-9b3a-4d0c-a2a5-1e3c4d5e6f70", want: "b5fbb4b4-9b3a-4d0c-a2a5-1e3c4d5e6f70"},
+		{input: "urn:vcloud:vm:not-a-uuid", wantErr: true},
+		{input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := urnToUuid(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("urnToUuid(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("urnToUuid(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func Test_uuidToUrn(t *testing.T) {
+	got := uuidToUrn("vdcGroup", "b5fbb4b4-9b3a-4d0c-a2a5-1e3c4d5e6f70")
+	want := "urn:vcloud:vdcGroup:b5fbb4b4-9b3a-4d0c-a2a5-1e3c4d5e6f70"
+	if got != want {
+		t.Errorf("uuidToUrn() = %q, want %q", got, want)
+	}
+}
+
+func Test_hrefToId(t *testing.T) {
+	uuid := "b5fbb4b4-9b3a-4d0c-a2a5-1e3c4d5e6f70"
+	tests := []struct {
+		href    string
+		want    string
+		wantErr bool
+	}{
+		{href: "https://vcd.example.com/api/vApp/vm-" + uuid, want: "urn:vcloud:vm:" + uuid},
+		{href: "https://vcd.example.com/api/vApp/vapp-" + uuid, want: "urn:vcloud:vapp:" + uuid},
+		{href: "https://vcd.example.com/api/vAppTemplate/vappTemplate-" + uuid, want: "urn:vcloud:vapptemplate:" + uuid},
+		{href: "https://vcd.example.com/api/vdc/" + uuid, want: "urn:vcloud:vdc:" + uuid},
+		{href: "https://vcd.example.com/api/admin/org/" + uuid, want: "urn:vcloud:org:" + uuid},
+		{href: "https://vcd.example.com/api/catalogItem/" + uuid, want: "urn:vcloud:catalogitem:" + uuid},
+		{href: "https://vcd.example.com/api/admin/edgeGateway/" + uuid, want: "urn:vcloud:gateway:" + uuid},
+		{href: "https://vcd.example.com/api/vdcStorageProfile/" + uuid, want: "urn:vcloud:vdcstorageProfile:" + uuid},
+		{href: "https://vcd.example.com/api/vApp/vm-" + uuid + "/action/powerOn", want: "urn:vcloud:vm:" + uuid},
+		{href: "https://vcd.example.com/cloudapi/1.0.0/edgeGateways/urn:vcloud:gateway:" + uuid, want: "urn:vcloud:gateway:" + uuid},
+		{href: uuid, wantErr: true},
+		{href: "https://vcd.example.com/api/org", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := hrefToId(tt.href)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("hrefToId(%q) error = %v, wantErr %v", tt.href, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("hrefToId(%q) = %q, want %q", tt.href, got, tt.want)
+		}
+	}
+}
+
+func Test_ipRangeContains(t *testing.T) {
+	tests := []struct {
+		ipRange string
+		ip      string
+		want    bool
+		wantErr bool
+	}{
+		{ipRange: "192.168.1.0/24", ip: "192.168.1.0", want: true},
+		{ipRange: "192.168.1.0/24", ip: "192.168.1.255", want: true},
+		{ipRange: "192.168.1.0/24", ip: "192.168.2.1", want: false},
+		{ipRange: "192.168.1.17/28", ip: "192.168.1.31", want: true},
+		{ipRange: "192.168.1.10-192.168.1.20", ip: "192.168.1.10", want: true},
+		{ipRange: "192.168.1.10 - 192.168.1.20", ip: "192.168.1.20", want: true},
+		{ipRange: "192.168.1.10-192.168.1.20", ip: "192.168.1.21", want: false},
+		{ipRange: "192.168.1.10", ip: "192.168.1.10", want: true},
+		{ipRange: "2001:db8::/64", ip: "2001:db8::ffff:ffff:ffff:ffff", want: true},
+		{ipRange: "2001:db8::/64", ip: "2001:db8:0:1::1", want: false},
+		{ipRange: "2001:db8::10-2001:db8::20", ip: "2001:db8::1a", want: true},
+		{ipRange: "192.168.1.0/24", ip: "::ffff:192.168.1.5", want: true},
+		{ipRange: "192.168.1.0/24", ip: "2001:db8::1", want: false},
+		{ipRange: "192.168.1.20-192.168.1.10", wantErr: true},
+		{ipRange: "192.168.1.10-2001:db8::1", wantErr: true},
+		{ipRange: "192.168.1.0/33", wantErr: true},
+		{ipRange: "not-an-ip", wantErr: true},
+	}
+	for _, tt := range tests {
+		startAddress, endAddress, err := parseIpRange(tt.ipRange)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseIpRange(%q) error = %v, wantErr %v", tt.ipRange, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		got := ipRangeContains(startAddress, endAddress, netip.MustParseAddr(tt.ip))
+		if got != tt.want {
+			t.Errorf("ip_range_contains(%q, %q) = %t, want %t", tt.ipRange, tt.ip, got, tt.want)
+		}
+	}
+}
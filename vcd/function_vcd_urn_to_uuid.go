@@ -0,0 +1,58 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &functionUrnToUuid{}
+
+// functionUrnToUuid implements provider::vcd::urn_to_uuid
+type functionUrnToUuid struct{}
+
+func newFunctionUrnToUuid() function.Function {
+	return &functionUrnToUuid{}
+}
+
+func (f *functionUrnToUuid) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "urn_to_uuid"
+}
+
+func (f *functionUrnToUuid) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Extracts the UUID from a URN",
+		Description: "Returns the UUID contained in a VCD URN, such as 'urn:vcloud:vm:<uuid>', or in an HREF",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "urn",
+				Description: "URN or HREF containing a UUID",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionUrnToUuid) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var urn string
+	resp.Error = req.Arguments.Get(ctx, &urn)
+	if resp.Error != nil {
+		return
+	}
+	uuid, err := urnToUuid(urn)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, uuid)
+}
+
+// urnToUuid returns the UUID contained in the given URN or HREF
+func urnToUuid(urn string) (string, error) {
+	uuid := extractUuid(urn)
+	if uuid == "" {
+		return "", fmt.Errorf("no UUID found in '%s'", urn)
+	}
+	return uuid, nil
+}
@@ -0,0 +1,65 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &functionUuidToUrn{}
+
+// urnTypeRegex matches the entity type of a URN, such as 'vm', 'vdc' or 'vdcGroup'
+var urnTypeRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+
+// functionUuidToUrn implements provider::vcd::uuid_to_urn
+type functionUuidToUrn struct{}
+
+func newFunctionUuidToUrn() function.Function {
+	return &functionUuidToUrn{}
+}
+
+func (f *functionUuidToUrn) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "uuid_to_urn"
+}
+
+func (f *functionUuidToUrn) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Builds a URN from an entity type and a UUID",
+		Description: "Returns the VCD URN 'urn:vcloud:<type>:<uuid>' of the entity with the given type and UUID",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "type",
+				Description: "Entity type of the URN, such as 'vm', 'vapp', 'vdc' or 'gateway'",
+			},
+			function.StringParameter{
+				Name:        "uuid",
+				Description: "UUID of the entity",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionUuidToUrn) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var entityType, uuid string
+	resp.Error = req.Arguments.Get(ctx, &entityType, &uuid)
+	if resp.Error != nil {
+		return
+	}
+	if !urnTypeRegex.MatchString(entityType) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("'%s' is not a valid URN type", entityType))
+		return
+	}
+	if !getUuidRegex("^", "$").MatchString(uuid) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("'%s' is not a valid UUID", uuid))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, uuidToUrn(entityType, uuid))
+}
+
+// uuidToUrn returns the URN of an entity with the given type and UUID
+func uuidToUrn(entityType, uuid string) string {
+	return fmt.Sprintf("urn:vcloud:%s:%s", entityType, uuid)
+}
@@ -1,13 +1,20 @@
 package vcd
 
 import (
-	"github.com/vmware/go-vcloud-director/v2/util"
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/util"
 )
 
+// taskPollingInterval defines how often a task is refreshed in waitTaskCompletionWithContext
+var taskPollingInterval = 3 * time.Second
+
 // onlyHasChange is a schema helper which accepts Terraform schema definition and checks if field
 // with `fieldName` is the only one which has change (using d.HasChange)
 func onlyHasChange(fieldName string, schema map[string]*schema.Schema, d *schema.ResourceData) bool {
@@ -31,3 +38,86 @@ func safeClose(file *os.File) {
 		util.Logger.Printf("Error closing file: %s\n", err)
 	}
 }
+
+// waitTaskCompletionWithContext works like govcd.Task.WaitTaskCompletion, but it also honours the
+// deadline of ctx, which is set by the `timeouts` block of resources that define one. When ctx
+// expires before the task is finished, the task is cancelled in VCD so that it does not keep running
+// after Terraform has given up on it. As in the SDK, the task can be monitored by setting the
+// environment variable GOVCD_TASK_MONITOR
+func waitTaskCompletionWithContext(ctx context.Context, task govcd.Task) error {
+	if task.Task == nil {
+		return fmt.Errorf("cannot wait for an empty task")
+	}
+
+	inspectionFunc := taskMonitorInspectionFunc(os.Getenv("GOVCD_TASK_MONITOR"))
+	startTime := time.Now()
+	for howManyTimes := 1; ; howManyTimes++ {
+		err := task.Refresh()
+		if err != nil {
+			return fmt.Errorf("error retrieving task: %s", err)
+		}
+
+		running := task.Task.Status == "queued" || task.Task.Status == "preRunning" || task.Task.Status == "running"
+		if inspectionFunc != nil {
+			inspectionFunc(task.Task, howManyTimes, time.Since(startTime), howManyTimes == 1, !running)
+		}
+		switch {
+		case running:
+		case task.Task.Status == "error":
+			errorMessage := "unknown error"
+			if task.Task.Error != nil {
+				errorMessage = task.Task.Error.Message
+			}
+			return fmt.Errorf("task did not complete successfully: %s", errorMessage)
+		default:
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return cancelTimedOutTask(ctx, &task)
+		case <-time.After(taskPollingInterval):
+		}
+	}
+}
+
+// cancelTimedOutTask cancels a task that Terraform stopped waiting for because ctx expired, and returns the
+// timeout error
+func cancelTimedOutTask(ctx context.Context, task *govcd.Task) error {
+	util.Logger.Printf("[DEBUG] [waitTaskCompletionWithContext] cancelling task %s (%s): %s", task.Task.ID, task.Task.Operation, ctx.Err())
+	cancelErr := task.CancelTask()
+	if cancelErr != nil {
+		return fmt.Errorf("timeout waiting for task '%s' (%s) to complete and it could not be cancelled: %s",
+			task.Task.Operation, task.Task.ID, cancelErr)
+	}
+	return fmt.Errorf("timeout waiting for task '%s' (%s) to complete, the task was cancelled: %s",
+		task.Task.Operation, task.Task.ID, ctx.Err())
+}
+
+// taskMonitorInspectionFunc returns the SDK task inspection function selected by the value of GOVCD_TASK_MONITOR,
+// or nil when the value is empty or unknown
+func taskMonitorInspectionFunc(taskMonitor string) govcd.InspectionFunc {
+	switch taskMonitor {
+	case "log":
+		return govcd.LogTask
+	case "show":
+		return govcd.ShowTask
+	case "simple_log":
+		return govcd.SimpleLogTask
+	case "simple_show":
+		return govcd.SimpleShowTask
+	case "minimal_show":
+		return govcd.MinimalShowTask
+	}
+	return nil
+}
+
+// remainingTimeout returns the time left before the deadline of ctx. When ctx has no deadline, it
+// returns fallback
+func remainingTimeout(ctx context.Context, fallback time.Duration) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fallback
+	}
+	return time.Until(deadline)
+}
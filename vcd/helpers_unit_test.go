@@ -0,0 +1,98 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// testTaskServer returns a server that reports a task as running for the given number of refreshes, and then
+// with the given final status. It counts the cancellation requests
+func testTaskServer(runningRefreshes int32, finalStatus string, cancellations *int32) *httptest.Server {
+	var refreshes int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/action/cancel") {
+			atomic.AddInt32(cancellations, 1)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		status := finalStatus
+		if atomic.AddInt32(&refreshes, 1) <= runningRefreshes {
+			status = "running"
+		}
+		w.Header().Set("Content-Type", types.MimeTask)
+		_, _ = fmt.Fprintf(w, `<Task xmlns="http://www.vmware.com/vcloud/v1.5" status="%s" operation="test" id="urn:vcloud:task:1" href="%s/api/task/1"></Task>`,
+			status, "http://"+r.Host)
+	}))
+}
+
+func Test_waitTaskCompletionWithContext(t *testing.T) {
+	previousInterval := taskPollingInterval
+	taskPollingInterval = 10 * time.Millisecond
+	defer func() { taskPollingInterval = previousInterval }()
+
+	tests := []struct {
+		name              string
+		runningRefreshes  int32
+		finalStatus       string
+		timeout           time.Duration
+		wantErr           string
+		wantCancellations int32
+	}{
+		{name: "success", runningRefreshes: 2, finalStatus: "success"},
+		{name: "error", runningRefreshes: 1, finalStatus: "error", wantErr: "task did not complete successfully"},
+		{name: "timeout", runningRefreshes: 1000, finalStatus: "success", timeout: 50 * time.Millisecond,
+			wantErr: "the task was cancelled", wantCancellations: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cancellations int32
+			server := testTaskServer(tt.runningRefreshes, tt.finalStatus, &cancellations)
+			defer server.Close()
+
+			client := &govcd.Client{Http: *server.Client(), APIVersion: "37.0"}
+			task := govcd.NewTask(client)
+			task.Task.HREF = server.URL + "/api/task/1"
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			err := waitTaskCompletionWithContext(ctx, *task)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("expected error containing '%s', got: %v", tt.wantErr, err)
+			}
+			if got := atomic.LoadInt32(&cancellations); got != tt.wantCancellations {
+				t.Errorf("expected %d task cancellations, got %d", tt.wantCancellations, got)
+			}
+		})
+	}
+}
+
+func Test_taskMonitorInspectionFunc(t *testing.T) {
+	for _, monitor := range []string{"log", "show", "simple_log", "simple_show", "minimal_show"} {
+		if taskMonitorInspectionFunc(monitor) == nil {
+			t.Errorf("expected an inspection function for GOVCD_TASK_MONITOR=%s", monitor)
+		}
+	}
+	for _, monitor := range []string{"", "unknown"} {
+		if taskMonitorInspectionFunc(monitor) != nil {
+			t.Errorf("expected no inspection function for GOVCD_TASK_MONITOR=%s", monitor)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package vcd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// resourceTypeRights contains the rights needed to use a resource type. The view rights are needed both by the data
+// source and by the resource, while the manage rights are needed only by the resource. When manage is nil, the
+// resource can't be managed by tenant users, and only the data source is covered
+type resourceTypeRights struct {
+	view   []string
+	manage []string
+}
+
+const (
+	rightOrgView            = "Organization: View"
+	rightVdcView            = "Organization vDC: View"
+	rightCatalogView        = "Catalog: View Private and Shared Catalogs"
+	rightTemplateView       = "vApp Template / Media: View"
+	rightNetworkView        = "Organization vDC Network: View Properties"
+	rightNetworkEdit        = "Organization vDC Network: Edit Properties"
+	rightGatewayView        = "Organization vDC Gateway: View"
+	rightDistributedFwView  = "Organization vDC Distributed Firewall: View Rules"
+	rightDistributedFwEdit  = "Organization vDC Distributed Firewall: Configure Rules"
+	rightVappCreate         = "vApp: Create / Reconfigure"
+	rightVappDelete         = "vApp: Delete"
+	rightVappEditProperties = "vApp: Edit Properties"
+	rightVappPower          = "vApp: Power Operations"
+	rightAdministratorCtrl  = "General: Administrator Control"
+)
+
+func gatewayServiceRights(service string) resourceTypeRights {
+	return resourceTypeRights{
+		view:   []string{rightGatewayView, "Organization vDC Gateway: View " + service},
+		manage: []string{"Organization vDC Gateway: Configure " + service},
+	}
+}
+
+var (
+	vmRights = resourceTypeRights{
+		view: []string{rightVdcView},
+		manage: []string{rightVappCreate, rightVappDelete, rightVappEditProperties, rightVappPower,
+			"vApp: Edit VM Properties", "vApp: Edit VM CPU", "vApp: Edit VM Memory", "vApp: Edit VM Hard Disk",
+			"vApp: Edit VM Network", "vApp: Edit VM Compute Policy", rightCatalogView, rightTemplateView},
+	}
+	vappRights = resourceTypeRights{
+		view:   []string{rightVdcView},
+		manage: []string{rightVappCreate, rightVappDelete, rightVappEditProperties, rightVappPower},
+	}
+	vappNetworkServiceRights = resourceTypeRights{
+		view:   []string{rightVdcView},
+		manage: []string{rightVappCreate, rightVappEditProperties},
+	}
+	catalogItemRights = resourceTypeRights{
+		view:   []string{rightCatalogView, rightTemplateView},
+		manage: []string{"vApp Template / Media: Create / Upload", "vApp Template / Media: Edit"},
+	}
+	orgNetworkRights = resourceTypeRights{
+		view:   []string{rightNetworkView},
+		manage: []string{rightNetworkEdit},
+	}
+	gatewayNatRights          = gatewayServiceRights("NAT")
+	gatewayFirewallRights     = gatewayServiceRights("Firewall")
+	gatewayIpsecVpnRights     = gatewayServiceRights("IPSec VPN")
+	gatewayDhcpRights         = gatewayServiceRights("DHCP")
+	gatewayLoadBalancerRights = gatewayServiceRights("Load Balancer")
+	distributedFirewallRights = resourceTypeRights{
+		view:   []string{rightDistributedFwView},
+		manage: []string{rightDistributedFwEdit},
+	}
+)
+
+// rightsByResourceType contains the rights needed by the resources and data sources that tenant users can use
+var rightsByResourceType = map[string]resourceTypeRights{
+	"vcd_org":              {view: []string{rightOrgView}},
+	"vcd_org_vdc":          {view: []string{rightVdcView}},
+	"vcd_storage_profile":  {view: []string{rightVdcView}},
+	"vcd_vm_sizing_policy": {view: []string{"Organization vDC Compute Policy: View"}},
+	"vcd_org_settings": {
+		view:   []string{rightOrgView},
+		manage: []string{"Organization: Edit Properties", "Organization: Edit Leases Policy"},
+	},
+	"vcd_org_user": {
+		view:   []string{"Group / User: View"},
+		manage: []string{rightAdministratorCtrl},
+	},
+	"vcd_org_group": {
+		view:   []string{"Group / User: View"},
+		manage: []string{rightAdministratorCtrl},
+	},
+	"vcd_org_saml": {
+		view:   []string{rightOrgView},
+		manage: []string{"Organization: Edit Federation Settings"},
+	},
+	"vcd_org_oidc": {
+		view:   []string{rightOrgView},
+		manage: []string{"Organization: Edit OAuth Settings"},
+	},
+	"vcd_right": {view: []string{"Right: View"}},
+	"vcd_role": {
+		view:   []string{"Right: View"},
+		manage: []string{"Role: Create, Edit, Delete, or Copy"},
+	},
+	"vcd_library_certificate": {
+		view:   []string{"Certificate Library: View"},
+		manage: []string{"Certificate Library: Manage"},
+	},
+
+	"vcd_vapp":    vappRights,
+	"vcd_vm":      vmRights,
+	"vcd_vapp_vm": vmRights,
+	"vcd_cloned_vapp": {
+		view:   []string{rightVdcView},
+		manage: []string{rightVappCreate, rightVappDelete, rightVappPower, "vApp: Copy", rightCatalogView, rightTemplateView},
+	},
+	"vcd_vm_internal_disk": {
+		view:   []string{rightVdcView},
+		manage: []string{"vApp: Edit VM Hard Disk"},
+	},
+	"vcd_vm_snapshot": {
+		view:   []string{rightVdcView},
+		manage: []string{"vApp: Snapshot Operations"},
+	},
+	"vcd_vm_console_ticket": {view: []string{rightVdcView, "vApp: Use Console"}},
+	"vcd_vm_affinity_rule": {
+		view:   []string{rightVdcView},
+		manage: []string{"Organization vDC: VM-VM Affinity Edit"},
+	},
+	"vcd_inserted_media": {
+		view:   []string{rightVdcView},
+		manage: []string{"vApp: Edit VM Properties", rightCatalogView, rightTemplateView},
+	},
+	"vcd_vapp_access_control": {
+		view:   []string{"vApp: View ACL"},
+		manage: []string{"vApp: Sharing"},
+	},
+	"vcd_vapp_lease_renewal": {
+		view:   []string{rightVdcView},
+		manage: []string{rightVappEditProperties},
+	},
+	"vcd_vapp_lease_expiry":   {view: []string{rightVdcView}},
+	"vcd_vapp_network":        vappNetworkServiceRights,
+	"vcd_vapp_org_network":    vappNetworkServiceRights,
+	"vcd_vapp_firewall_rules": vappNetworkServiceRights,
+	"vcd_vapp_nat_rules":      vappNetworkServiceRights,
+	"vcd_vapp_static_routing": vappNetworkServiceRights,
+
+	"vcd_catalog": {
+		view:   []string{rightCatalogView},
+		manage: []string{"Catalog: Create / Delete a Catalog", "Catalog: Edit Properties"},
+	},
+	"vcd_catalog_access_control": {
+		view:   []string{"Catalog: View ACL"},
+		manage: []string{"Catalog: Sharing"},
+	},
+	"vcd_catalog_item":  catalogItemRights,
+	"vcd_catalog_media": catalogItemRights,
+	"vcd_catalog_vapp_template": {
+		view:   catalogItemRights.view,
+		manage: append([]string{"Catalog: Add vApp from My Cloud"}, catalogItemRights.manage...),
+	},
+	"vcd_independent_disk": {
+		view: []string{"Organization vDC Named Disk: View Properties"},
+		manage: []string{"Organization vDC Named Disk: Create", "Organization vDC Named Disk: Delete",
+			"Organization vDC Named Disk: Edit Properties"},
+	},
+
+	"vcd_network_routed":      orgNetworkRights,
+	"vcd_network_isolated":    orgNetworkRights,
+	"vcd_network_routed_v2":   orgNetworkRights,
+	"vcd_network_isolated_v2": orgNetworkRights,
+	"vcd_edgegateway":         {view: []string{rightGatewayView}},
+	"vcd_nsxt_edgegateway":    {view: []string{rightGatewayView}},
+
+	"vcd_nsxv_dnat":                   gatewayNatRights,
+	"vcd_nsxv_snat":                   gatewayNatRights,
+	"vcd_nsxt_nat_rule":               gatewayNatRights,
+	"vcd_nsxv_firewall_rule":          gatewayFirewallRights,
+	"vcd_nsxt_firewall":               gatewayFirewallRights,
+	"vcd_nsxv_ip_set":                 gatewayFirewallRights,
+	"vcd_nsxt_ip_set":                 gatewayFirewallRights,
+	"vcd_nsxt_security_group":         gatewayFirewallRights,
+	"vcd_nsxt_dynamic_security_group": gatewayFirewallRights,
+	"vcd_nsxt_app_port_profile":       gatewayFirewallRights,
+
+	"vcd_edgegateway_vpn":               gatewayIpsecVpnRights,
+	"vcd_nsxt_ipsec_vpn_tunnel":         gatewayIpsecVpnRights,
+	"vcd_nsxt_ipsec_vpn_tunnel_status":  {view: gatewayIpsecVpnRights.view},
+	"vcd_nsxt_edgegateway_static_route": gatewayServiceRights("Static Routing"),
+	"vcd_nsxt_edgegateway_dns":          gatewayServiceRights("DNS"),
+
+	"vcd_nsxv_dhcp_relay":                  gatewayDhcpRights,
+	"vcd_nsxt_edgegateway_dhcp_forwarding": gatewayDhcpRights,
+	"vcd_nsxt_edgegateway_dhcpv6":          gatewayDhcpRights,
+	"vcd_nsxt_network_dhcp":                gatewayDhcpRights,
+	"vcd_nsxt_network_dhcp_binding":        gatewayDhcpRights,
+
+	"vcd_lb_app_profile":                            gatewayLoadBalancerRights,
+	"vcd_lb_app_rule":                               gatewayLoadBalancerRights,
+	"vcd_lb_server_pool":                            gatewayLoadBalancerRights,
+	"vcd_lb_service_monitor":                        gatewayLoadBalancerRights,
+	"vcd_lb_virtual_server":                         gatewayLoadBalancerRights,
+	"vcd_nsxt_alb_settings":                         {view: gatewayLoadBalancerRights.view},
+	"vcd_nsxt_alb_edgegateway_service_engine_group": {view: gatewayLoadBalancerRights.view},
+	"vcd_nsxt_alb_pool":                             gatewayLoadBalancerRights,
+	"vcd_nsxt_alb_virtual_service":                  gatewayLoadBalancerRights,
+	"vcd_nsxt_alb_virtual_service_http_req_rules":   gatewayLoadBalancerRights,
+	"vcd_nsxt_alb_virtual_service_http_resp_rules":  gatewayLoadBalancerRights,
+	"vcd_nsxt_alb_virtual_service_http_sec_rules":   gatewayLoadBalancerRights,
+
+	"vcd_nsxv_distributed_firewall":                  distributedFirewallRights,
+	"vcd_nsxt_distributed_firewall":                  distributedFirewallRights,
+	"vcd_nsxt_distributed_firewall_rule":             distributedFirewallRights,
+	"vcd_nsxt_distributed_firewall_effective_policy": {view: distributedFirewallRights.view},
+	"vcd_vdc_group": {
+		view:   []string{"vDC Group: View"},
+		manage: []string{"vDC Group: Configure"},
+	},
+	"vcd_ip_space": {
+		view:   []string{"IP Spaces: View", "Private IP Spaces: View"},
+		manage: []string{"Private IP Spaces: Manage"},
+	},
+	"vcd_ip_space_ip_allocation": {
+		view:   []string{"IP Spaces: View"},
+		manage: []string{"IP Spaces: Allocate"},
+	},
+}
+
+// getLeastPrivilegeRights returns the rights needed to use the given resource types, sorted by name. Resource types
+// starting with 'data.' are data sources, and only need view rights. It also returns the resource types for which
+// the needed rights are not known, or that can't be managed by tenant users
+func getLeastPrivilegeRights(resourceTypes []string) ([]string, []string) {
+	rights := make(map[string]bool)
+	var unknown []string
+	for _, resourceType := range resourceTypes {
+		name := strings.TrimPrefix(resourceType, "data.")
+		isDataSource := name != resourceType
+		typeRights, found := rightsByResourceType[name]
+		if !found || (!isDataSource && typeRights.manage == nil) {
+			unknown = append(unknown, resourceType)
+			continue
+		}
+		for _, right := range typeRights.view {
+			rights[right] = true
+		}
+		if isDataSource {
+			continue
+		}
+		for _, right := range typeRights.manage {
+			rights[right] = true
+		}
+	}
+	sort.Strings(unknown)
+	return sortedKeys(rights), unknown
+}
+
+// resolveRights checks the given rights against the ones available in VCD and, when requested, adds the rights they
+// imply, recursively. It returns the available rights sorted by name, and the ones that are not available
+func resolveRights(rights []string, availableRights map[string]*types.Right, includeImplied bool) ([]string, []string) {
+	result := make(map[string]bool)
+	unavailable := make(map[string]bool)
+	pending := append([]string{}, rights...)
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if result[name] || unavailable[name] {
+			continue
+		}
+		right, found := availableRights[name]
+		if !found {
+			unavailable[name] = true
+			continue
+		}
+		result[name] = true
+		if !includeImplied {
+			continue
+		}
+		for _, impliedRight := range right.ImpliedRights {
+			pending = append(pending, impliedRight.Name)
+		}
+	}
+	return sortedKeys(result), sortedKeys(unavailable)
+}
+
+func sortedKeys(values map[string]bool) []string {
+	result := make([]string, 0, len(values))
+	for value := range values {
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return result
+}
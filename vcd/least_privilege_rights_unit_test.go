@@ -0,0 +1,109 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Test_rightsByResourceType checks that all the resource types with known rights are defined in the provider
+func Test_rightsByResourceType(t *testing.T) {
+	for resourceType, rights := range rightsByResourceType {
+		_, isResource := globalResourceMap[resourceType]
+		_, isDataSource := globalDataSourceMap[resourceType]
+		if !isResource && !isDataSource {
+			t.Errorf("%s is neither a resource nor a data source", resourceType)
+		}
+		if len(rights.view) == 0 {
+			t.Errorf("%s has no view rights", resourceType)
+		}
+		// Without manage rights, only the data source is covered
+		if !isDataSource && rights.manage == nil {
+			t.Errorf("%s has no data source and no manage rights", resourceType)
+		}
+	}
+}
+
+func Test_getLeastPrivilegeRights(t *testing.T) {
+	tests := []struct {
+		name          string
+		resourceTypes []string
+		wantRights    []string
+		wantUnknown   []string
+	}{
+		{
+			name:          "DataSource",
+			resourceTypes: []string{"data.vcd_catalog"},
+			wantRights:    []string{rightCatalogView},
+			wantUnknown:   []string{},
+		},
+		{
+			name:          "Resource",
+			resourceTypes: []string{"vcd_nsxt_nat_rule", "data.vcd_nsxt_edgegateway"},
+			wantRights: []string{
+				"Organization vDC Gateway: Configure NAT",
+				rightGatewayView,
+				"Organization vDC Gateway: View NAT",
+			},
+			wantUnknown: []string{},
+		},
+		{
+			name:          "UnknownAndProviderOnly",
+			resourceTypes: []string{"vcd_nsxt_edgegateway", "vcd_not_a_resource", "data.vcd_org_vdc"},
+			wantRights:    []string{rightVdcView},
+			wantUnknown:   []string{"vcd_not_a_resource", "vcd_nsxt_edgegateway"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rights, unknown := getLeastPrivilegeRights(tt.resourceTypes)
+			if !reflect.DeepEqual(rights, tt.wantRights) {
+				t.Errorf("expected rights %v, got %v", tt.wantRights, rights)
+			}
+			if unknown == nil {
+				unknown = []string{}
+			}
+			if !reflect.DeepEqual(unknown, tt.wantUnknown) {
+				t.Errorf("expected unknown resource types %v, got %v", tt.wantUnknown, unknown)
+			}
+		})
+	}
+}
+
+func Test_resolveRights(t *testing.T) {
+	availableRights := map[string]*types.Right{
+		"A": {Name: "A", ImpliedRights: []types.OpenApiReference{{Name: "B"}}},
+		"B": {Name: "B", ImpliedRights: []types.OpenApiReference{{Name: "C"}, {Name: "A"}}},
+		"C": {Name: "C"},
+		"D": {Name: "D"},
+	}
+
+	rights, unavailable := resolveRights([]string{"A", "X"}, availableRights, true)
+	if !reflect.DeepEqual(rights, []string{"A", "B", "C"}) || !reflect.DeepEqual(unavailable, []string{"X"}) {
+		t.Errorf("unexpected rights with implied ones: %v - unavailable: %v", rights, unavailable)
+	}
+
+	rights, unavailable = resolveRights([]string{"A", "D", "A"}, availableRights, false)
+	if !reflect.DeepEqual(rights, []string{"A", "D"}) || len(unavailable) != 0 {
+		t.Errorf("unexpected rights without implied ones: %v - unavailable: %v", rights, unavailable)
+	}
+}
+
+func Test_compareRights(t *testing.T) {
+	onlyInFirst, onlyInSecond, intersection, union := compareRights([]string{"b", "a", "c"}, []string{"d", "c", "b"})
+	if !reflect.DeepEqual(onlyInFirst, []string{"a"}) {
+		t.Errorf("unexpected rights only in first set: %v", onlyInFirst)
+	}
+	if !reflect.DeepEqual(onlyInSecond, []string{"d"}) {
+		t.Errorf("unexpected rights only in second set: %v", onlyInSecond)
+	}
+	if !reflect.DeepEqual(intersection, []string{"b", "c"}) {
+		t.Errorf("unexpected intersection: %v", intersection)
+	}
+	if !reflect.DeepEqual(union, []string{"a", "b", "c", "d"}) {
+		t.Errorf("unexpected union: %v", union)
+	}
+}
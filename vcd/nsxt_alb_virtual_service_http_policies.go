@@ -0,0 +1,789 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NSX-T ALB Virtual Service HTTP policies (request rules, response rules and security rules) are available
+// in VCD 10.5+. Each policy is a list of rules that is always updated as a whole with a PUT request to the
+// endpoint of the Virtual Service
+
+const (
+	albVsHttpPolicyApiVersion = "38.0"
+
+	albVsHttpRequestRulesEndpoint  = "httpRequestRules"
+	albVsHttpResponseRulesEndpoint = "httpResponseRules"
+	albVsHttpSecurityRulesEndpoint = "httpSecurityRules"
+)
+
+var (
+	albVsHttpIsInCriteria   = []string{"IS_IN", "IS_NOT_IN"}
+	albVsHttpStringCriteria = []string{"BEGINS_WITH", "DOES_NOT_BEGIN_WITH", "CONTAINS", "DOES_NOT_CONTAIN",
+		"ENDS_WITH", "DOES_NOT_END_WITH", "EQUALS", "DOES_NOT_EQUAL", "REGEX_MATCH", "REGEX_DOES_NOT_MATCH"}
+	albVsHttpHeaderCriteria = append([]string{"EXISTS", "DOES_NOT_EXIST"}, albVsHttpStringCriteria...)
+	albVsHttpMethods        = []string{"GET", "PUT", "POST", "DELETE", "HEAD", "OPTIONS", "TRACE", "CONNECT", "PATCH",
+		"PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK"}
+)
+
+type albVsHttpRequestRules struct {
+	Values []albVsHttpRequestRule `json:"values"`
+}
+
+type albVsHttpRequestRule struct {
+	Name             string                     `json:"name"`
+	Active           bool                       `json:"active"`
+	Logging          bool                       `json:"logging"`
+	MatchCriteria    albVsHttpMatchCriteria     `json:"matchCriteria"`
+	RedirectAction   *albVsHttpRedirectAction   `json:"redirectAction,omitempty"`
+	HeaderActions    []albVsHttpHeaderAction    `json:"headerActions,omitempty"`
+	RewriteURLAction *albVsHttpRewriteURLAction `json:"rewriteUrlAction,omitempty"`
+}
+
+type albVsHttpResponseRules struct {
+	Values []albVsHttpResponseRule `json:"values"`
+}
+
+type albVsHttpResponseRule struct {
+	Name                        string                          `json:"name"`
+	Active                      bool                            `json:"active"`
+	Logging                     bool                            `json:"logging"`
+	MatchCriteria               albVsHttpMatchCriteria          `json:"matchCriteria"`
+	HeaderActions               []albVsHttpHeaderAction         `json:"headerActions,omitempty"`
+	RewriteLocationHeaderAction *albVsHttpRewriteLocationAction `json:"rewriteLocationHeaderAction,omitempty"`
+}
+
+type albVsHttpSecurityRules struct {
+	Values []albVsHttpSecurityRule `json:"values"`
+}
+
+type albVsHttpSecurityRule struct {
+	Name                         string                    `json:"name"`
+	Active                       bool                      `json:"active"`
+	Logging                      bool                      `json:"logging"`
+	MatchCriteria                albVsHttpMatchCriteria    `json:"matchCriteria"`
+	AllowOrCloseConnectionAction string                    `json:"allowOrCloseConnectionAction,omitempty"`
+	RedirectToHTTPSAction        *albVsHttpRedirectToHttps `json:"redirectToHTTPSAction,omitempty"`
+	SendResponseAction           *albVsHttpLocalResponse   `json:"sendResponseAction,omitempty"`
+	RateLimitAction              *albVsHttpRateLimitAction `json:"rateLimitAction,omitempty"`
+}
+
+// albVsHttpMatchCriteria contains the criteria of all rule types. Request and security rules use 'HeaderMatch',
+// while response rules use 'RequestHeaderMatch', 'ResponseHeaderMatch', 'LocationHeaderMatch' and 'StatusCodeMatch'
+type albVsHttpMatchCriteria struct {
+	ClientIPMatch       *albVsHttpClientIpMatch    `json:"clientIpMatch,omitempty"`
+	ServicePortMatch    *albVsHttpServicePortMatch `json:"servicePortMatch,omitempty"`
+	MethodMatch         *albVsHttpMethodMatch      `json:"methodMatch,omitempty"`
+	Protocol            string                     `json:"protocol,omitempty"`
+	PathMatch           *albVsHttpStringMatch      `json:"pathMatch,omitempty"`
+	CookieMatch         *albVsHttpCookieMatch      `json:"cookieMatch,omitempty"`
+	QueryMatch          []string                   `json:"queryMatch,omitempty"`
+	HeaderMatch         []albVsHttpHeaderMatch     `json:"headerMatch,omitempty"`
+	RequestHeaderMatch  []albVsHttpHeaderMatch     `json:"requestHeaderMatch,omitempty"`
+	ResponseHeaderMatch []albVsHttpHeaderMatch     `json:"responseHeaderMatch,omitempty"`
+	LocationHeaderMatch *albVsHttpLocationMatch    `json:"locationHeaderMatch,omitempty"`
+	StatusCodeMatch     *albVsHttpStatusCodeMatch  `json:"statusCodeMatch,omitempty"`
+}
+
+type albVsHttpClientIpMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Addresses     []string `json:"addresses"`
+}
+
+type albVsHttpServicePortMatch struct {
+	MatchCriteria string `json:"matchCriteria"`
+	Ports         []int  `json:"ports"`
+}
+
+type albVsHttpMethodMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Methods       []string `json:"methods"`
+}
+
+type albVsHttpStringMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	MatchStrings  []string `json:"matchStrings"`
+}
+
+type albVsHttpCookieMatch struct {
+	MatchCriteria string `json:"matchCriteria"`
+	Key           string `json:"key"`
+	Value         string `json:"value,omitempty"`
+}
+
+type albVsHttpHeaderMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Key           string   `json:"key"`
+	Value         []string `json:"value,omitempty"`
+}
+
+type albVsHttpLocationMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Value         []string `json:"value"`
+}
+
+type albVsHttpStatusCodeMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	StatusCodes   []string `json:"statusCodes"`
+}
+
+type albVsHttpRedirectAction struct {
+	Protocol   string `json:"protocol"`
+	Host       string `json:"host,omitempty"`
+	Port       *int   `json:"port,omitempty"`
+	Path       string `json:"path,omitempty"`
+	KeepQuery  bool   `json:"keepQuery"`
+	StatusCode int    `json:"statusCode"`
+}
+
+type albVsHttpHeaderAction struct {
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	Value  string `json:"value,omitempty"`
+}
+
+type albVsHttpRewriteURLAction struct {
+	Host         string `json:"hostHeader,omitempty"`
+	ExistingPath string `json:"existingPath,omitempty"`
+	KeepQuery    bool   `json:"keepQuery"`
+	Query        string `json:"query,omitempty"`
+}
+
+type albVsHttpRewriteLocationAction struct {
+	Protocol  string `json:"protocol"`
+	Host      string `json:"host,omitempty"`
+	Port      *int   `json:"port,omitempty"`
+	Path      string `json:"path,omitempty"`
+	KeepQuery bool   `json:"keepQuery"`
+}
+
+type albVsHttpRedirectToHttps struct {
+	Port int `json:"port"`
+}
+
+type albVsHttpLocalResponse struct {
+	StatusCode  string `json:"statusCode"`
+	Content     string `json:"content,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+type albVsHttpRateLimitAction struct {
+	Count                 int                      `json:"count"`
+	Period                int                      `json:"period"`
+	CloseConnectionAction string                   `json:"closeConnectionAction,omitempty"`
+	RedirectAction        *albVsHttpRedirectAction `json:"redirectAction,omitempty"`
+	LocalResponseAction   *albVsHttpLocalResponse  `json:"localResponseAction,omitempty"`
+}
+
+// getAlbVsHttpPolicy retrieves one of the HTTP policies of an NSX-T ALB Virtual Service into policy
+func getAlbVsHttpPolicy(vcdClient *VCDClient, virtualServiceId, endpoint string, policy interface{}) error {
+	urlRef, err := albVsHttpPolicyUrl(vcdClient, virtualServiceId, endpoint)
+	if err != nil {
+		return err
+	}
+	return vcdClient.Client.OpenApiGetItem(albVsHttpPolicyApiVersion, urlRef, nil, policy, nil)
+}
+
+// updateAlbVsHttpPolicy replaces all the rules of one of the HTTP policies of an NSX-T ALB Virtual Service
+func updateAlbVsHttpPolicy(vcdClient *VCDClient, virtualServiceId, endpoint string, policy interface{}) error {
+	urlRef, err := albVsHttpPolicyUrl(vcdClient, virtualServiceId, endpoint)
+	if err != nil {
+		return err
+	}
+	return vcdClient.Client.OpenApiPutItem(albVsHttpPolicyApiVersion, urlRef, nil, policy, nil, nil)
+}
+
+func albVsHttpPolicyUrl(vcdClient *VCDClient, virtualServiceId, endpoint string) (*url.URL, error) {
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + albVsHttpPolicyApiVersion) {
+		return nil, fmt.Errorf("NSX-T ALB Virtual Service HTTP policies require VCD 10.5+ (API %s+)", albVsHttpPolicyApiVersion)
+	}
+	if virtualServiceId == "" {
+		return nil, fmt.Errorf("empty NSX-T ALB Virtual Service ID")
+	}
+	return vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointAlbVirtualServices,
+		virtualServiceId, "/", endpoint)
+}
+
+// resourceVcdAlbVsHttpPolicyImport imports any of the HTTP policies, using the path of its Virtual Service
+func resourceVcdAlbVsHttpPolicyImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[TRACE] NSX-T ALB Virtual Service HTTP policy import initiated")
+
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	if len(resourceURI) != 4 {
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.virtual_service_name")
+	}
+	orgName, vdcOrVdcGroupName, edgeName, virtualServiceName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+
+	vcdClient := meta.(*VCDClient)
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	edge, err := vdcOrVdcGroup.GetNsxtEdgeGatewayByName(edgeName)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve NSX-T edge gateway '%s': %s", edgeName, err)
+	}
+
+	albVirtualService, err := vcdClient.GetAlbVirtualServiceByName(edge.EdgeGateway.ID, virtualServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve NSX-T ALB Virtual Service '%s': %s", virtualServiceName, err)
+	}
+
+	dSet(d, "virtual_service_id", albVirtualService.NsxtAlbVirtualService.ID)
+	d.SetId(albVirtualService.NsxtAlbVirtualService.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// albVsHttpField adapts a field definition to a resource (required or optional) or to a data source (computed)
+func albVsHttpField(field *schema.Schema, required, computed bool) *schema.Schema {
+	if computed {
+		field.Computed = true
+		field.ValidateFunc = nil
+		field.Default = nil
+		field.MaxItems = 0
+		return field
+	}
+	field.Required = required
+	field.Optional = !required
+	return field
+}
+
+// albVsHttpRuleSchema returns the schema of the fields shared by all rule types, plus the given action fields
+func albVsHttpRuleSchema(matchCriteria *schema.Resource, actions map[string]*schema.Schema, computed bool) *schema.Resource {
+	ruleSchema := map[string]*schema.Schema{
+		"name": albVsHttpField(&schema.Schema{
+			Type:        schema.TypeString,
+			Description: "Name of the rule",
+		}, true, computed),
+		"active": albVsHttpField(&schema.Schema{
+			Type:        schema.TypeBool,
+			Default:     true,
+			Description: "Defines if the rule is active or not",
+		}, false, computed),
+		"logging": albVsHttpField(&schema.Schema{
+			Type:        schema.TypeBool,
+			Default:     false,
+			Description: "Defines whether to enable logging with headers on rule match or not",
+		}, false, computed),
+		"match_criteria": albVsHttpField(&schema.Schema{
+			Type:        schema.TypeList,
+			MaxItems:    1,
+			Elem:        matchCriteria,
+			Description: "Rule matching criteria",
+		}, false, computed),
+	}
+	for name, action := range actions {
+		ruleSchema[name] = action
+	}
+	return &schema.Resource{Schema: ruleSchema}
+}
+
+// albVsHttpMatchCriteriaSchema returns the schema of the match criteria. Response rules can also match
+// response headers, location header and status code
+func albVsHttpMatchCriteriaSchema(isResponse, computed bool) *schema.Resource {
+	headerSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"criteria": albVsHttpField(&schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(albVsHttpHeaderCriteria, false),
+				Description:  "Criteria to use for matching headers. One of " + strings.Join(albVsHttpHeaderCriteria, ", "),
+			}, true, computed),
+			"name": albVsHttpField(&schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Name of the HTTP header whose value is to be matched",
+			}, true, computed),
+			"values": albVsHttpField(&schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "String values to match for an HTTP header",
+			}, false, computed),
+		},
+	}
+
+	criteriaSchema := map[string]*schema.Schema{
+		"client_ip_address": albVsHttpField(&schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"criteria": albVsHttpField(&schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(albVsHttpIsInCriteria, false),
+						Description:  "Criteria to use for IP address matching the HTTP request. Options - IS_IN, IS_NOT_IN",
+					}, true, computed),
+					"ip_addresses": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeSet,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "A set of IP addresses, CIDRs or IP ranges",
+					}, true, computed),
+				},
+			},
+			Description: "Client IP addresses",
+		}, false, computed),
+		"service_ports": albVsHttpField(&schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"criteria": albVsHttpField(&schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(albVsHttpIsInCriteria, false),
+						Description:  "Criteria to use for port matching the HTTP request. Options - IS_IN, IS_NOT_IN",
+					}, true, computed),
+					"ports": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeSet,
+						Elem:        &schema.Schema{Type: schema.TypeInt},
+						Description: "A set of TCP ports",
+					}, true, computed),
+				},
+			},
+			Description: "Virtual Service ports",
+		}, false, computed),
+		"protocol_type": albVsHttpField(&schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice([]string{"HTTP", "HTTPS"}, false),
+			Description:  "Protocol to match. One of 'HTTP' or 'HTTPS'",
+		}, false, computed),
+		"http_methods": albVsHttpField(&schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"criteria": albVsHttpField(&schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(albVsHttpIsInCriteria, false),
+						Description:  "Criteria to use for HTTP method matching. Options - IS_IN, IS_NOT_IN",
+					}, true, computed),
+					"methods": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeSet,
+						Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(albVsHttpMethods, false)},
+						Description: "HTTP methods to match. One of " + strings.Join(albVsHttpMethods, ", "),
+					}, true, computed),
+				},
+			},
+			Description: "HTTP methods",
+		}, false, computed),
+		"path": albVsHttpField(&schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"criteria": albVsHttpField(&schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(albVsHttpStringCriteria, false),
+						Description:  "Criteria to use for matching the path. One of " + strings.Join(albVsHttpStringCriteria, ", "),
+					}, true, computed),
+					"paths": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeSet,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "String values to match the path",
+					}, true, computed),
+				},
+			},
+			Description: "Request path",
+		}, false, computed),
+		"cookie": albVsHttpField(&schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"criteria": albVsHttpField(&schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(albVsHttpHeaderCriteria, false),
+						Description:  "Criteria to use for matching cookies. One of " + strings.Join(albVsHttpHeaderCriteria, ", "),
+					}, true, computed),
+					"key": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeString,
+						Description: "Name of the cookie",
+					}, true, computed),
+					"value": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeString,
+						Description: "String value to match the cookie",
+					}, false, computed),
+				},
+			},
+			Description: "Cookie",
+		}, false, computed),
+		"query": albVsHttpField(&schema.Schema{
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "HTTP request query strings to match",
+		}, false, computed),
+		"request_headers": albVsHttpField(&schema.Schema{
+			Type:        schema.TypeSet,
+			Elem:        headerSchema,
+			Description: "HTTP request headers",
+		}, false, computed),
+	}
+
+	if isResponse {
+		criteriaSchema["response_headers"] = albVsHttpField(&schema.Schema{
+			Type:        schema.TypeSet,
+			Elem:        headerSchema,
+			Description: "HTTP response headers",
+		}, false, computed)
+		criteriaSchema["location_header"] = albVsHttpField(&schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"criteria": albVsHttpField(&schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(albVsHttpStringCriteria, false),
+						Description:  "Criteria to use for matching the location header. One of " + strings.Join(albVsHttpStringCriteria, ", "),
+					}, true, computed),
+					"values": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeSet,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "String values to match the location header",
+					}, true, computed),
+				},
+			},
+			Description: "Location header",
+		}, false, computed)
+		criteriaSchema["status_code"] = albVsHttpField(&schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"criteria": albVsHttpField(&schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(albVsHttpIsInCriteria, false),
+						Description:  "Criteria to use for matching the status code. Options - IS_IN, IS_NOT_IN",
+					}, true, computed),
+					"http_status_code": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeString,
+						Description: "Status codes or ranges of status codes, separated by commas (e.g. '200,400-499')",
+					}, true, computed),
+				},
+			},
+			Description: "HTTP status code",
+		}, false, computed)
+	}
+
+	return &schema.Resource{Schema: criteriaSchema}
+}
+
+// albVsHttpRedirectSchema returns the schema of a redirect action
+func albVsHttpRedirectSchema(computed bool) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"protocol": albVsHttpField(&schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"HTTP", "HTTPS"}, false),
+				Description:  "HTTP or HTTPS protocol",
+			}, true, computed),
+			"port": albVsHttpField(&schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "Port to which redirect the request",
+			}, false, computed),
+			"status_code": albVsHttpField(&schema.Schema{
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntInSlice([]int{301, 302, 307}),
+				Description:  "One of the redirect status codes - 301, 302, 307",
+			}, true, computed),
+			"host": albVsHttpField(&schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Host to which redirect the request. Default is the original host",
+			}, false, computed),
+			"path": albVsHttpField(&schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Path to which redirect the request. Default is the original path",
+			}, false, computed),
+			"keep_query_string": albVsHttpField(&schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Defines whether to keep the query string of the original request",
+			}, false, computed),
+		},
+	}
+}
+
+// albVsHttpLocalResponseSchema returns the schema of a local response action
+func albVsHttpLocalResponseSchema(computed bool) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"status_code": albVsHttpField(&schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"200", "204", "403", "404", "429", "501"}, false),
+				Description:  "HTTP status code of the response. One of 200, 204, 403, 404, 429, 501",
+			}, true, computed),
+			"content": albVsHttpField(&schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Base64 encoded content of the response",
+			}, false, computed),
+			"content_type": albVsHttpField(&schema.Schema{
+				Type:        schema.TypeString,
+				Description: "MIME type of the content",
+			}, false, computed),
+		},
+	}
+}
+
+// albVsHttpModifyHeaderSchema returns the schema of the header modification actions
+func albVsHttpModifyHeaderSchema(computed bool) *schema.Schema {
+	return albVsHttpField(&schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"action": albVsHttpField(&schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"ADD", "REMOVE", "REPLACE"}, false),
+					Description:  "One of the following HTTP header actions. Options - ADD, REMOVE, REPLACE",
+				}, true, computed),
+				"name": albVsHttpField(&schema.Schema{
+					Type:        schema.TypeString,
+					Description: "HTTP header name",
+				}, true, computed),
+				"value": albVsHttpField(&schema.Schema{
+					Type:        schema.TypeString,
+					Description: "HTTP header value",
+				}, false, computed),
+			},
+		},
+		Description: "Modify HTTP headers",
+	}, false, computed)
+}
+
+// getAlbVsHttpMatchCriteriaType converts the 'match_criteria' block of a rule into its API structure
+func getAlbVsHttpMatchCriteriaType(rule map[string]interface{}, isResponse bool) albVsHttpMatchCriteria {
+	var criteria albVsHttpMatchCriteria
+	criteriaList := rule["match_criteria"].([]interface{})
+	if len(criteriaList) == 0 || criteriaList[0] == nil {
+		return criteria
+	}
+	criteriaMap := criteriaList[0].(map[string]interface{})
+
+	if block := firstBlock(criteriaMap["client_ip_address"]); block != nil {
+		criteria.ClientIPMatch = &albVsHttpClientIpMatch{
+			MatchCriteria: block["criteria"].(string),
+			Addresses:     convertSchemaSetToSliceOfStrings(block["ip_addresses"].(*schema.Set)),
+		}
+	}
+	if block := firstBlock(criteriaMap["service_ports"]); block != nil {
+		criteria.ServicePortMatch = &albVsHttpServicePortMatch{
+			MatchCriteria: block["criteria"].(string),
+			Ports:         convertSchemaSetToSliceOfInts(block["ports"].(*schema.Set)),
+		}
+	}
+	criteria.Protocol = criteriaMap["protocol_type"].(string)
+	if block := firstBlock(criteriaMap["http_methods"]); block != nil {
+		criteria.MethodMatch = &albVsHttpMethodMatch{
+			MatchCriteria: block["criteria"].(string),
+			Methods:       convertSchemaSetToSliceOfStrings(block["methods"].(*schema.Set)),
+		}
+	}
+	if block := firstBlock(criteriaMap["path"]); block != nil {
+		criteria.PathMatch = &albVsHttpStringMatch{
+			MatchCriteria: block["criteria"].(string),
+			MatchStrings:  convertSchemaSetToSliceOfStrings(block["paths"].(*schema.Set)),
+		}
+	}
+	if block := firstBlock(criteriaMap["cookie"]); block != nil {
+		criteria.CookieMatch = &albVsHttpCookieMatch{
+			MatchCriteria: block["criteria"].(string),
+			Key:           block["key"].(string),
+			Value:         block["value"].(string),
+		}
+	}
+	criteria.QueryMatch = convertSchemaSetToSliceOfStrings(criteriaMap["query"].(*schema.Set))
+
+	requestHeaders := getAlbVsHttpHeaderMatchType(criteriaMap["request_headers"].(*schema.Set))
+	if !isResponse {
+		criteria.HeaderMatch = requestHeaders
+		return criteria
+	}
+
+	criteria.RequestHeaderMatch = requestHeaders
+	criteria.ResponseHeaderMatch = getAlbVsHttpHeaderMatchType(criteriaMap["response_headers"].(*schema.Set))
+	if block := firstBlock(criteriaMap["location_header"]); block != nil {
+		criteria.LocationHeaderMatch = &albVsHttpLocationMatch{
+			MatchCriteria: block["criteria"].(string),
+			Value:         convertSchemaSetToSliceOfStrings(block["values"].(*schema.Set)),
+		}
+	}
+	if block := firstBlock(criteriaMap["status_code"]); block != nil {
+		criteria.StatusCodeMatch = &albVsHttpStatusCodeMatch{
+			MatchCriteria: block["criteria"].(string),
+			StatusCodes:   strings.Split(strings.ReplaceAll(block["http_status_code"].(string), " ", ""), ","),
+		}
+	}
+	return criteria
+}
+
+func getAlbVsHttpHeaderMatchType(headerSet *schema.Set) []albVsHttpHeaderMatch {
+	var headers []albVsHttpHeaderMatch
+	for _, header := range headerSet.List() {
+		headerMap := header.(map[string]interface{})
+		headers = append(headers, albVsHttpHeaderMatch{
+			MatchCriteria: headerMap["criteria"].(string),
+			Key:           headerMap["name"].(string),
+			Value:         convertSchemaSetToSliceOfStrings(headerMap["values"].(*schema.Set)),
+		})
+	}
+	return headers
+}
+
+// setAlbVsHttpMatchCriteriaData converts the API match criteria into a 'match_criteria' block
+func setAlbVsHttpMatchCriteriaData(criteria albVsHttpMatchCriteria, isResponse bool) []interface{} {
+	criteriaMap := map[string]interface{}{
+		"protocol_type": criteria.Protocol,
+		"query":         convertStringsToTypeSet(criteria.QueryMatch),
+	}
+	if criteria.ClientIPMatch != nil {
+		criteriaMap["client_ip_address"] = []interface{}{map[string]interface{}{
+			"criteria":     criteria.ClientIPMatch.MatchCriteria,
+			"ip_addresses": convertStringsToTypeSet(criteria.ClientIPMatch.Addresses),
+		}}
+	}
+	if criteria.ServicePortMatch != nil {
+		criteriaMap["service_ports"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.ServicePortMatch.MatchCriteria,
+			"ports":    convertIntsToTypeSet(criteria.ServicePortMatch.Ports),
+		}}
+	}
+	if criteria.MethodMatch != nil {
+		criteriaMap["http_methods"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.MethodMatch.MatchCriteria,
+			"methods":  convertStringsToTypeSet(criteria.MethodMatch.Methods),
+		}}
+	}
+	if criteria.PathMatch != nil {
+		criteriaMap["path"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.PathMatch.MatchCriteria,
+			"paths":    convertStringsToTypeSet(criteria.PathMatch.MatchStrings),
+		}}
+	}
+	if criteria.CookieMatch != nil {
+		criteriaMap["cookie"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.CookieMatch.MatchCriteria,
+			"key":      criteria.CookieMatch.Key,
+			"value":    criteria.CookieMatch.Value,
+		}}
+	}
+
+	if !isResponse {
+		criteriaMap["request_headers"] = setAlbVsHttpHeaderMatchData(criteria.HeaderMatch)
+		return []interface{}{criteriaMap}
+	}
+
+	criteriaMap["request_headers"] = setAlbVsHttpHeaderMatchData(criteria.RequestHeaderMatch)
+	criteriaMap["response_headers"] = setAlbVsHttpHeaderMatchData(criteria.ResponseHeaderMatch)
+	if criteria.LocationHeaderMatch != nil {
+		criteriaMap["location_header"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.LocationHeaderMatch.MatchCriteria,
+			"values":   convertStringsToTypeSet(criteria.LocationHeaderMatch.Value),
+		}}
+	}
+	if criteria.StatusCodeMatch != nil {
+		criteriaMap["status_code"] = []interface{}{map[string]interface{}{
+			"criteria":         criteria.StatusCodeMatch.MatchCriteria,
+			"http_status_code": strings.Join(criteria.StatusCodeMatch.StatusCodes, ","),
+		}}
+	}
+	return []interface{}{criteriaMap}
+}
+
+func setAlbVsHttpHeaderMatchData(headers []albVsHttpHeaderMatch) []interface{} {
+	headerSlice := make([]interface{}, len(headers))
+	for i, header := range headers {
+		headerSlice[i] = map[string]interface{}{
+			"criteria": header.MatchCriteria,
+			"name":     header.Key,
+			"values":   convertStringsToTypeSet(header.Value),
+		}
+	}
+	return headerSlice
+}
+
+func getAlbVsHttpRedirectType(block map[string]interface{}) *albVsHttpRedirectAction {
+	redirect := &albVsHttpRedirectAction{
+		Protocol:   block["protocol"].(string),
+		Host:       block["host"].(string),
+		Path:       block["path"].(string),
+		KeepQuery:  block["keep_query_string"].(bool),
+		StatusCode: block["status_code"].(int),
+	}
+	if port := block["port"].(int); port != 0 {
+		redirect.Port = addrOf(port)
+	}
+	return redirect
+}
+
+func setAlbVsHttpRedirectData(redirect *albVsHttpRedirectAction) []interface{} {
+	if redirect == nil {
+		return nil
+	}
+	redirectMap := map[string]interface{}{
+		"protocol":          redirect.Protocol,
+		"host":              redirect.Host,
+		"path":              redirect.Path,
+		"keep_query_string": redirect.KeepQuery,
+		"status_code":       redirect.StatusCode,
+	}
+	if redirect.Port != nil {
+		redirectMap["port"] = *redirect.Port
+	}
+	return []interface{}{redirectMap}
+}
+
+func getAlbVsHttpLocalResponseType(block map[string]interface{}) *albVsHttpLocalResponse {
+	return &albVsHttpLocalResponse{
+		StatusCode:  block["status_code"].(string),
+		Content:     block["content"].(string),
+		ContentType: block["content_type"].(string),
+	}
+}
+
+func setAlbVsHttpLocalResponseData(response *albVsHttpLocalResponse) []interface{} {
+	if response == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"status_code":  response.StatusCode,
+		"content":      response.Content,
+		"content_type": response.ContentType,
+	}}
+}
+
+func getAlbVsHttpHeaderActionsType(headerActions []interface{}) []albVsHttpHeaderAction {
+	var actions []albVsHttpHeaderAction
+	for _, headerAction := range headerActions {
+		actionMap := headerAction.(map[string]interface{})
+		actions = append(actions, albVsHttpHeaderAction{
+			Action: actionMap["action"].(string),
+			Name:   actionMap["name"].(string),
+			Value:  actionMap["value"].(string),
+		})
+	}
+	return actions
+}
+
+func setAlbVsHttpHeaderActionsData(actions []albVsHttpHeaderAction) []interface{} {
+	actionSlice := make([]interface{}, len(actions))
+	for i, action := range actions {
+		actionSlice[i] = map[string]interface{}{
+			"action": action.Action,
+			"name":   action.Name,
+			"value":  action.Value,
+		}
+	}
+	return actionSlice
+}
+
+// firstBlock returns the content of a single-item block (TypeList with MaxItems 1), or nil if it is not set
+func firstBlock(value interface{}) map[string]interface{} {
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	return list[0].(map[string]interface{})
+}
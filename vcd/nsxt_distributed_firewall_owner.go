@@ -0,0 +1,121 @@
+package vcd
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NSX-T Distributed Firewall rules have no metadata, so the owner of a rule is stored as a marker at the end of
+// its comment, such as "Allow web traffic [terraform-owner:team-a]". The marker is added when the rule is sent to
+// VCD and removed from the comment stored in the state, so that the 'comment' field keeps the value that was
+// configured.
+
+const (
+	dfwOwnerMarkerPrefix = "[terraform-owner:"
+	dfwOwnerMarkerSuffix = "]"
+
+	// Ownership classification of Distributed Firewall rules
+	dfwRuleOwned      = "owned"
+	dfwRuleOtherOwner = "other_owner"
+	dfwRuleUnmanaged  = "unmanaged"
+)
+
+var (
+	// dfwOwnerRegex defines the valid owner names, which must not break the comment marker
+	dfwOwnerRegex = regexp.MustCompile(`^[a-zA-Z0-9._:/@-]+$`)
+	// dfwOwnerMarkerRegex finds the owner marker at the end of a comment
+	dfwOwnerMarkerRegex = regexp.MustCompile(`\s*` + regexp.QuoteMeta(dfwOwnerMarkerPrefix) + `([a-zA-Z0-9._:/@-]+)` +
+		regexp.QuoteMeta(dfwOwnerMarkerSuffix) + `$`)
+)
+
+// dfwOwnerSchema returns the schema of the 'owner' field of Distributed Firewall resources
+func dfwOwnerSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ValidateFunc: validation.StringMatch(dfwOwnerRegex,
+			"can only contain letters, digits and the characters '.', '_', ':', '/', '@', '-'"),
+		Description: description,
+	}
+}
+
+// dfwCommentWithOwner adds the owner marker to a Distributed Firewall rule comment
+func dfwCommentWithOwner(comment, owner string) string {
+	if owner == "" {
+		return comment
+	}
+	marker := dfwOwnerMarkerPrefix + owner + dfwOwnerMarkerSuffix
+	if comment == "" {
+		return marker
+	}
+	return comment + " " + marker
+}
+
+// dfwCommentOwner splits a Distributed Firewall rule comment into the comment without the owner marker and the owner
+func dfwCommentOwner(comment string) (string, string) {
+	match := dfwOwnerMarkerRegex.FindStringSubmatchIndex(comment)
+	if match == nil {
+		return comment, ""
+	}
+	return comment[:match[0]], comment[match[2]:match[3]]
+}
+
+// dfwRuleOwnership classifies a Distributed Firewall rule owner from the point of view of the given owner
+func dfwRuleOwnership(ruleOwner, owner string) string {
+	switch {
+	case ruleOwner == "":
+		return dfwRuleUnmanaged
+	case ruleOwner == owner:
+		return dfwRuleOwned
+	default:
+		return dfwRuleOtherOwner
+	}
+}
+
+// setDistributedFirewallRuleComment sets the comment of a rule, with the owner marker, checking that the VCD
+// version supports comments
+func setDistributedFirewallRuleComment(vcdClient *VCDClient, rule *types.DistributedFirewallRule, comment, owner string) error {
+	if vcdClient.Client.APIVCDMaxVersionIs(">= 36.2") {
+		rule.Comments = dfwCommentWithOwner(comment, owner)
+		return nil
+	}
+	if comment != "" {
+		return fmt.Errorf("field 'comment' can only be set in VCD 10.3.2+")
+	}
+	if owner != "" {
+		return fmt.Errorf("field 'owner' can only be set in VCD 10.3.2+")
+	}
+	return nil
+}
+
+// dfwCommonOwner returns the owner of all the given rules, or an empty string when they don't have the same owner
+func dfwCommonOwner(rules []*types.DistributedFirewallRule) string {
+	commonOwner := ""
+	for index, rule := range rules {
+		_, owner := dfwCommentOwner(rule.Comments)
+		if index == 0 {
+			commonOwner = owner
+			continue
+		}
+		if owner != commonOwner {
+			return ""
+		}
+	}
+	return commonOwner
+}
+
+// dfwRuleIdsByOwnership lists the IDs of the rules with the given ownership, keeping the rule order
+func dfwRuleIdsByOwnership(rules []*types.DistributedFirewallRule, owner, ownership string) []string {
+	ids := make([]string, 0)
+	for _, rule := range rules {
+		_, ruleOwner := dfwCommentOwner(rule.Comments)
+		if dfwRuleOwnership(ruleOwner, owner) == ownership {
+			ids = append(ids, rule.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,72 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_dfwCommentOwner(t *testing.T) {
+	tests := []struct {
+		comment string
+		owner   string
+	}{
+		{comment: "", owner: ""},
+		{comment: "", owner: "team-a"},
+		{comment: "Allow web traffic", owner: ""},
+		{comment: "Allow web traffic", owner: "team-a"},
+		{comment: "Allow web traffic", owner: "workspace/prod@team_a.1:blue"},
+		{comment: "[terraform-owner:old] in the middle", owner: "team-b"},
+	}
+	for _, tt := range tests {
+		commentWithOwner := dfwCommentWithOwner(tt.comment, tt.owner)
+		comment, owner := dfwCommentOwner(commentWithOwner)
+		if comment != tt.comment || owner != tt.owner {
+			t.Errorf("dfwCommentOwner(%q) = (%q, %q), want (%q, %q)", commentWithOwner, comment, owner, tt.comment, tt.owner)
+		}
+	}
+
+	// A marker that is not at the end of the comment is part of the comment
+	comment, owner := dfwCommentOwner("[terraform-owner:team-a] Allow web traffic")
+	if comment != "[terraform-owner:team-a] Allow web traffic" || owner != "" {
+		t.Errorf("unexpected owner %q found in comment %q", owner, comment)
+	}
+}
+
+func Test_dfwRuleIdsByOwnership(t *testing.T) {
+	rules := []*types.DistributedFirewallRule{
+		{ID: "1", Comments: dfwCommentWithOwner("first", "team-a")},
+		{ID: "2", Comments: "manual rule"},
+		{ID: "3", Comments: dfwCommentWithOwner("", "team-b")},
+		{ID: "4", Comments: dfwCommentWithOwner("", "team-a")},
+		{ID: "5"},
+	}
+
+	tests := []struct {
+		owner     string
+		ownership string
+		want      []string
+	}{
+		{owner: "team-a", ownership: dfwRuleOwned, want: []string{"1", "4"}},
+		{owner: "team-a", ownership: dfwRuleOtherOwner, want: []string{"3"}},
+		{owner: "team-a", ownership: dfwRuleUnmanaged, want: []string{"2", "5"}},
+		{owner: "", ownership: dfwRuleOwned, want: []string{}},
+		{owner: "", ownership: dfwRuleOtherOwner, want: []string{"1", "3", "4"}},
+	}
+	for _, tt := range tests {
+		got := dfwRuleIdsByOwnership(rules, tt.owner, tt.ownership)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("dfwRuleIdsByOwnership(%q, %q) = %v, want %v", tt.owner, tt.ownership, got, tt.want)
+		}
+	}
+
+	if commonOwner := dfwCommonOwner(rules); commonOwner != "" {
+		t.Errorf("expected no common owner, got %q", commonOwner)
+	}
+	if commonOwner := dfwCommonOwner([]*types.DistributedFirewallRule{rules[0], rules[3]}); commonOwner != "team-a" {
+		t.Errorf("expected common owner 'team-a', got %q", commonOwner)
+	}
+}
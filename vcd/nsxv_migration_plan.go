@@ -0,0 +1,889 @@
+package vcd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// This file converts the configuration of an NSX-V edge gateway and its services into an equivalent NSX-T
+// configuration, rendered as HCL or as Terraform JSON. It is used by the 'vcd_nsxv_migration_plan' data source.
+
+// nsxvMigrationSource holds the NSX-V configuration to convert
+type nsxvMigrationSource struct {
+	EdgeGateway           *types.EdgeGateway
+	FirewallRules         []*types.EdgeFirewallRule
+	FirewallDefaultPolicy *types.FirewallDefaultPolicy
+	NatRules              []*types.EdgeNatRule
+	IpSets                []*types.EdgeIpSet
+	LoadBalancerEnabled   bool
+	LbVirtualServers      []*types.LbVirtualServer
+	LbPools               []*types.LbPool
+	LbAppProfiles         []*types.LbAppProfile
+	LbAppRules            []*types.LbAppRule
+	LbMonitors            []*types.LbMonitor
+	DhcpRelay             *types.EdgeDhcpRelay
+}
+
+// nsxvMigrationOptions holds the values of the NSX-T configuration which can't be derived from NSX-V. Empty values are
+// replaced by Terraform variables in the generated configuration
+type nsxvMigrationOptions struct {
+	Org                  string
+	EdgeGatewayName      string
+	OwnerId              string
+	ExternalNetworkId    string
+	ServiceEngineGroupId string
+}
+
+// nsxvMigrationUnsupported is an NSX-V construct which was not converted, or only partially converted
+type nsxvMigrationUnsupported struct {
+	SourceType string
+	SourceId   string
+	Name       string
+	Reason     string
+}
+
+// hclReference is an expression referring to another resource or a variable, such as 'vcd_nsxt_ip_set.web.id'
+type hclReference string
+
+// migrationAttribute is an argument of a generated resource. The value can be a string, an int, a bool, a []string,
+// an hclReference, a []hclReference, or a []migrationBlock for nested blocks
+type migrationAttribute struct {
+	Key   string
+	Value interface{}
+}
+
+type migrationBlock []migrationAttribute
+
+type migrationResource struct {
+	Type string
+	Name string
+	Body migrationBlock
+}
+
+func (r migrationResource) ref(attribute string) hclReference {
+	return hclReference(fmt.Sprintf("%s.%s.%s", r.Type, r.Name, attribute))
+}
+
+type migrationVariable struct {
+	Name        string
+	Description string
+}
+
+// nsxvMigrationPlan is the NSX-T configuration resulting from the conversion
+type nsxvMigrationPlan struct {
+	Variables   []migrationVariable
+	Resources   []migrationResource
+	Unsupported []nsxvMigrationUnsupported
+
+	options         nsxvMigrationOptions
+	resourceNames   map[string]bool
+	appPortProfiles map[string]hclReference
+	ipSets          map[string]hclReference
+	edgeGatewayName string
+	edgeGatewayId   hclReference
+}
+
+// buildNsxvMigrationPlan converts the NSX-V configuration into NSX-T resources
+func buildNsxvMigrationPlan(source nsxvMigrationSource, options nsxvMigrationOptions) *nsxvMigrationPlan {
+	plan := &nsxvMigrationPlan{
+		options:         options,
+		resourceNames:   make(map[string]bool),
+		appPortProfiles: make(map[string]hclReference),
+		ipSets:          make(map[string]hclReference),
+	}
+	plan.convertEdgeGateway(source)
+	plan.convertNatRules(source.NatRules)
+	plan.convertFirewallRules(source)
+	plan.convertLoadBalancer(source)
+	return plan
+}
+
+func (plan *nsxvMigrationPlan) addResource(resourceType, name string, body migrationBlock) migrationResource {
+	base := hclIdentifier(name)
+	resourceName := base
+	for i := 2; plan.resourceNames[resourceType+"."+resourceName]; i++ {
+		resourceName = fmt.Sprintf("%s_%d", base, i)
+	}
+	plan.resourceNames[resourceType+"."+resourceName] = true
+	// Empty strings are left out, as they are the default of all optional arguments
+	var filteredBody migrationBlock
+	for _, attribute := range body {
+		if value, ok := attribute.Value.(string); !ok || value != "" {
+			filteredBody = append(filteredBody, attribute)
+		}
+	}
+	resource := migrationResource{Type: resourceType, Name: resourceName, Body: filteredBody}
+	plan.Resources = append(plan.Resources, resource)
+	return resource
+}
+
+// valueOrVariable returns the given value, or a reference to a variable when the value is empty
+func (plan *nsxvMigrationPlan) valueOrVariable(value, variableName, description string) interface{} {
+	if value != "" {
+		return value
+	}
+	for _, variable := range plan.Variables {
+		if variable.Name == variableName {
+			return hclReference("var." + variableName)
+		}
+	}
+	plan.Variables = append(plan.Variables, migrationVariable{Name: variableName, Description: description})
+	return hclReference("var." + variableName)
+}
+
+func (plan *nsxvMigrationPlan) unsupported(sourceType, sourceId, name, reason string) {
+	plan.Unsupported = append(plan.Unsupported, nsxvMigrationUnsupported{
+		SourceType: sourceType,
+		SourceId:   sourceId,
+		Name:       name,
+		Reason:     reason,
+	})
+}
+
+func (plan *nsxvMigrationPlan) convertEdgeGateway(source nsxvMigrationSource) {
+	edge := source.EdgeGateway
+	name := plan.options.EdgeGatewayName
+	if name == "" {
+		name = edge.Name
+	}
+	body := migrationBlock{
+		{"org", plan.options.Org},
+		{"owner_id", plan.valueOrVariable(plan.options.OwnerId, "nsxt_owner_id", "ID of the NSX-T VDC or VDC Group owning the Edge Gateway")},
+		{"name", name},
+		{"description", edge.Description},
+		{"external_network_id", plan.valueOrVariable(plan.options.ExternalNetworkId, "nsxt_external_network_id", "ID of the NSX-T external network (Tier-0 gateway) of the Edge Gateway")},
+	}
+
+	var subnets []migrationBlock
+	if edge.Configuration != nil && edge.Configuration.GatewayInterfaces != nil {
+		var uplinks []*types.GatewayInterface
+		for _, gatewayInterface := range edge.Configuration.GatewayInterfaces.GatewayInterface {
+			interfaceName := gatewayInterface.DisplayName
+			if interfaceName == "" && gatewayInterface.Network != nil {
+				interfaceName = gatewayInterface.Network.Name
+			}
+			if strings.EqualFold(gatewayInterface.InterfaceType, "uplink") {
+				uplinks = append(uplinks, gatewayInterface)
+				continue
+			}
+			plan.unsupported("edge_gateway_interface", gatewayInterface.Name, interfaceName,
+				"internal interfaces are not part of an NSX-T Edge Gateway. Re-create the network as 'vcd_network_routed_v2'")
+		}
+		// NSX-T Edge Gateways have a single uplink. The one used for the default route is preferred
+		sort.SliceStable(uplinks, func(i, j int) bool {
+			return uplinks[i].UseForDefaultRoute && !uplinks[j].UseForDefaultRoute
+		})
+		for i, uplink := range uplinks {
+			if i > 0 {
+				plan.unsupported("edge_gateway_interface", uplink.Name, uplink.DisplayName,
+					"NSX-T Edge Gateways have a single uplink. Only the first uplink is converted")
+				continue
+			}
+			for _, subnet := range uplink.SubnetParticipation {
+				subnets = append(subnets, convertNsxvSubnetParticipation(subnet))
+			}
+		}
+	}
+	if len(subnets) > 0 {
+		body = append(body, migrationAttribute{"subnet", subnets})
+	}
+
+	features := edge.Configuration
+	if features != nil && features.EdgeGatewayServiceConfiguration != nil {
+		services := features.EdgeGatewayServiceConfiguration
+		if services.GatewayIpsecVpnService != nil && services.GatewayIpsecVpnService.IsEnabled {
+			plan.unsupported("ipsec_vpn", "", edge.Name, "IPsec VPN tunnels are not converted. Re-create them with 'vcd_nsxt_ipsec_vpn_tunnel'")
+		}
+		if services.StaticRoutingService != nil && len(services.StaticRoutingService.StaticRoute) > 0 {
+			plan.unsupported("static_routing", "", edge.Name, "static routes are not converted. Re-create them with 'vcd_nsxt_edgegateway_static_route'")
+		}
+		if services.GatewayDhcpService != nil && services.GatewayDhcpService.IsEnabled {
+			plan.unsupported("dhcp", "", edge.Name, "Edge Gateway DHCP pools are not converted. Use DHCP of the routed networks with 'vcd_nsxt_network_dhcp'")
+		}
+	}
+	if source.DhcpRelay != nil && source.DhcpRelay.RelayServer != nil {
+		plan.unsupported("dhcp_relay", "", edge.Name, "DHCP relay is not converted. Use 'vcd_nsxt_edgegateway_dhcp_forwarding'")
+	}
+
+	resource := plan.addResource("vcd_nsxt_edgegateway", name, body)
+	plan.edgeGatewayName = name
+	plan.edgeGatewayId = resource.ref("id")
+}
+
+func convertNsxvSubnetParticipation(subnet *types.SubnetParticipation) migrationBlock {
+	block := migrationBlock{
+		{"gateway", subnet.Gateway},
+		{"prefix_length", netmaskToPrefixLength(subnet.Netmask)},
+	}
+	if subnet.IPAddress != "" {
+		block = append(block, migrationAttribute{"primary_ip", subnet.IPAddress})
+	}
+	var ranges []migrationBlock
+	primaryInRange := subnet.IPAddress == ""
+	if subnet.IPRanges != nil {
+		for _, ipRange := range subnet.IPRanges.IPRange {
+			ranges = append(ranges, migrationBlock{
+				{"start_address", ipRange.StartAddress},
+				{"end_address", ipRange.EndAddress},
+			})
+			primaryInRange = primaryInRange || isIpInRange(subnet.IPAddress, ipRange.StartAddress, ipRange.EndAddress)
+		}
+	}
+	// The primary IP of an NSX-T Edge Gateway must be one of the allocated IPs
+	if !primaryInRange {
+		ranges = append(ranges, migrationBlock{
+			{"start_address", subnet.IPAddress},
+			{"end_address", subnet.IPAddress},
+		})
+	}
+	if len(ranges) > 0 {
+		block = append(block, migrationAttribute{"allocated_ips", ranges})
+	}
+	return block
+}
+
+func netmaskToPrefixLength(netmask string) int {
+	ip := net.ParseIP(netmask).To4()
+	if ip == nil {
+		return 0
+	}
+	ones, _ := net.IPv4Mask(ip[0], ip[1], ip[2], ip[3]).Size()
+	return ones
+}
+
+func isIpInRange(ip, start, end string) bool {
+	address, startAddress, endAddress := net.ParseIP(ip).To16(), net.ParseIP(start).To16(), net.ParseIP(end).To16()
+	if address == nil || startAddress == nil || endAddress == nil {
+		return false
+	}
+	return bytes.Compare(address, startAddress) >= 0 && bytes.Compare(address, endAddress) <= 0
+}
+
+// appPortProfile returns a reference to an Application Port Profile for the given protocol and port, creating it
+// when needed. Empty ports, or 'any', match all ports
+func (plan *nsxvMigrationPlan) appPortProfile(protocol, port string) hclReference {
+	protocol = strings.ToUpper(protocol)
+	if protocol == "ICMP" {
+		protocol = "ICMPv4"
+	}
+	if strings.EqualFold(port, "any") {
+		port = ""
+	}
+	key := protocol + "-" + port
+	if key[len(key)-1] == '-' {
+		key += "any"
+	}
+	if ref, ok := plan.appPortProfiles[key]; ok {
+		return ref
+	}
+	appPort := migrationBlock{{"protocol", protocol}}
+	if port != "" {
+		appPort = append(appPort, migrationAttribute{"port", strings.Split(port, ",")})
+	}
+	resource := plan.addResource("vcd_nsxt_app_port_profile", strings.ToLower(key), migrationBlock{
+		{"org", plan.options.Org},
+		{"context_id", plan.edgeGatewayId},
+		{"name", fmt.Sprintf("%s-%s", plan.edgeGatewayName, strings.ToLower(key))},
+		{"scope", "TENANT"},
+		{"app_port", []migrationBlock{appPort}},
+	})
+	plan.appPortProfiles[key] = resource.ref("id")
+	return plan.appPortProfiles[key]
+}
+
+func (plan *nsxvMigrationPlan) resourcePrefix() string {
+	for _, resource := range plan.Resources {
+		if resource.Type == "vcd_nsxt_edgegateway" {
+			return resource.Name
+		}
+	}
+	return "edge"
+}
+
+func (plan *nsxvMigrationPlan) convertNatRules(rules []*types.EdgeNatRule) {
+	for _, rule := range rules {
+		if rule.RuleType != "" && rule.RuleType != "user" {
+			continue
+		}
+		ruleType := strings.ToUpper(rule.Action)
+		name := strings.ToLower(ruleType) + "-" + rule.ID
+		body := migrationBlock{
+			{"org", plan.options.Org},
+			{"edge_gateway_id", plan.edgeGatewayId},
+			{"name", name},
+			{"rule_type", ruleType},
+			{"description", rule.Description},
+		}
+		protocol := strings.ToLower(rule.Protocol)
+		switch ruleType {
+		case "DNAT":
+			body = append(body,
+				migrationAttribute{"external_address", rule.OriginalAddress},
+				migrationAttribute{"internal_address", rule.TranslatedAddress},
+			)
+			// In NSX-T, the Application Port Profile holds the translated port, and 'dnat_external_port' the
+			// original one when they differ
+			originalPort, translatedPort := nsxvPortValue(rule.OriginalPort), nsxvPortValue(rule.TranslatedPort)
+			switch protocol {
+			case "tcp", "udp":
+				profilePort := translatedPort
+				if profilePort == "" {
+					profilePort = originalPort
+				}
+				body = append(body, migrationAttribute{"app_port_profile_id", plan.appPortProfile(protocol, profilePort)})
+				if originalPort != "" && originalPort != profilePort {
+					body = append(body, migrationAttribute{"dnat_external_port", originalPort})
+				}
+			case "icmp":
+				if rule.IcmpType != "" && !strings.EqualFold(rule.IcmpType, "any") {
+					plan.unsupported("nat_rule", rule.ID, name, fmt.Sprintf("ICMP type '%s' is not converted. The rule matches any ICMP type", rule.IcmpType))
+				}
+				body = append(body, migrationAttribute{"app_port_profile_id", plan.appPortProfile(protocol, "")})
+			}
+		case "SNAT":
+			body = append(body,
+				migrationAttribute{"external_address", rule.TranslatedAddress},
+				migrationAttribute{"internal_address", rule.OriginalAddress},
+			)
+			if protocol != "" && protocol != "any" {
+				plan.unsupported("nat_rule", rule.ID, name, "protocol and ports of SNAT rules are not supported by NSX-T. The rule matches any traffic")
+			}
+		default:
+			plan.unsupported("nat_rule", rule.ID, name, fmt.Sprintf("NAT action '%s' is not supported", rule.Action))
+			continue
+		}
+		body = append(body,
+			migrationAttribute{"enabled", rule.Enabled},
+			migrationAttribute{"logging", rule.LoggingEnabled},
+		)
+		plan.addResource("vcd_nsxt_nat_rule", name, body)
+	}
+}
+
+func nsxvPortValue(port string) string {
+	if strings.EqualFold(port, "any") {
+		return ""
+	}
+	return port
+}
+
+func (plan *nsxvMigrationPlan) convertFirewallRules(source nsxvMigrationSource) {
+	ipSetsById := make(map[string]*types.EdgeIpSet)
+	for _, ipSet := range source.IpSets {
+		ipSetsById[ipSet.ID] = ipSet
+	}
+
+	var rules []migrationBlock
+	for _, rule := range source.FirewallRules {
+		if rule.RuleType != "" && rule.RuleType != "user" {
+			continue
+		}
+		name := rule.Name
+		if name == "" {
+			name = "rule-" + rule.ID
+		}
+		sourceIds, ok := plan.convertFirewallEndpoint(rule, name, "source", rule.Source, ipSetsById)
+		if !ok {
+			continue
+		}
+		destinationIds, ok := plan.convertFirewallEndpoint(rule, name, "destination", rule.Destination, ipSetsById)
+		if !ok {
+			continue
+		}
+		if rule.Application.ID != "" {
+			plan.unsupported("firewall_rule", rule.ID, name, "NSX-V applications are not converted. Use an equivalent 'vcd_nsxt_app_port_profile'")
+			continue
+		}
+		var appPortProfileIds []hclReference
+		for _, service := range rule.Application.Services {
+			if service.SourcePort != "" && !strings.EqualFold(service.SourcePort, "any") {
+				plan.unsupported("firewall_rule", rule.ID, name, "source ports are not converted. The rule matches any source port")
+			}
+			if strings.EqualFold(service.Protocol, "any") || service.Protocol == "" {
+				appPortProfileIds = nil
+				break
+			}
+			appPortProfileIds = append(appPortProfileIds, plan.appPortProfile(service.Protocol, nsxvPortValue(service.Port)))
+		}
+
+		block := migrationBlock{
+			{"name", name},
+			{"direction", nsxvFirewallDirection(rule.Direction)},
+			{"ip_protocol", "IPV4_IPV6"},
+			{"action", nsxvFirewallAction(rule.Action)},
+			{"enabled", rule.Enabled},
+			{"logging", rule.LoggingEnabled},
+		}
+		if len(sourceIds) > 0 {
+			block = append(block, migrationAttribute{"source_ids", sourceIds})
+		}
+		if len(destinationIds) > 0 {
+			block = append(block, migrationAttribute{"destination_ids", destinationIds})
+		}
+		if len(appPortProfileIds) > 0 {
+			block = append(block, migrationAttribute{"app_port_profile_ids", appPortProfileIds})
+		}
+		rules = append(rules, block)
+	}
+
+	// The default rule of NSX-T Edge Gateways drops all traffic. An allow-all NSX-V default policy becomes an
+	// explicit last rule
+	if source.FirewallDefaultPolicy != nil && nsxvFirewallAction(source.FirewallDefaultPolicy.Action) != "DROP" {
+		rules = append(rules, migrationBlock{
+			{"name", "default-policy"},
+			{"direction", "IN_OUT"},
+			{"ip_protocol", "IPV4_IPV6"},
+			{"action", nsxvFirewallAction(source.FirewallDefaultPolicy.Action)},
+			{"enabled", true},
+			{"logging", source.FirewallDefaultPolicy.LoggingEnabled},
+		})
+	}
+
+	if len(rules) == 0 {
+		return
+	}
+	plan.addResource("vcd_nsxt_firewall", plan.resourcePrefix(), migrationBlock{
+		{"org", plan.options.Org},
+		{"edge_gateway_id", plan.edgeGatewayId},
+		{"rule", rules},
+	})
+}
+
+// convertFirewallEndpoint returns the IP Set references for the source or destination of a firewall rule. It returns
+// false when the endpoint can't be converted, in which case the rule is skipped
+func (plan *nsxvMigrationPlan) convertFirewallEndpoint(rule *types.EdgeFirewallRule, ruleName, endpointName string, endpoint types.EdgeFirewallEndpoint, ipSetsById map[string]*types.EdgeIpSet) ([]hclReference, bool) {
+	if endpoint.Exclude {
+		plan.unsupported("firewall_rule", rule.ID, ruleName, fmt.Sprintf("negated %s is not supported by NSX-T", endpointName))
+		return nil, false
+	}
+	if len(endpoint.VnicGroupIds) > 0 {
+		plan.unsupported("firewall_rule", rule.ID, ruleName,
+			fmt.Sprintf("gateway interfaces in %s are not supported by NSX-T. Use IP Sets or Static Groups", endpointName))
+		return nil, false
+	}
+	var ids []hclReference
+	for _, groupingObjectId := range endpoint.GroupingObjectIds {
+		ipSet, ok := ipSetsById[groupingObjectId]
+		if !ok {
+			plan.unsupported("firewall_rule", rule.ID, ruleName,
+				fmt.Sprintf("%s object '%s' is not converted. Only IP Sets and IP addresses are supported", endpointName, groupingObjectId))
+			return nil, false
+		}
+		ids = append(ids, plan.ipSet(ipSet))
+	}
+	var addresses []string
+	for _, address := range endpoint.IpAddresses {
+		if !strings.EqualFold(address, "any") {
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) > 0 {
+		resource := plan.addResource("vcd_nsxt_ip_set", ruleName+"_"+endpointName, migrationBlock{
+			{"org", plan.options.Org},
+			{"edge_gateway_id", plan.edgeGatewayId},
+			{"name", fmt.Sprintf("%s-%s", ruleName, endpointName)},
+			{"ip_addresses", addresses},
+		})
+		ids = append(ids, resource.ref("id"))
+	}
+	return ids, true
+}
+
+// ipSet returns a reference to the NSX-T IP Set converted from the given NSX-V IP Set, creating it when needed
+func (plan *nsxvMigrationPlan) ipSet(ipSet *types.EdgeIpSet) hclReference {
+	if ref, ok := plan.ipSets[ipSet.ID]; ok {
+		return ref
+	}
+	var addresses []string
+	for _, address := range strings.Split(ipSet.IPAddresses, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	resource := plan.addResource("vcd_nsxt_ip_set", ipSet.Name, migrationBlock{
+		{"org", plan.options.Org},
+		{"edge_gateway_id", plan.edgeGatewayId},
+		{"name", ipSet.Name},
+		{"description", ipSet.Description},
+		{"ip_addresses", addresses},
+	})
+	plan.ipSets[ipSet.ID] = resource.ref("id")
+	return plan.ipSets[ipSet.ID]
+}
+
+func nsxvFirewallDirection(direction string) string {
+	switch strings.ToLower(direction) {
+	case "in":
+		return "IN"
+	case "out":
+		return "OUT"
+	}
+	return "IN_OUT"
+}
+
+func nsxvFirewallAction(action string) string {
+	switch strings.ToLower(action) {
+	case "accept":
+		return "ALLOW"
+	case "reject":
+		return "REJECT"
+	}
+	return "DROP"
+}
+
+// nsxvLbAlgorithms maps NSX-V load balancer algorithms to the NSX-T ALB ones
+var nsxvLbAlgorithms = map[string]string{
+	"round-robin": "ROUND_ROBIN",
+	"leastconn":   "LEAST_CONNECTIONS",
+}
+
+// nsxvLbMonitorTypes maps NSX-V health monitor types to the NSX-T ALB ones
+var nsxvLbMonitorTypes = map[string]string{
+	"http":  "HTTP",
+	"https": "HTTPS",
+	"tcp":   "TCP",
+	"udp":   "UDP",
+	"icmp":  "PING",
+}
+
+// nsxvLbPersistenceTypes maps NSX-V persistence methods to the NSX-T ALB ones
+var nsxvLbPersistenceTypes = map[string]string{
+	"sourceip": "CLIENT_IP",
+	"cookie":   "HTTP_COOKIE",
+}
+
+func (plan *nsxvMigrationPlan) convertLoadBalancer(source nsxvMigrationSource) {
+	if !source.LoadBalancerEnabled || len(source.LbVirtualServers) == 0 {
+		return
+	}
+	pools := make(map[string]*types.LbPool)
+	for _, pool := range source.LbPools {
+		pools[pool.ID] = pool
+	}
+	monitors := make(map[string]*types.LbMonitor)
+	for _, monitor := range source.LbMonitors {
+		monitors[monitor.ID] = monitor
+	}
+	appProfiles := make(map[string]*types.LbAppProfile)
+	for _, appProfile := range source.LbAppProfiles {
+		appProfiles[appProfile.ID] = appProfile
+	}
+	for _, appRule := range source.LbAppRules {
+		plan.unsupported("lb_app_rule", appRule.ID, appRule.Name, "application rules are not converted. Use HTTP policies of the ALB Virtual Service")
+	}
+
+	settings := plan.addResource("vcd_nsxt_alb_settings", plan.resourcePrefix(), migrationBlock{
+		{"org", plan.options.Org},
+		{"edge_gateway_id", plan.edgeGatewayId},
+		{"is_active", true},
+	})
+	serviceEngineGroup := plan.addResource("vcd_nsxt_alb_edgegateway_service_engine_group", plan.resourcePrefix(), migrationBlock{
+		{"org", plan.options.Org},
+		{"edge_gateway_id", settings.ref("edge_gateway_id")},
+		{"service_engine_group_id", plan.valueOrVariable(plan.options.ServiceEngineGroupId, "nsxt_alb_service_engine_group_id",
+			"ID of the ALB Service Engine Group used by the Virtual Services")},
+	})
+
+	poolIds := make(map[string]hclReference)
+	for _, virtualServer := range source.LbVirtualServers {
+		if len(virtualServer.ApplicationRuleIds) > 0 {
+			plan.unsupported("lb_virtual_server", virtualServer.ID, virtualServer.Name, "application rules of the virtual server are not converted")
+		}
+		pool, ok := pools[virtualServer.DefaultPoolId]
+		if !ok {
+			plan.unsupported("lb_virtual_server", virtualServer.ID, virtualServer.Name, "virtual servers without default pool are not supported by NSX-T ALB")
+			continue
+		}
+		appProfile := appProfiles[virtualServer.ApplicationProfileId]
+		poolId, ok := poolIds[pool.ID]
+		if !ok {
+			poolId = plan.convertLbPool(pool, monitors, appProfile, settings)
+			poolIds[pool.ID] = poolId
+		}
+
+		profileType, servicePortType := "L4", "TCP_PROXY"
+		sslEnabled := false
+		switch strings.ToLower(virtualServer.Protocol) {
+		case "http":
+			profileType = "HTTP"
+		case "https":
+			if appProfile != nil && appProfile.SslPassthrough {
+				profileType = "L4"
+			} else {
+				profileType, sslEnabled = "HTTPS", true
+			}
+		case "udp":
+			servicePortType = "UDP_FAST_PATH"
+		}
+		if appProfile != nil && appProfile.HttpRedirect != nil {
+			plan.unsupported("lb_app_profile", appProfile.ID, appProfile.Name, "HTTP redirect is not converted. Use HTTP policies of the ALB Virtual Service")
+		}
+
+		servicePort := migrationBlock{
+			{"start_port", virtualServer.Port},
+			{"type", servicePortType},
+		}
+		if sslEnabled {
+			servicePort = append(servicePort, migrationAttribute{"ssl_enabled", true})
+		}
+		body := migrationBlock{
+			{"org", plan.options.Org},
+			{"edge_gateway_id", serviceEngineGroup.ref("edge_gateway_id")},
+			{"name", virtualServer.Name},
+			{"description", virtualServer.Description},
+			{"pool_id", poolId},
+			{"service_engine_group_id", serviceEngineGroup.ref("service_engine_group_id")},
+			{"virtual_ip_address", virtualServer.IpAddress},
+			{"application_profile_type", profileType},
+			{"enabled", virtualServer.Enabled},
+		}
+		if sslEnabled {
+			body = append(body, migrationAttribute{"ca_certificate_id", plan.valueOrVariable("", "nsxt_alb_certificate_id",
+				"ID of the certificate, from the Org certificate library, used by HTTPS Virtual Services")})
+			plan.unsupported("lb_virtual_server", virtualServer.ID, virtualServer.Name,
+				"SSL certificates are not converted. Upload the certificate to the Org certificate library")
+		}
+		body = append(body, migrationAttribute{"service_port", []migrationBlock{servicePort}})
+		plan.addResource("vcd_nsxt_alb_virtual_service", virtualServer.Name, body)
+	}
+}
+
+func (plan *nsxvMigrationPlan) convertLbPool(pool *types.LbPool, monitors map[string]*types.LbMonitor, appProfile *types.LbAppProfile, settings migrationResource) hclReference {
+	algorithm, ok := nsxvLbAlgorithms[pool.Algorithm]
+	if !ok {
+		algorithm = "LEAST_CONNECTIONS"
+		plan.unsupported("lb_pool", pool.ID, pool.Name, fmt.Sprintf("algorithm '%s' is not converted. LEAST_CONNECTIONS is used", pool.Algorithm))
+	}
+	if pool.Transparent {
+		plan.unsupported("lb_pool", pool.ID, pool.Name, "transparent mode is not converted. Use 'is_transparent_mode_enabled' of 'vcd_nsxt_alb_settings'")
+	}
+	body := migrationBlock{
+		{"org", plan.options.Org},
+		{"edge_gateway_id", settings.ref("edge_gateway_id")},
+		{"name", pool.Name},
+		{"description", pool.Description},
+		{"enabled", true},
+		{"algorithm", algorithm},
+	}
+
+	var members []migrationBlock
+	for _, member := range pool.Members {
+		block := migrationBlock{
+			{"enabled", member.Condition != "disabled"},
+			{"ip_address", member.IpAddress},
+		}
+		if member.Port > 0 {
+			block = append(block, migrationAttribute{"port", member.Port})
+		}
+		if member.Weight > 0 {
+			block = append(block, migrationAttribute{"ratio", member.Weight})
+		}
+		if member.MinConn > 0 || member.MaxConn > 0 {
+			plan.unsupported("lb_pool", pool.ID, pool.Name, fmt.Sprintf("connection limits of member '%s' are not converted", member.Name))
+		}
+		members = append(members, block)
+	}
+	if len(members) > 0 {
+		body = append(body, migrationAttribute{"member", members})
+	}
+
+	if monitor, ok := monitors[pool.MonitorId]; ok {
+		if monitorType, ok := nsxvLbMonitorTypes[monitor.Type]; ok {
+			body = append(body, migrationAttribute{"health_monitor", []migrationBlock{{{"type", monitorType}}}})
+			if monitor.URL != "" || monitor.Send != "" || monitor.Receive != "" || monitor.Expected != "" {
+				plan.unsupported("lb_service_monitor", monitor.ID, monitor.Name, "custom monitor settings are not converted. The system defined ALB monitor is used")
+			}
+		} else {
+			plan.unsupported("lb_service_monitor", monitor.ID, monitor.Name, fmt.Sprintf("monitor type '%s' is not supported by NSX-T ALB", monitor.Type))
+		}
+	}
+
+	if appProfile != nil && appProfile.Persistence != nil && appProfile.Persistence.Method != "" {
+		if persistenceType, ok := nsxvLbPersistenceTypes[appProfile.Persistence.Method]; ok {
+			persistence := migrationBlock{{"type", persistenceType}}
+			if persistenceType == "HTTP_COOKIE" && appProfile.Persistence.CookieName != "" {
+				persistence = append(persistence, migrationAttribute{"value", appProfile.Persistence.CookieName})
+			}
+			body = append(body, migrationAttribute{"persistence_profile", []migrationBlock{persistence}})
+		} else {
+			plan.unsupported("lb_app_profile", appProfile.ID, appProfile.Name,
+				fmt.Sprintf("persistence method '%s' is not converted", appProfile.Persistence.Method))
+		}
+	}
+
+	return plan.addResource("vcd_nsxt_alb_pool", pool.Name, body).ref("id")
+}
+
+var hclIdentifierInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// hclIdentifier converts a name into a valid Terraform resource name
+func hclIdentifier(name string) string {
+	identifier := strings.Trim(hclIdentifierInvalidChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if identifier == "" || (identifier[0] >= '0' && identifier[0] <= '9') {
+		identifier = "r_" + identifier
+	}
+	return identifier
+}
+
+// hclString quotes a string, escaping the template sequences of HCL
+func hclString(value string) string {
+	value = strings.ReplaceAll(value, "${", "$${")
+	value = strings.ReplaceAll(value, "%{", "%%{")
+	return strconv.Quote(value)
+}
+
+// renderHcl renders the plan as HCL, formatted as 'terraform fmt' does
+func (plan *nsxvMigrationPlan) renderHcl() string {
+	var buf strings.Builder
+	for _, variable := range plan.Variables {
+		buf.WriteString(fmt.Sprintf("variable %q {\n", variable.Name))
+		writeHclBlock(&buf, migrationBlock{
+			{"type", hclReference("string")},
+			{"description", variable.Description},
+		}, 1)
+		buf.WriteString("}\n\n")
+	}
+	for i, resource := range plan.Resources {
+		buf.WriteString(fmt.Sprintf("resource %q %q {\n", resource.Type, resource.Name))
+		writeHclBlock(&buf, resource.Body, 1)
+		buf.WriteString("}\n")
+		if i < len(plan.Resources)-1 {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.String()
+}
+
+func writeHclBlock(buf *strings.Builder, block migrationBlock, level int) {
+	indent := strings.Repeat("  ", level)
+	// Consecutive attributes have their '=' aligned
+	width := 0
+	for i, attribute := range block {
+		if _, isBlock := attribute.Value.([]migrationBlock); isBlock {
+			width = 0
+			continue
+		}
+		if width == 0 {
+			for j := i; j < len(block); j++ {
+				if _, isBlock := block[j].Value.([]migrationBlock); isBlock {
+					break
+				}
+				width = max(width, len(block[j].Key))
+			}
+		}
+		buf.WriteString(fmt.Sprintf("%s%-*s = %s\n", indent, width, attribute.Key, hclValue(attribute.Value)))
+	}
+	for i, attribute := range block {
+		blocks, isBlock := attribute.Value.([]migrationBlock)
+		if !isBlock {
+			continue
+		}
+		for _, nested := range blocks {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			buf.WriteString(fmt.Sprintf("%s%s {\n", indent, attribute.Key))
+			writeHclBlock(buf, nested, level+1)
+			buf.WriteString(fmt.Sprintf("%s}\n", indent))
+		}
+	}
+}
+
+func hclValue(value interface{}) string {
+	switch typed := value.(type) {
+	case string:
+		return hclString(typed)
+	case hclReference:
+		return string(typed)
+	case []string:
+		items := make([]string, len(typed))
+		for i, item := range typed {
+			items[i] = hclString(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case []hclReference:
+		items := make([]string, len(typed))
+		for i, item := range typed {
+			items[i] = string(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// renderJson renders the plan using the JSON configuration syntax of Terraform
+func (plan *nsxvMigrationPlan) renderJson() (string, error) {
+	configuration := make(map[string]interface{})
+	if len(plan.Variables) > 0 {
+		variables := make(map[string]interface{})
+		for _, variable := range plan.Variables {
+			variables[variable.Name] = map[string]interface{}{
+				"type":        "string",
+				"description": variable.Description,
+			}
+		}
+		configuration["variable"] = variables
+	}
+	resources := make(map[string]map[string]interface{})
+	for _, resource := range plan.Resources {
+		if resources[resource.Type] == nil {
+			resources[resource.Type] = make(map[string]interface{})
+		}
+		resources[resource.Type][resource.Name] = jsonBlock(resource.Body)
+	}
+	configuration["resource"] = resources
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(configuration)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func jsonBlock(block migrationBlock) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, attribute := range block {
+		result[attribute.Key] = jsonValue(attribute.Value)
+	}
+	return result
+}
+
+func jsonValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case string:
+		return strings.ReplaceAll(strings.ReplaceAll(typed, "${", "$${"), "%{", "%%{")
+	case hclReference:
+		return "${" + string(typed) + "}"
+	case []string:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			items[i] = jsonValue(item)
+		}
+		return items
+	case []hclReference:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			items[i] = jsonValue(item)
+		}
+		return items
+	case []migrationBlock:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			items[i] = jsonBlock(item)
+		}
+		return items
+	}
+	return value
+}
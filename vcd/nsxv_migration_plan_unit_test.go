@@ -0,0 +1,198 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func testNsxvMigrationSource() nsxvMigrationSource {
+	return nsxvMigrationSource{
+		EdgeGateway: &types.EdgeGateway{
+			Name:        "edge-v",
+			Description: "NSX-V edge",
+			Configuration: &types.GatewayConfiguration{
+				GatewayInterfaces: &types.GatewayInterfaces{
+					GatewayInterface: []*types.GatewayInterface{
+						{
+							Name:               "uplink1",
+							DisplayName:        "ext-net",
+							InterfaceType:      "uplink",
+							UseForDefaultRoute: true,
+							SubnetParticipation: []*types.SubnetParticipation{
+								{
+									Gateway:   "192.168.1.1",
+									Netmask:   "255.255.255.0",
+									IPAddress: "192.168.1.10",
+									IPRanges: &types.IPRanges{IPRange: []*types.IPRange{
+										{StartAddress: "192.168.1.20", EndAddress: "192.168.1.30"},
+									}},
+								},
+							},
+						},
+						{
+							Name:          "internal1",
+							DisplayName:   "org-net",
+							InterfaceType: "internal",
+						},
+					},
+				},
+			},
+		},
+		NatRules: []*types.EdgeNatRule{
+			{ID: "196609", RuleType: "user", Action: "dnat", OriginalAddress: "192.168.1.20", TranslatedAddress: "10.0.0.5",
+				Protocol: "tcp", OriginalPort: "80", TranslatedPort: "8080", Enabled: true},
+			{ID: "196610", RuleType: "user", Action: "snat", OriginalAddress: "10.0.0.0/24", TranslatedAddress: "192.168.1.21",
+				Protocol: "any", Enabled: true},
+			{ID: "196611", RuleType: "internal_high", Action: "snat"},
+		},
+		IpSets: []*types.EdgeIpSet{
+			{ID: "f9daf2da-b4f9-4921-a2f4-d77a943a381c:ipset-2", Name: "web servers", IPAddresses: "10.0.0.5,10.0.0.6-10.0.0.8"},
+		},
+		FirewallRules: []*types.EdgeFirewallRule{
+			{ID: "131073", RuleType: "internal_high", Name: "firewall"},
+			{
+				ID: "131074", RuleType: "user", Name: "allow-https", Action: "accept", Enabled: true,
+				Source:      types.EdgeFirewallEndpoint{IpAddresses: []string{"172.16.0.0/16"}},
+				Destination: types.EdgeFirewallEndpoint{GroupingObjectIds: []string{"f9daf2da-b4f9-4921-a2f4-d77a943a381c:ipset-2"}},
+				Application: types.EdgeFirewallApplication{Services: []types.EdgeFirewallApplicationService{
+					{Protocol: "tcp", Port: "443", SourcePort: "any"},
+				}},
+			},
+			{
+				ID: "131075", RuleType: "user", Name: "from-internal", Action: "accept", Enabled: true,
+				Source: types.EdgeFirewallEndpoint{VnicGroupIds: []string{"internal"}},
+			},
+		},
+		FirewallDefaultPolicy: &types.FirewallDefaultPolicy{Action: "accept"},
+		LoadBalancerEnabled:   true,
+		LbPools: []*types.LbPool{
+			{ID: "pool-1", Name: "web-pool", Algorithm: "round-robin", MonitorId: "monitor-1", Members: types.LbPoolMembers{
+				{Name: "web1", IpAddress: "10.0.0.5", Port: 8080, Weight: 1, Condition: "enabled"},
+				{Name: "web2", IpAddress: "10.0.0.6", Port: 8080, Weight: 1, Condition: "disabled"},
+			}},
+		},
+		LbMonitors: []*types.LbMonitor{
+			{ID: "monitor-1", Name: "http-monitor", Type: "http", URL: "/health"},
+		},
+		LbAppProfiles: []*types.LbAppProfile{
+			{ID: "profile-1", Name: "http", Template: "HTTP", Persistence: &types.LbAppProfilePersistence{Method: "sourceip"}},
+		},
+		LbVirtualServers: []*types.LbVirtualServer{
+			{ID: "vs-1", Name: "web", Enabled: true, IpAddress: "192.168.1.22", Protocol: "http", Port: 80,
+				ApplicationProfileId: "profile-1", DefaultPoolId: "pool-1"},
+			{ID: "vs-2", Name: "no-pool", Enabled: true, IpAddress: "192.168.1.23", Protocol: "tcp", Port: 22},
+		},
+	}
+}
+
+// Test_buildNsxvMigrationPlan checks the conversion of an NSX-V Edge Gateway and its services into NSX-T resources
+func Test_buildNsxvMigrationPlan(t *testing.T) {
+	plan := buildNsxvMigrationPlan(testNsxvMigrationSource(), nsxvMigrationOptions{Org: "my-org"})
+
+	var resources []string
+	for _, resource := range plan.Resources {
+		resources = append(resources, resource.Type+"."+resource.Name)
+	}
+	expectedResources := []string{
+		"vcd_nsxt_edgegateway.edge_v",
+		"vcd_nsxt_app_port_profile.tcp_8080",
+		"vcd_nsxt_nat_rule.dnat_196609",
+		"vcd_nsxt_nat_rule.snat_196610",
+		"vcd_nsxt_ip_set.allow_https_source",
+		"vcd_nsxt_ip_set.web_servers",
+		"vcd_nsxt_app_port_profile.tcp_443",
+		"vcd_nsxt_firewall.edge_v",
+		"vcd_nsxt_alb_settings.edge_v",
+		"vcd_nsxt_alb_edgegateway_service_engine_group.edge_v",
+		"vcd_nsxt_alb_pool.web_pool",
+		"vcd_nsxt_alb_virtual_service.web",
+	}
+	if strings.Join(resources, ",") != strings.Join(expectedResources, ",") {
+		t.Errorf("expected resources:\n%v\ngot:\n%v", expectedResources, resources)
+	}
+
+	var variables []string
+	for _, variable := range plan.Variables {
+		variables = append(variables, variable.Name)
+	}
+	expectedVariables := "nsxt_owner_id,nsxt_external_network_id,nsxt_alb_service_engine_group_id"
+	if strings.Join(variables, ",") != expectedVariables {
+		t.Errorf("expected variables %s, got %v", expectedVariables, variables)
+	}
+
+	unsupported := make(map[string]bool)
+	for _, item := range plan.Unsupported {
+		unsupported[item.SourceType+":"+item.Name] = true
+	}
+	for _, expected := range []string{
+		"edge_gateway_interface:org-net",
+		"firewall_rule:from-internal",
+		"lb_service_monitor:http-monitor",
+		"lb_virtual_server:no-pool",
+	} {
+		if !unsupported[expected] {
+			t.Errorf("expected '%s' to be reported as unsupported, got %v", expected, plan.Unsupported)
+		}
+	}
+
+	hcl := plan.renderHcl()
+	for _, expected := range []string{
+		`variable "nsxt_owner_id" {`,
+		`resource "vcd_nsxt_edgegateway" "edge_v" {`,
+		`  external_network_id = var.nsxt_external_network_id`,
+		`    prefix_length = 24`,
+		`      start_address = "192.168.1.10"`,
+		`  app_port_profile_id = vcd_nsxt_app_port_profile.tcp_8080.id`,
+		`  dnat_external_port  = "80"`,
+		`    destination_ids      = [vcd_nsxt_ip_set.web_servers.id]`,
+		`  ip_addresses    = ["10.0.0.5", "10.0.0.6-10.0.0.8"]`,
+		`    name        = "default-policy"`,
+		`    type = "CLIENT_IP"`,
+		`  service_engine_group_id  = vcd_nsxt_alb_edgegateway_service_engine_group.edge_v.service_engine_group_id`,
+	} {
+		if !strings.Contains(hcl, expected) {
+			t.Errorf("expected HCL to contain '%s'. HCL:\n%s", expected, hcl)
+		}
+	}
+
+	jsonText, err := plan.renderJson()
+	if err != nil {
+		t.Fatalf("error rendering JSON: %s", err)
+	}
+	var configuration struct {
+		Resource map[string]map[string]map[string]interface{} `json:"resource"`
+	}
+	err = json.Unmarshal([]byte(jsonText), &configuration)
+	if err != nil {
+		t.Fatalf("error parsing rendered JSON: %s", err)
+	}
+	natRule := configuration.Resource["vcd_nsxt_nat_rule"]["dnat_196609"]
+	if natRule["edge_gateway_id"] != "${vcd_nsxt_edgegateway.edge_v.id}" || natRule["rule_type"] != "DNAT" {
+		t.Errorf("unexpected DNAT rule in JSON: %v", natRule)
+	}
+}
+
+func Test_hclHelpers(t *testing.T) {
+	identifiers := map[string]string{
+		"web servers": "web_servers",
+		"10-net":      "r_10_net",
+		"Edge-GW.01":  "edge_gw_01",
+		"---":         "r_",
+	}
+	for name, expected := range identifiers {
+		if got := hclIdentifier(name); got != expected {
+			t.Errorf("hclIdentifier(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+	if got := hclString(`a "b" ${c}`); got != `"a \"b\" $${c}"` {
+		t.Errorf("unexpected escaped string %s", got)
+	}
+	if got := netmaskToPrefixLength("255.255.254.0"); got != 23 {
+		t.Errorf("expected prefix length 23, got %d", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"time"
 
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 
@@ -170,6 +171,21 @@ var globalDataSourceMap = map[string]*schema.Resource{
 	"vcd_org_vdc_template":                             datasourceVcdOrgVdcTemplate(),                          // 3.13
 	"vcd_external_endpoint":                            datasourceVcdExternalEndpoint(),                        // 3.14
 	"vcd_api_filter":                                   datasourceVcdApiFilter(),                               // 3.14
+	"vcd_vm_snapshot":                                  datasourceVcdVmSnapshot(),                              // 3.14
+	"vcd_nsxt_alb_virtual_service_http_req_rules":      datasourceVcdAlbVirtualServiceHttpReqRules(),           // 3.14
+	"vcd_nsxt_alb_virtual_service_http_resp_rules":     datasourceVcdAlbVirtualServiceHttpRespRules(),          // 3.14
+	"vcd_nsxt_alb_virtual_service_http_sec_rules":      datasourceVcdAlbVirtualServiceHttpSecRules(),           // 3.14
+	"vcd_org_settings":                                 datasourceVcdOrgSettings(),                             // 3.14
+	"vcd_nsxt_distributed_firewall_effective_policy":   datasourceVcdNsxtDistributedFirewallEffectivePolicy(),  // 3.14
+	"vcd_nsxt_ipsec_vpn_tunnel_status":                 datasourceVcdNsxtIpSecVpnTunnelStatus(),                // 3.14
+	"vcd_vm_console_ticket":                            datasourceVcdVmConsoleTicket(),                         // 3.14
+	"vcd_vapp_lease_expiry":                            datasourceVcdVappLeaseExpiry(),                         // 3.14
+	"vcd_nsxv_migration_plan":                          datasourceVcdNsxvMigrationPlan(),                       // 3.14
+	"vcd_rights_comparison":                            datasourceVcdRightsComparison(),                        // 3.14
+	"vcd_least_privilege_rights":                       datasourceVcdLeastPrivilegeRights(),                    // 3.14
+	"vcd_current_session":                              datasourceVcdCurrentSession(),                          // 3.14
+	"vcd_nsxt_edgegateway_l2_vpn_tunnel_status":        datasourceVcdNsxtEdgegatewayL2VpnTunnelStatus(),        // 3.14
+	"vcd_org_ldap_search":                              datasourceVcdOrgLdapSearch(),                           // 3.14
 }
 
 var globalResourceMap = map[string]*schema.Resource{
@@ -293,6 +309,13 @@ var globalResourceMap = map[string]*schema.Resource{
 	"vcd_org_vdc_template_instance":                    resourceVcdOrgVdcTemplateInstance(),                  // 3.13
 	"vcd_external_endpoint":                            resourceVcdExternalEndpoint(),                        // 3.14
 	"vcd_api_filter":                                   resourceVcdApiFilter(),                               // 3.14
+	"vcd_vm_snapshot":                                  resourceVcdVmSnapshot(),                              // 3.14
+	"vcd_catalog_item_download":                        resourceVcdCatalogItemDownload(),                     // 3.14
+	"vcd_nsxt_alb_virtual_service_http_req_rules":      resourceVcdAlbVirtualServiceHttpReqRules(),           // 3.14
+	"vcd_nsxt_alb_virtual_service_http_resp_rules":     resourceVcdAlbVirtualServiceHttpRespRules(),          // 3.14
+	"vcd_nsxt_alb_virtual_service_http_sec_rules":      resourceVcdAlbVirtualServiceHttpSecRules(),           // 3.14
+	"vcd_org_settings":                                 resourceVcdOrgSettings(),                             // 3.14
+	"vcd_vapp_lease_renewal":                           resourceVcdVappLeaseRenewal(),                        // 3.14
 }
 
 // Provider returns a terraform.ResourceProvider.
@@ -405,6 +428,29 @@ func Provider() *schema.Provider {
 				Description: "Max num seconds to wait for successful response when operating on resources within vCloud (defaults to 60)",
 			},
 
+			"max_requests_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VCD_MAX_REQUESTS_PER_SECOND", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of API requests sent to VCD every second. When set, requests throttled by VCD (429 and 503) are retried. 0 (default) means unlimited",
+			},
+
+			"max_concurrent_operations": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VCD_MAX_CONCURRENT_OPERATIONS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of resource create, update and delete operations running at the same time. 0 (default) means unlimited",
+			},
+
+			"preflight_rights_check": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_PREFLIGHT_RIGHTS_CHECK", false),
+				Description: "If set, the plan fails when the authenticated user lacks the rights needed to create or update the planned resources",
+			},
+
 			"allow_unverified_ssl": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -412,6 +458,42 @@ func Provider() *schema.Provider {
 				Description: "If set, VCDClient will permit unverifiable SSL certificates.",
 			},
 
+			"ca_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_CA_FILE", ""),
+				Description: "Path to a PEM file with the CA certificates used to verify the VCD endpoint, in addition to the system ones",
+			},
+
+			"ca_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_CA_PEM", ""),
+				Description: "PEM-encoded CA certificates used to verify the VCD endpoint, in addition to the system ones. Conflicts with 'ca_file'",
+			},
+
+			"client_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_CLIENT_CERT", ""),
+				Description: "Client certificate for mutual TLS, either PEM-encoded or as a path to a PEM file (requires 'client_key')",
+			},
+
+			"client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_CLIENT_KEY", ""),
+				Description: "Private key of the client certificate, either PEM-encoded or as a path to a PEM file (requires 'client_cert')",
+			},
+
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_PROXY_URL", ""),
+				Description: "URL of the HTTP(S) proxy used to reach VCD. When empty, the proxy is taken from the HTTPS_PROXY and NO_PROXY environment variables",
+			},
+
 			"logging": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -431,9 +513,22 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("VCD_IMPORT_SEPARATOR", "."),
 				Description: "Defines the import separation string to be used with 'terraform import'",
 			},
+			"enable_read_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_ENABLE_READ_CACHE", false),
+				Description: "If set, Orgs, VDCs and NSX-T Edge Gateways retrieved while reading resources are cached for 'read_cache_ttl' seconds",
+			},
+			"read_cache_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VCD_READ_CACHE_TTL", 300),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "How many seconds the objects stay in the read cache, when 'enable_read_cache' is set (defaults to 300)",
+			},
 			"ignore_metadata_changes": ignoreMetadataSchema(),
 		},
-		ResourcesMap:         globalResourceMap,
+		ResourcesMap:         checkRightsOnPlan(limitConcurrentOperations(trackReadCacheWrites(globalResourceMap))),
 		DataSourcesMap:       globalDataSourceMap,
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -468,6 +563,14 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		Href:                    d.Get("url").(string),
 		MaxRetryTimeout:         maxRetryTimeout,
 		InsecureFlag:            d.Get("allow_unverified_ssl").(bool),
+		CaFile:                  d.Get("ca_file").(string),
+		CaPem:                   d.Get("ca_pem").(string),
+		ClientCert:              d.Get("client_cert").(string),
+		ClientKey:               d.Get("client_key").(string),
+		ProxyUrl:                d.Get("proxy_url").(string),
+		MaxRequestsPerSecond:    d.Get("max_requests_per_second").(int),
+		MaxConcurrentOperations: d.Get("max_concurrent_operations").(int),
+		PreflightRightsCheck:    d.Get("preflight_rights_check").(bool),
 	}
 
 	// auth_type dependent configuration
@@ -556,6 +659,10 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		IgnoreMetadataChangesConflictActions[im.IgnoredMetadata.String()] = ignoredMetadata[i].ConflictAction
 	}
 
+	if d.Get("enable_read_cache").(bool) {
+		config.ReadCacheTTL = time.Duration(d.Get("read_cache_ttl").(int)) * time.Second
+	}
+
 	vcdClient, err := config.Client()
 	if err != nil {
 		return nil, diag.FromErr(err)
@@ -614,5 +721,12 @@ func validateProviderSchema(d *schema.ResourceData) error {
 		return fmt.Errorf(`both "org" and "sysorg" properties are empty`)
 	}
 
+	if d.Get("ca_file").(string) != "" && d.Get("ca_pem").(string) != "" {
+		return fmt.Errorf(`only one of "ca_file" and "ca_pem" can be set`)
+	}
+	if (d.Get("client_cert").(string) == "") != (d.Get("client_key").(string) == "") {
+		return fmt.Errorf(`"client_cert" and "client_key" must be set together`)
+	}
+
 	return nil
 }
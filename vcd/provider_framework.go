@@ -0,0 +1,308 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The provider is served as the combination (mux) of two providers:
+//   - the SDK v2 provider returned by Provider(), which contains all the resources and data sources defined
+//     with schema.Resource
+//   - the Plugin Framework provider vcdFrameworkProvider, where new resources can be written using the features
+//     that are only available in the framework
+//
+// Both providers share the same configuration: the framework provider schema is generated from the SDK v2 one, and
+// its Configure method reuses the *VCDClient built by providerConfigure.
+
+// globalFrameworkResources contains the resources implemented with the Plugin Framework
+var globalFrameworkResources = []func() resource.Resource{}
+
+// globalFrameworkDataSources contains the data sources implemented with the Plugin Framework
+var globalFrameworkDataSources = []func() datasource.DataSource{}
+
+// globalFrameworkEphemeralResources contains the ephemeral resources, which are only supported by the Plugin Framework
+var globalFrameworkEphemeralResources = []func() ephemeral.EphemeralResource{
+	newEphemeralVcdApiToken,      // 3.14
+	newEphemeralVcdSessionToken,  // 3.14
+	newEphemeralVcdCseKubeconfig, // 3.14
+}
+
+// globalFrameworkFunctions contains the provider functions, which are only supported by the Plugin Framework
+var globalFrameworkFunctions = []func() function.Function{
+	newFunctionUrnToUuid,       // 3.14
+	newFunctionUuidToUrn,       // 3.14
+	newFunctionHrefToId,        // 3.14
+	newFunctionIpRangeContains, // 3.14
+}
+
+// NewMuxProviderServer returns the function that creates the provider server, combining the SDK v2 provider
+// with the Plugin Framework one
+func NewMuxProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return muxProviderServer(ctx, Provider())
+}
+
+// muxProviderServer combines the given SDK v2 provider with a Plugin Framework provider that shares its configuration
+func muxProviderServer(ctx context.Context, sdkProvider *schema.Provider) (func() tfprotov5.ProviderServer, error) {
+	frameworkProvider, err := newFrameworkProvider(ctx, sdkProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(frameworkProvider),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error combining SDK and Plugin Framework providers: %s", err)
+	}
+	return muxServer.ProviderServer, nil
+}
+
+var (
+	_ provider.ProviderWithEphemeralResources = &vcdFrameworkProvider{}
+	_ provider.ProviderWithFunctions          = &vcdFrameworkProvider{}
+)
+
+// vcdFrameworkProvider is the Plugin Framework half of the provider
+type vcdFrameworkProvider struct {
+	sdkProvider *schema.Provider
+	schema      providerschema.Schema
+}
+
+func newFrameworkProvider(ctx context.Context, sdkProvider *schema.Provider) (*vcdFrameworkProvider, error) {
+	providerSchema, err := frameworkProviderSchema(ctx, sdkProvider)
+	if err != nil {
+		return nil, fmt.Errorf("error building Plugin Framework provider schema: %s", err)
+	}
+	return &vcdFrameworkProvider{
+		sdkProvider: sdkProvider,
+		schema:      providerSchema,
+	}, nil
+}
+
+func (p *vcdFrameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "vcd"
+}
+
+func (p *vcdFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = p.schema
+}
+
+// Configure passes the client of the SDK v2 provider to the framework resources and data sources. The mux server
+// configures the providers in the order they are given, so the SDK v2 provider has already been configured here.
+// The provider arguments are validated by the SDK v2 provider, which also reports any configuration error
+func (p *vcdFrameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	meta := p.sdkProvider.Meta()
+	if meta == nil {
+		return
+	}
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider configuration",
+			fmt.Sprintf("expected *VCDClient from the SDK provider, got %T", meta))
+		return
+	}
+	resp.ResourceData = vcdClient
+	resp.DataSourceData = vcdClient
+	resp.EphemeralResourceData = vcdClient
+}
+
+func (p *vcdFrameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return globalFrameworkResources
+}
+
+func (p *vcdFrameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return globalFrameworkDataSources
+}
+
+func (p *vcdFrameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return globalFrameworkEphemeralResources
+}
+
+func (p *vcdFrameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return globalFrameworkFunctions
+}
+
+// frameworkVcdClient returns the client passed by the provider to the Configure method of framework resources.
+// It returns nil without errors when the provider is not configured yet, which happens during validation
+func frameworkVcdClient(providerData any, diagnostics *diag.Diagnostics) *VCDClient {
+	if providerData == nil {
+		return nil
+	}
+	vcdClient, ok := providerData.(*VCDClient)
+	if !ok {
+		diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("expected *VCDClient, got %T", providerData))
+		return nil
+	}
+	return vcdClient
+}
+
+// frameworkProviderSchema builds the Plugin Framework provider schema from the SDK v2 one. The mux server requires
+// the provider schemas of both halves to be identical, so the conversion starts from the protocol schema that the
+// SDK v2 provider sends to Terraform
+func frameworkProviderSchema(ctx context.Context, sdkProvider *schema.Provider) (providerschema.Schema, error) {
+	resp, err := schema.NewGRPCProviderServer(sdkProvider).GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return providerschema.Schema{}, err
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
+			return providerschema.Schema{}, fmt.Errorf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+	if resp.Provider == nil || resp.Provider.Block == nil {
+		return providerschema.Schema{}, fmt.Errorf("the SDK provider has no schema")
+	}
+
+	attributes, blocks, err := frameworkSchemaElements(resp.Provider.Block, sdkProvider.Schema)
+	if err != nil {
+		return providerschema.Schema{}, err
+	}
+	return providerschema.Schema{
+		Description: resp.Provider.Block.Description,
+		Attributes:  attributes,
+		Blocks:      blocks,
+	}, nil
+}
+
+// frameworkSchemaElements converts the attributes and nested blocks of a protocol schema block. The SDK v2 schema
+// is only used to retrieve the deprecation messages, which are not part of the protocol schema
+func frameworkSchemaElements(block *tfprotov5.SchemaBlock, sdkSchema map[string]*schema.Schema) (map[string]providerschema.Attribute, map[string]providerschema.Block, error) {
+	attributes := make(map[string]providerschema.Attribute, len(block.Attributes))
+	for _, protoAttribute := range block.Attributes {
+		attribute, err := frameworkAttribute(protoAttribute, deprecationMessage(sdkSchema, protoAttribute.Name, protoAttribute.Deprecated))
+		if err != nil {
+			return nil, nil, fmt.Errorf("attribute '%s': %s", protoAttribute.Name, err)
+		}
+		attributes[protoAttribute.Name] = attribute
+	}
+
+	blocks := make(map[string]providerschema.Block, len(block.BlockTypes))
+	for _, protoBlock := range block.BlockTypes {
+		var nestedSdkSchema map[string]*schema.Schema
+		if sdkSchema[protoBlock.TypeName] != nil {
+			if nestedResource, ok := sdkSchema[protoBlock.TypeName].Elem.(*schema.Resource); ok {
+				nestedSdkSchema = nestedResource.Schema
+			}
+		}
+		nestedAttributes, nestedBlocks, err := frameworkSchemaElements(protoBlock.Block, nestedSdkSchema)
+		if err != nil {
+			return nil, nil, fmt.Errorf("block '%s': %s", protoBlock.TypeName, err)
+		}
+		nestedObject := providerschema.NestedBlockObject{Attributes: nestedAttributes, Blocks: nestedBlocks}
+		deprecation := deprecationMessage(sdkSchema, protoBlock.TypeName, protoBlock.Block.Deprecated)
+
+		switch protoBlock.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			blocks[protoBlock.TypeName] = providerschema.ListNestedBlock{
+				NestedObject:       nestedObject,
+				Description:        protoBlock.Block.Description,
+				DeprecationMessage: deprecation,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			blocks[protoBlock.TypeName] = providerschema.SetNestedBlock{
+				NestedObject:       nestedObject,
+				Description:        protoBlock.Block.Description,
+				DeprecationMessage: deprecation,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSingle:
+			blocks[protoBlock.TypeName] = providerschema.SingleNestedBlock{
+				Attributes:         nestedAttributes,
+				Blocks:             nestedBlocks,
+				Description:        protoBlock.Block.Description,
+				DeprecationMessage: deprecation,
+			}
+		default:
+			return nil, nil, fmt.Errorf("block '%s': unsupported nesting mode %s", protoBlock.TypeName, protoBlock.Nesting)
+		}
+	}
+	return attributes, blocks, nil
+}
+
+func frameworkAttribute(protoAttribute *tfprotov5.SchemaAttribute, deprecation string) (providerschema.Attribute, error) {
+	if protoAttribute.Computed {
+		return nil, fmt.Errorf("computed attributes are not supported in the provider schema")
+	}
+	required := protoAttribute.Required
+	optional := protoAttribute.Optional
+	description := protoAttribute.Description
+	sensitive := protoAttribute.Sensitive
+
+	attrType, err := frameworkAttrType(protoAttribute.Type)
+	if err != nil {
+		return nil, err
+	}
+	switch typed := attrType.(type) {
+	case types.ListType:
+		return providerschema.ListAttribute{ElementType: typed.ElemType, Required: required, Optional: optional,
+			Sensitive: sensitive, Description: description, DeprecationMessage: deprecation}, nil
+	case types.SetType:
+		return providerschema.SetAttribute{ElementType: typed.ElemType, Required: required, Optional: optional,
+			Sensitive: sensitive, Description: description, DeprecationMessage: deprecation}, nil
+	case types.MapType:
+		return providerschema.MapAttribute{ElementType: typed.ElemType, Required: required, Optional: optional,
+			Sensitive: sensitive, Description: description, DeprecationMessage: deprecation}, nil
+	}
+	switch attrType {
+	case types.StringType:
+		return providerschema.StringAttribute{Required: required, Optional: optional,
+			Sensitive: sensitive, Description: description, DeprecationMessage: deprecation}, nil
+	case types.BoolType:
+		return providerschema.BoolAttribute{Required: required, Optional: optional,
+			Sensitive: sensitive, Description: description, DeprecationMessage: deprecation}, nil
+	case types.NumberType:
+		return providerschema.NumberAttribute{Required: required, Optional: optional,
+			Sensitive: sensitive, Description: description, DeprecationMessage: deprecation}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", protoAttribute.Type)
+}
+
+// frameworkAttrType converts a protocol type into the corresponding framework type
+func frameworkAttrType(protoType tftypes.Type) (attr.Type, error) {
+	switch {
+	case protoType.Is(tftypes.String):
+		return types.StringType, nil
+	case protoType.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case protoType.Is(tftypes.Number):
+		return types.NumberType, nil
+	case protoType.Is(tftypes.List{}):
+		elemType, err := frameworkAttrType(protoType.(tftypes.List).ElementType)
+		return types.ListType{ElemType: elemType}, err
+	case protoType.Is(tftypes.Set{}):
+		elemType, err := frameworkAttrType(protoType.(tftypes.Set).ElementType)
+		return types.SetType{ElemType: elemType}, err
+	case protoType.Is(tftypes.Map{}):
+		elemType, err := frameworkAttrType(protoType.(tftypes.Map).ElementType)
+		return types.MapType{ElemType: elemType}, err
+	}
+	return nil, fmt.Errorf("unsupported type %s", protoType)
+}
+
+// deprecationMessage returns the deprecation message of an SDK v2 field, with a generic message when the field is
+// deprecated in the protocol schema but can't be found
+func deprecationMessage(sdkSchema map[string]*schema.Schema, name string, deprecated bool) string {
+	if !deprecated {
+		return ""
+	}
+	if field, ok := sdkSchema[name]; ok && field.Deprecated != "" {
+		return field.Deprecated
+	}
+	return "This field is deprecated"
+}
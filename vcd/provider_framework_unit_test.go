@@ -0,0 +1,86 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Test_muxProviderServer checks that the SDK v2 and the Plugin Framework providers can be combined, which requires
+// identical provider schemas
+func Test_muxProviderServer(t *testing.T) {
+	ctx := context.Background()
+	sdkProvider := Provider()
+
+	serverFactory, err := muxProviderServer(ctx, sdkProvider)
+	if err != nil {
+		t.Fatalf("error creating mux server: %s", err)
+	}
+
+	resp, err := serverFactory().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("error getting provider schema: %s", err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected error diagnostic: %s - %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+	if resp.Provider == nil {
+		t.Fatalf("no provider schema returned")
+	}
+	if len(resp.Provider.Block.Attributes) != len(sdkProvider.Schema)-len(resp.Provider.Block.BlockTypes) {
+		t.Errorf("expected %d attributes, got %d", len(sdkProvider.Schema)-len(resp.Provider.Block.BlockTypes), len(resp.Provider.Block.Attributes))
+	}
+	for name := range sdkProvider.ResourcesMap {
+		if _, ok := resp.ResourceSchemas[name]; !ok {
+			t.Errorf("resource %s not found in the combined provider", name)
+		}
+	}
+	for name := range sdkProvider.DataSourcesMap {
+		if _, ok := resp.DataSourceSchemas[name]; !ok {
+			t.Errorf("data source %s not found in the combined provider", name)
+		}
+	}
+	if len(resp.EphemeralResourceSchemas) != len(globalFrameworkEphemeralResources) {
+		t.Errorf("expected %d ephemeral resources, got %d", len(globalFrameworkEphemeralResources), len(resp.EphemeralResourceSchemas))
+	}
+	if len(resp.Functions) != len(globalFrameworkFunctions) {
+		t.Errorf("expected %d functions, got %d", len(globalFrameworkFunctions), len(resp.Functions))
+	}
+}
+
+func Test_frameworkAttrType(t *testing.T) {
+	tests := []struct {
+		protoType tftypes.Type
+		expected  string
+		wantErr   bool
+	}{
+		{protoType: tftypes.String, expected: types.StringType.String()},
+		{protoType: tftypes.Bool, expected: types.BoolType.String()},
+		{protoType: tftypes.Number, expected: types.NumberType.String()},
+		{protoType: tftypes.List{ElementType: tftypes.String}, expected: types.ListType{ElemType: types.StringType}.String()},
+		{protoType: tftypes.Set{ElementType: tftypes.Number}, expected: types.SetType{ElemType: types.NumberType}.String()},
+		{protoType: tftypes.Map{ElementType: tftypes.Bool}, expected: types.MapType{ElemType: types.BoolType}.String()},
+		{protoType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"a": tftypes.String}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.protoType.String(), func(t *testing.T) {
+			got, err := frameworkAttrType(tt.protoType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("frameworkAttrType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.expected {
+				t.Errorf("frameworkAttrType() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -26,6 +27,9 @@ var testAccProvider *schema.Provider
 // testAccProviders used in field ProviderFactories required for test runs in SDK 2.x
 var testAccProviders map[string]func() (*schema.Provider, error)
 
+// testAccMuxProviders used in field ProtoV5ProviderFactories for tests of resources implemented with the Plugin Framework
+var testAccMuxProviders map[string]func() (tfprotov5.ProviderServer, error)
+
 func TestProvider(t *testing.T) {
 	// Do not add pre and post checks
 	if err := Provider().InternalValidate(); err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v2/govcd"
@@ -21,6 +22,9 @@ func resourceVcdCatalogItem() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdCatalogItemImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -110,9 +114,9 @@ func resourceVcdCatalogItemCreate(ctx context.Context, d *schema.ResourceData, m
 	var diagError diag.Diagnostics
 	itemName := d.Get("name").(string)
 	if d.Get("ova_path").(string) != "" {
-		diagError = uploadOvaFromFilePath(d, catalog, itemName, "vcd_catalog_item")
+		diagError = uploadOvaFromFilePath(ctx, d, catalog, itemName, "vcd_catalog_item")
 	} else if d.Get("ovf_url").(string) != "" {
-		diagError = uploadFromUrl(d, catalog, itemName, "vcd_catalog_item")
+		diagError = uploadFromUrl(ctx, d, catalog, itemName, "vcd_catalog_item")
 	} else {
 		return diag.Errorf("`ova_path` or `ovf_url` value is missing %s", err)
 	}
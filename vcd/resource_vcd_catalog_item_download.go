@@ -0,0 +1,630 @@
+package vcd
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// ovfFileReferences contains the files referenced by an OVF descriptor
+type ovfFileReferences struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Files   []struct {
+		Href string `xml:"href,attr"`
+		Size int64  `xml:"size,attr"`
+	} `xml:"References>File"`
+}
+
+// catalogDownloadChecksumAlgorithms maps the supported checksum algorithms to their hash constructors
+var catalogDownloadChecksumAlgorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// catalogSourceChecksumAlgorithms maps the length of a hex encoded checksum to the algorithm that produces it.
+// VCD reports the checksums of the files of a catalog item without their algorithm
+var catalogSourceChecksumAlgorithms = map[int]func() hash.Hash{
+	32:  md5.New,
+	40:  sha1.New,
+	64:  sha256.New,
+	128: sha512.New,
+}
+
+func resourceVcdCatalogItemDownload() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdCatalogItemDownloadCreate,
+		ReadContext:   resourceVcdCatalogItemDownloadRead,
+		UpdateContext: resourceVcdCatalogItemDownloadUpdate,
+		DeleteContext: resourceVcdCatalogItemDownloadDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"vapp_template_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "ID of the vApp template to download",
+				ExactlyOneOf: []string{"vapp_template_id", "media_id"},
+			},
+			"media_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "ID of the media item to download",
+				ExactlyOneOf: []string{"vapp_template_id", "media_id"},
+			},
+			"download_path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "Local path where the item is saved. For vApp templates with format 'ovf', " +
+					"this is the directory that will contain the descriptor, the manifest and the disk files",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "ova",
+				ValidateFunc: validation.StringInSlice([]string{"ova", "ovf"}, false),
+				Description:  "Format of a downloaded vApp template. One of 'ova' (single file) or 'ovf' (directory). Ignored for media items",
+			},
+			"checksum_algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "sha256",
+				ValidateFunc: validation.StringInSlice([]string{"sha1", "sha256", "sha512"}, false),
+				Description:  "Algorithm used to compute the 'checksum' attribute and the generated OVF manifest. One of 'sha1', 'sha256', 'sha512'",
+			},
+			"expected_checksum": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "If set, the download fails when the computed 'checksum' is different from this value",
+			},
+			"overwrite": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether to overwrite existing files in 'download_path'",
+			},
+			"remove_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to remove the downloaded files when the resource is destroyed",
+			},
+			"item_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the downloaded vApp template or media item",
+			},
+			"checksum": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Checksum of the downloaded file, computed locally. For vApp templates with format 'ovf', " +
+					"it is the checksum of the generated manifest, which contains the checksums of all the other files",
+			},
+			"source_verified": {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Whether every downloaded file was verified against a checksum reported by VCD. " +
+					"When false, only the file sizes were verified for the files without a checksum",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size in bytes of the downloaded file. For vApp templates with format 'ovf', it is the total size of the files",
+			},
+			"files": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Local paths of the files written by the download",
+			},
+		},
+	}
+}
+
+func resourceVcdCatalogItemDownloadCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	downloadPath := d.Get("download_path").(string)
+	if _, err := os.Stat(downloadPath); err == nil && !d.Get("overwrite").(bool) {
+		return diag.Errorf("[catalog item download] path '%s' already exists. Set 'overwrite = true' to replace it", downloadPath)
+	}
+
+	var result *catalogDownloadResult
+	var err error
+	if vAppTemplateId := d.Get("vapp_template_id").(string); vAppTemplateId != "" {
+		result, err = downloadVAppTemplate(ctx, vcdClient, vAppTemplateId, d)
+		if err == nil {
+			d.SetId(vAppTemplateId)
+		}
+	} else {
+		result, err = downloadMedia(ctx, vcdClient, d.Get("media_id").(string), d)
+		if err == nil {
+			d.SetId(d.Get("media_id").(string))
+		}
+	}
+	if err != nil {
+		if result != nil {
+			removeDownloadedFiles(result.files)
+		}
+		return diag.Errorf("[catalog item download] %s", err)
+	}
+
+	expectedChecksum := d.Get("expected_checksum").(string)
+	if expectedChecksum != "" && !strings.EqualFold(expectedChecksum, result.checksum) {
+		removeDownloadedFiles(result.files)
+		d.SetId("")
+		return diag.Errorf("[catalog item download] checksum mismatch for '%s': expected %s, got %s", result.itemName, expectedChecksum, result.checksum)
+	}
+
+	dSet(d, "item_name", result.itemName)
+	dSet(d, "checksum", result.checksum)
+	dSet(d, "size", result.size)
+	dSet(d, "source_verified", result.sourceVerified)
+	err = d.Set("files", result.files)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceVcdCatalogItemDownloadRead(ctx, d, meta)
+}
+
+// resourceVcdCatalogItemDownloadRead checks that the downloaded files are still in place. When one of them is missing
+// or has a different size, the resource is removed from state, so that the next apply downloads the item again
+func resourceVcdCatalogItemDownloadRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	var totalSize int64
+	for _, file := range d.Get("files").(*schema.Set).List() {
+		info, err := os.Stat(file.(string))
+		if err != nil {
+			log.Printf("[DEBUG] downloaded file '%s' is not available anymore. Removing resource from state: %s", file, err)
+			d.SetId("")
+			return nil
+		}
+		totalSize += info.Size()
+	}
+
+	// The OVA and ISO files are the only ones written, so their size must match exactly
+	if d.Get("format").(string) == "ova" || d.Get("media_id").(string) != "" {
+		if totalSize != int64(d.Get("size").(int)) {
+			log.Printf("[DEBUG] downloaded file '%s' has changed size. Removing resource from state", d.Get("download_path").(string))
+			d.SetId("")
+		}
+	}
+	return nil
+}
+
+// resourceVcdCatalogItemDownloadUpdate only handles "remove_on_destroy", which does not require any action
+func resourceVcdCatalogItemDownloadUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVcdCatalogItemDownloadRead(ctx, d, meta)
+}
+
+func resourceVcdCatalogItemDownloadDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	if !d.Get("remove_on_destroy").(bool) {
+		return nil
+	}
+	var files []string
+	for _, file := range d.Get("files").(*schema.Set).List() {
+		files = append(files, file.(string))
+	}
+	removeDownloadedFiles(files)
+	return nil
+}
+
+// catalogDownloadResult contains the outcome of a catalog item download
+type catalogDownloadResult struct {
+	itemName       string
+	checksum       string
+	size           int64
+	files          []string
+	sourceVerified bool
+}
+
+// downloadVAppTemplate enables the download of a vApp template and saves its descriptor, a manifest and the disk files,
+// either in a directory (format "ovf") or in a single OVA file (format "ova")
+func downloadVAppTemplate(ctx context.Context, vcdClient *VCDClient, vAppTemplateId string, d *schema.ResourceData) (*catalogDownloadResult, error) {
+	vAppTemplate, err := vcdClient.GetVAppTemplateById(vAppTemplateId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving vApp template '%s': %s", vAppTemplateId, err)
+	}
+	templateHref := vAppTemplate.VAppTemplate.HREF
+
+	task, err := vcdClient.Client.ExecuteTaskRequest(templateHref+"/action/enableDownload", http.MethodPost, "",
+		"error enabling download of vApp template: %s", nil)
+	if err == nil {
+		err = waitTaskCompletionWithContext(ctx, task)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := vcdClient.Client.ExecuteRequestWithoutResponse(templateHref+"/action/disableDownload", http.MethodPost, "",
+			"error disabling download of vApp template: %s", nil)
+		if err != nil {
+			log.Printf("[WARN] %s", err)
+		}
+	}()
+
+	err = vAppTemplate.Refresh()
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing vApp template: %s", err)
+	}
+	descriptorLink := vAppTemplate.VAppTemplate.Link.Find(func(link *types.Link) bool {
+		return link.Rel == types.RelDownloadDefault
+	})
+	if descriptorLink == nil {
+		return nil, fmt.Errorf("no download link found for vApp template '%s'", vAppTemplate.VAppTemplate.Name)
+	}
+	descriptorUrl, err := url.ParseRequestURI(descriptorLink.HREF)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing descriptor URL: %s", err)
+	}
+	sourceFiles := make(map[string]*types.File)
+	if vAppTemplate.VAppTemplate.Files != nil {
+		for _, file := range vAppTemplate.VAppTemplate.Files.File {
+			sourceFiles[file.Name] = file
+		}
+	}
+
+	downloadPath := d.Get("download_path").(string)
+	format := d.Get("format").(string)
+	newHash := catalogDownloadChecksumAlgorithms[d.Get("checksum_algorithm").(string)]
+	result := &catalogDownloadResult{itemName: vAppTemplate.VAppTemplate.Name}
+
+	// With format "ovf" the files are saved directly in the download path, otherwise in a temporary directory
+	// that is removed once the OVA is built
+	workDir := downloadPath
+	if format == "ova" {
+		workDir, err = os.MkdirTemp(filepath.Dir(downloadPath), ".vcd-download-")
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = os.RemoveAll(workDir)
+		}()
+	} else {
+		err = os.MkdirAll(workDir, 0750)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	baseName := strings.TrimSuffix(filepath.Base(downloadPath), filepath.Ext(downloadPath))
+	descriptorName := baseName + ".ovf"
+	manifestName := baseName + ".mf"
+	var written []string
+	var manifest strings.Builder
+	result.sourceVerified = true
+
+	// addFile downloads the file at href as "name". The file is verified against the size declared by the descriptor
+	// and against the size and checksum that VCD reports for "sourceName"
+	addFile := func(href, sourceName, name string, expectedSize int64) error {
+		localPath := filepath.Join(workDir, name)
+		written = append(written, localPath)
+		fileHash := newHash()
+		sourceFile := sourceFiles[sourceName]
+		var sourceHash hash.Hash
+		if sourceFile != nil {
+			sourceHash = sourceChecksumHash(sourceFile.Checksum)
+		}
+		size, err := downloadCatalogFile(ctx, &vcdClient.Client, href, localPath, fileHash, sourceHash)
+		if err != nil {
+			return err
+		}
+		if expectedSize > 0 && size != expectedSize {
+			return fmt.Errorf("file '%s' has size %d, but the descriptor declares %d", name, size, expectedSize)
+		}
+		verified, err := verifySourceFile(sourceName, sourceFile, size, sourceHash)
+		if err != nil {
+			return err
+		}
+		result.sourceVerified = result.sourceVerified && verified
+		manifest.WriteString(fmt.Sprintf("%s(%s)= %s\n", strings.ToUpper(d.Get("checksum_algorithm").(string)), name, hex.EncodeToString(fileHash.Sum(nil))))
+		return nil
+	}
+
+	err = addFile(descriptorUrl.String(), filepath.Base(descriptorUrl.Path), descriptorName, 0)
+	if err != nil {
+		return catalogDownloadResultForCleanup(result, written, format), err
+	}
+	descriptor, err := os.ReadFile(filepath.Clean(filepath.Join(workDir, descriptorName)))
+	if err != nil {
+		return catalogDownloadResultForCleanup(result, written, format), err
+	}
+	var references ovfFileReferences
+	err = xml.Unmarshal(descriptor, &references)
+	if err != nil {
+		return catalogDownloadResultForCleanup(result, written, format), fmt.Errorf("error parsing OVF descriptor: %s", err)
+	}
+
+	fileNames := []string{descriptorName, manifestName}
+	for _, file := range references.Files {
+		fileUrl, err := descriptorUrl.Parse(file.Href)
+		if err != nil {
+			return catalogDownloadResultForCleanup(result, written, format), fmt.Errorf("error parsing URL of file '%s': %s", file.Href, err)
+		}
+		// Only the base name is used, to prevent the descriptor from writing outside the work directory
+		name := filepath.Base(file.Href)
+		err = addFile(fileUrl.String(), name, name, file.Size)
+		if err != nil {
+			return catalogDownloadResultForCleanup(result, written, format), err
+		}
+		fileNames = append(fileNames, name)
+	}
+
+	manifestPath := filepath.Join(workDir, manifestName)
+	written = append(written, manifestPath)
+	err = os.WriteFile(manifestPath, []byte(manifest.String()), 0600)
+	if err != nil {
+		return catalogDownloadResultForCleanup(result, written, format), err
+	}
+
+	if format == "ovf" {
+		result.files = written
+		for _, file := range written {
+			info, err := os.Stat(file)
+			if err != nil {
+				return result, err
+			}
+			result.size += info.Size()
+		}
+		manifestHash := newHash()
+		manifestHash.Write([]byte(manifest.String()))
+		result.checksum = hex.EncodeToString(manifestHash.Sum(nil))
+		return result, nil
+	}
+
+	result.files = []string{downloadPath}
+	result.size, result.checksum, err = writeOva(downloadPath, workDir, fileNames, newHash())
+	return result, err
+}
+
+// catalogDownloadResultForCleanup returns the files that must be removed after a failed download.
+// With format "ova" the partial files are in a temporary directory, which is removed anyway
+func catalogDownloadResultForCleanup(result *catalogDownloadResult, written []string, format string) *catalogDownloadResult {
+	if format == "ovf" {
+		result.files = written
+	}
+	return result
+}
+
+// writeOva creates an OVA archive at ovaPath with the given files from sourceDir, in the given order.
+// The OVF specification requires the descriptor to be the first file and the manifest to follow it.
+// It returns the size and checksum of the archive
+func writeOva(ovaPath, sourceDir string, fileNames []string, ovaHash hash.Hash) (int64, string, error) {
+	ovaFile, err := os.Create(filepath.Clean(ovaPath))
+	if err != nil {
+		return 0, "", err
+	}
+	counter := &countingWriter{}
+	tarWriter := tar.NewWriter(io.MultiWriter(ovaFile, ovaHash, counter))
+
+	err = func() error {
+		for _, name := range fileNames {
+			localPath := filepath.Join(sourceDir, name)
+			info, err := os.Stat(localPath)
+			if err != nil {
+				return err
+			}
+			err = tarWriter.WriteHeader(&tar.Header{
+				Name:    name,
+				Mode:    0644,
+				Size:    info.Size(),
+				ModTime: info.ModTime(),
+				Format:  tar.FormatUSTAR,
+			})
+			if err != nil {
+				return err
+			}
+			file, err := os.Open(filepath.Clean(localPath))
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(tarWriter, file)
+			_ = file.Close()
+			if err != nil {
+				return err
+			}
+		}
+		return tarWriter.Close()
+	}()
+	closeErr := ovaFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		removeDownloadedFiles([]string{ovaPath})
+		return 0, "", fmt.Errorf("error creating OVA file '%s': %s", ovaPath, err)
+	}
+	return counter.written, hex.EncodeToString(ovaHash.Sum(nil)), nil
+}
+
+// downloadMedia enables the download of a media item and saves it to "download_path"
+func downloadMedia(ctx context.Context, vcdClient *VCDClient, mediaId string, d *schema.ResourceData) (*catalogDownloadResult, error) {
+	mediaRecord, err := vcdClient.QueryMediaById(mediaId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving media '%s': %s", mediaId, err)
+	}
+	media := govcd.NewMedia(&vcdClient.Client)
+	media.Media.HREF = mediaRecord.MediaRecord.HREF
+	err = media.Refresh()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving media '%s': %s", mediaRecord.MediaRecord.Name, err)
+	}
+
+	enableLink := media.Media.Link.Find(func(link *types.Link) bool {
+		return link.Rel == types.RelEnable
+	})
+	if enableLink == nil {
+		return nil, fmt.Errorf("media '%s' can't be downloaded: no enable link found", media.Media.Name)
+	}
+	task, err := vcdClient.Client.ExecuteTaskRequest(enableLink.HREF, http.MethodPost, "", "error enabling download of media: %s", nil)
+	if err == nil {
+		err = waitTaskCompletionWithContext(ctx, task)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = media.Refresh()
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing media '%s': %s", media.Media.Name, err)
+	}
+	downloadHref := ""
+	var sourceFile *types.File
+	if media.Media.Files != nil {
+		for _, file := range media.Media.Files.File {
+			if link := file.Link.Find(func(link *types.Link) bool { return link.Rel == types.RelDownloadDefault }); link != nil {
+				downloadHref = link.HREF
+				sourceFile = file
+				break
+			}
+		}
+	}
+	if downloadHref == "" {
+		return nil, fmt.Errorf("no download link found for media '%s'", media.Media.Name)
+	}
+
+	downloadPath := d.Get("download_path").(string)
+	result := &catalogDownloadResult{
+		itemName: media.Media.Name,
+		files:    []string{downloadPath},
+	}
+	mediaHash := catalogDownloadChecksumAlgorithms[d.Get("checksum_algorithm").(string)]()
+	sourceHash := sourceChecksumHash(sourceFile.Checksum)
+	result.size, err = downloadCatalogFile(ctx, &vcdClient.Client, downloadHref, downloadPath, mediaHash, sourceHash)
+	if err != nil {
+		return result, err
+	}
+	if media.Media.Size > 0 && result.size != media.Media.Size {
+		return result, fmt.Errorf("downloaded %d bytes, but media '%s' has size %d", result.size, media.Media.Name, media.Media.Size)
+	}
+	result.sourceVerified, err = verifySourceFile(media.Media.Name, sourceFile, result.size, sourceHash)
+	if err != nil {
+		return result, err
+	}
+	result.checksum = hex.EncodeToString(mediaHash.Sum(nil))
+	return result, nil
+}
+
+// downloadCatalogFile streams the file at href to localPath, feeding the given hashes at the same time.
+// Nil hashes are ignored. It returns the number of bytes written
+func downloadCatalogFile(ctx context.Context, client *govcd.Client, href, localPath string, fileHashes ...hash.Hash) (int64, error) {
+	downloadUrl, err := url.ParseRequestURI(href)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing download URL '%s': %s", href, err)
+	}
+	request := client.NewRequest(map[string]string{}, http.MethodGet, *downloadUrl, nil).WithContext(ctx)
+	response, err := client.Http.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("error downloading '%s': %s", href, err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("error downloading '%s': %s", href, response.Status)
+	}
+
+	file, err := os.Create(filepath.Clean(localPath))
+	if err != nil {
+		return 0, err
+	}
+	writers := []io.Writer{file}
+	for _, fileHash := range fileHashes {
+		if fileHash != nil {
+			writers = append(writers, fileHash)
+		}
+	}
+	written, err := io.Copy(io.MultiWriter(writers...), response.Body)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return written, fmt.Errorf("error writing '%s': %s", localPath, err)
+	}
+	return written, nil
+}
+
+// sourceChecksumHash returns the hash that verifies a checksum reported by VCD, or nil when the checksum is empty or
+// its algorithm can't be inferred from its length
+func sourceChecksumHash(checksum string) hash.Hash {
+	if _, err := hex.DecodeString(checksum); err != nil {
+		return nil
+	}
+	newHash, ok := catalogSourceChecksumAlgorithms[len(checksum)]
+	if !ok {
+		return nil
+	}
+	return newHash()
+}
+
+// verifySourceFile compares a downloaded file with the size and checksum that VCD reports for it, when available.
+// sourceHash must be the hash returned by sourceChecksumHash for the checksum of sourceFile, fed with the file content.
+// It returns whether the checksum was verified
+func verifySourceFile(name string, sourceFile *types.File, size int64, sourceHash hash.Hash) (bool, error) {
+	if sourceFile == nil {
+		log.Printf("[DEBUG] VCD doesn't report any information about file '%s'. It can't be verified", name)
+		return false, nil
+	}
+	if sourceFile.Size > 0 && size != sourceFile.Size {
+		return false, fmt.Errorf("file '%s' has size %d, but VCD reports %d", name, size, sourceFile.Size)
+	}
+	if sourceHash == nil {
+		log.Printf("[DEBUG] VCD doesn't report a known checksum for file '%s'. Only its size was verified", name)
+		return false, nil
+	}
+	checksum := hex.EncodeToString(sourceHash.Sum(nil))
+	if !strings.EqualFold(checksum, sourceFile.Checksum) {
+		return false, fmt.Errorf("checksum mismatch for file '%s': VCD reports %s, got %s", name, sourceFile.Checksum, checksum)
+	}
+	return true, nil
+}
+
+// removeDownloadedFiles removes the given files, ignoring the ones that don't exist
+func removeDownloadedFiles(files []string) {
+	for _, file := range files {
+		err := os.Remove(file)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("[WARN] error removing downloaded file '%s': %s", file, err)
+		}
+	}
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	written int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.written += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,114 @@
+//go:build catalog || ALL || functional
+
+package vcd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVcdCatalogItemDownload(t *testing.T) {
+	preTestChecks(t)
+	if testConfig.Media.MediaName == "" {
+		t.Skip("`MediaName` is not configured")
+	}
+
+	downloadDir := t.TempDir()
+	var params = StringMap{
+		"Org":          testConfig.VCD.Org,
+		"Catalog":      testSuiteCatalogName,
+		"CatalogItem":  testSuiteCatalogOVAItem,
+		"CatalogMedia": testConfig.Media.MediaName,
+		"OvaPath":      filepath.Join(downloadDir, "template.ova"),
+		"IsoPath":      filepath.Join(downloadDir, "media.iso"),
+		"FuncName":     t.Name(),
+		"Tags":         "catalog",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdCatalogItemDownload, params)
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckCatalogItemDownloadRemoved(params["OvaPath"].(string), params["IsoPath"].(string)),
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_catalog_item_download.template", "item_name", testSuiteCatalogOVAItem),
+					resource.TestMatchResourceAttr("vcd_catalog_item_download.template", "checksum", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestCheckResourceAttr("vcd_catalog_item_download.template", "files.#", "1"),
+					resource.TestCheckResourceAttr("vcd_catalog_item_download.media", "item_name", testConfig.Media.MediaName),
+					resource.TestMatchResourceAttr("vcd_catalog_item_download.media", "checksum", regexp.MustCompile(`^[0-9a-f]{40}$`)),
+					resource.TestCheckResourceAttrPair("vcd_catalog_item_download.media", "size", "data.vcd_catalog_media.media", "size"),
+					testAccCheckFileExists(params["OvaPath"].(string)),
+					testAccCheckFileExists(params["IsoPath"].(string)),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+func testAccCheckFileExists(path string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if !fileExists(path) {
+			return fmt.Errorf("file '%s' not found", path)
+		}
+		return nil
+	}
+}
+
+func testAccCheckCatalogItemDownloadRemoved(paths ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		for _, path := range paths {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("file '%s' was not removed on destroy", path)
+			}
+		}
+		return nil
+	}
+}
+
+const testAccVcdCatalogItemDownload = `
+data "vcd_catalog" "catalog" {
+  org  = "{{.Org}}"
+  name = "{{.Catalog}}"
+}
+
+data "vcd_catalog_vapp_template" "template" {
+  org        = "{{.Org}}"
+  catalog_id = data.vcd_catalog.catalog.id
+  name       = "{{.CatalogItem}}"
+}
+
+data "vcd_catalog_media" "media" {
+  org     = "{{.Org}}"
+  catalog = data.vcd_catalog.catalog.name
+  name    = "{{.CatalogMedia}}"
+}
+
+resource "vcd_catalog_item_download" "template" {
+  vapp_template_id  = data.vcd_catalog_vapp_template.template.id
+  download_path     = "{{.OvaPath}}"
+  remove_on_destroy = true
+}
+
+resource "vcd_catalog_item_download" "media" {
+  media_id           = data.vcd_catalog_media.media.id
+  download_path      = "{{.IsoPath}}"
+  checksum_algorithm = "sha1"
+  remove_on_destroy  = true
+}
+`
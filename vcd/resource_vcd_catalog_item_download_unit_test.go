@@ -0,0 +1,158 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"archive/tar"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Test_writeOva checks that the OVA archive keeps the order of the files and that its checksum is
+// computed on the archive content
+func Test_writeOva(t *testing.T) {
+	sourceDir := t.TempDir()
+	files := map[string]string{
+		"template.ovf": "<Envelope/>",
+		"template.mf":  "SHA256(template.ovf)= abc\n",
+		"disk1.vmdk":   "disk content",
+	}
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(sourceDir, name), []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	order := []string{"template.ovf", "template.mf", "disk1.vmdk"}
+	ovaPath := filepath.Join(t.TempDir(), "template.ova")
+
+	size, checksum, err := writeOva(ovaPath, sourceDir, order, sha256.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	content, err := os.ReadFile(filepath.Clean(ovaPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != int64(len(content)) {
+		t.Errorf("expected size %d, got %d", len(content), size)
+	}
+	expectedChecksum := sha256.Sum256(content)
+	if checksum != hex.EncodeToString(expectedChecksum[:]) {
+		t.Errorf("expected checksum %x, got %s", expectedChecksum, checksum)
+	}
+
+	ova, err := os.Open(filepath.Clean(ovaPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = ova.Close()
+	}()
+	reader := tar.NewReader(ova)
+	for _, name := range order {
+		header, err := reader.Next()
+		if err != nil {
+			t.Fatalf("error reading entry for '%s': %s", name, err)
+		}
+		if header.Name != name {
+			t.Errorf("expected entry '%s', got '%s'", name, header.Name)
+		}
+		entry, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(entry) != files[name] {
+			t.Errorf("unexpected content for '%s': %s", name, entry)
+		}
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("expected end of archive, got %v", err)
+	}
+}
+
+// Test_ovfFileReferences checks that the files referenced by an OVF descriptor are found regardless of the namespace prefix
+func Test_ovfFileReferences(t *testing.T) {
+	descriptor := `<?xml version="1.0" encoding="UTF-8"?>
+<ovf:Envelope xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <ovf:References>
+    <ovf:File ovf:href="vm-disk1.vmdk" ovf:id="file1" ovf:size="1024"/>
+    <ovf:File ovf:href="vm-disk2.vmdk" ovf:id="file2"/>
+  </ovf:References>
+</ovf:Envelope>`
+
+	var references ovfFileReferences
+	err := xml.Unmarshal([]byte(descriptor), &references)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(references.Files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(references.Files))
+	}
+	if references.Files[0].Href != "vm-disk1.vmdk" || references.Files[0].Size != 1024 {
+		t.Errorf("unexpected first file: %+v", references.Files[0])
+	}
+	if references.Files[1].Href != "vm-disk2.vmdk" || references.Files[1].Size != 0 {
+		t.Errorf("unexpected second file: %+v", references.Files[1])
+	}
+}
+
+// Test_verifySourceFile checks that downloaded files are verified against the size and checksum reported by VCD,
+// inferring the algorithm from the length of the checksum
+func Test_verifySourceFile(t *testing.T) {
+	content := []byte("disk content")
+	md5Sum := md5.Sum(content)
+	sha1Sum := sha1.Sum(content)
+	sha256Sum := sha256.Sum256(content)
+	size := int64(len(content))
+
+	tests := []struct {
+		name         string
+		sourceFile   *types.File
+		wantVerified bool
+		wantErr      string
+	}{
+		{name: "no source file"},
+		{name: "size only", sourceFile: &types.File{Name: "disk", Size: size}},
+		{name: "unknown checksum length", sourceFile: &types.File{Name: "disk", Checksum: "abcd"}},
+		{name: "invalid checksum", sourceFile: &types.File{Name: "disk", Checksum: strings.Repeat("z", 64)}},
+		{name: "md5", sourceFile: &types.File{Name: "disk", Size: size, Checksum: hex.EncodeToString(md5Sum[:])}, wantVerified: true},
+		{name: "sha1", sourceFile: &types.File{Name: "disk", Checksum: hex.EncodeToString(sha1Sum[:])}, wantVerified: true},
+		{name: "sha256 upper case", sourceFile: &types.File{Name: "disk", Checksum: strings.ToUpper(hex.EncodeToString(sha256Sum[:]))}, wantVerified: true},
+		{name: "size mismatch", sourceFile: &types.File{Name: "disk", Size: size + 1}, wantErr: "VCD reports"},
+		{name: "checksum mismatch", sourceFile: &types.File{Name: "disk", Checksum: strings.Repeat("0", 64)}, wantErr: "checksum mismatch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checksum string
+			if tt.sourceFile != nil {
+				checksum = tt.sourceFile.Checksum
+			}
+			sourceHash := sourceChecksumHash(checksum)
+			if sourceHash != nil {
+				sourceHash.Write(content)
+			}
+			verified, err := verifySourceFile("disk", tt.sourceFile, size, sourceHash)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("expected error containing '%s', got: %v", tt.wantErr, err)
+			}
+			if verified != tt.wantVerified {
+				t.Errorf("expected verified %t, got %t", tt.wantVerified, verified)
+			}
+		})
+	}
+}
@@ -24,6 +24,9 @@ func resourceVcdCatalogMedia() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdCatalogMediaImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"org": {
@@ -193,6 +196,12 @@ func resourceVcdMediaCreate(ctx context.Context, d *schema.ResourceData, meta in
 			if task.GetUploadProgress() == "100.00" {
 				break
 			}
+			if ctx.Err() != nil {
+				if task.Task != nil && task.Task.Task != nil {
+					return diag.Errorf("timeout uploading media %s: %s", mediaName, cancelTimedOutTask(ctx, task.Task))
+				}
+				return diag.Errorf("timeout uploading media %s: %s", mediaName, ctx.Err())
+			}
 			time.Sleep(10 * time.Second)
 		}
 	}
@@ -212,11 +221,14 @@ func resourceVcdMediaCreate(ctx context.Context, d *schema.ResourceData, meta in
 				logForScreen("vcd_catalog_media", fmt.Sprintf("vcd_catalog_media.%s: VCD import catalog item finished with status '%s'\n", mediaName, task.Task.Task.Status))
 				break
 			}
+			if ctx.Err() != nil {
+				break
+			}
 			time.Sleep(10 * time.Second)
 		}
 	}
 
-	err = task.WaitTaskCompletion()
+	err = waitTaskCompletionWithContext(ctx, *task.Task)
 	if err != nil {
 		return diag.Errorf("error waiting for task to complete: %+v", err)
 	}
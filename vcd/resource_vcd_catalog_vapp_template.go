@@ -25,6 +25,11 @@ func resourceVcdCatalogVappTemplate() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdCatalogVappTemplateImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -182,9 +187,9 @@ func resourceVcdCatalogVappTemplateCreate(ctx context.Context, d *schema.Resourc
 
 	switch {
 	case ovaPath != "":
-		diagError = uploadOvaFromFilePath(d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
+		diagError = uploadOvaFromFilePath(ctx, d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
 	case ovfUrl != "":
-		diagError = uploadFromUrl(d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
+		diagError = uploadFromUrl(ctx, d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
 	case len(capturevAppTemplate) == 1:
 		templateCaptureSettings := capturevAppTemplate[0].(map[string]interface{})
 		sourceId := templateCaptureSettings["source_id"].(string)
@@ -411,7 +416,7 @@ func resourceVcdCatalogVappTemplateUpdate(_ context.Context, d *schema.ResourceD
 	return nil
 }
 
-func resourceVcdCatalogVappTemplateDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdCatalogVappTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	catalogId := d.Get("catalog_id").(string)
@@ -428,7 +433,10 @@ func resourceVcdCatalogVappTemplateDelete(_ context.Context, d *schema.ResourceD
 		return diag.Errorf("unable to find vApp Template with name %s", vAppTemplateName)
 	}
 
-	err = vAppTemplate.Delete()
+	task, err := vAppTemplate.DeleteAsync()
+	if err == nil {
+		err = waitTaskCompletionWithContext(ctx, task)
+	}
 	if err != nil {
 		log.Printf("[DEBUG] Error removing vApp Template %s", err)
 		return diag.Errorf("error removing vApp Template %s", err)
@@ -639,7 +647,7 @@ func checkSynchronisedVappTemplate(vcdClient *VCDClient, vAppTemplate *govcd.VAp
 }
 
 // uploadOvaFromFilePath uploads an OVA file specified in the resource to the given catalog
-func uploadOvaFromFilePath(d *schema.ResourceData, catalog *govcd.Catalog, vappTemplate, resourceName string) diag.Diagnostics {
+func uploadOvaFromFilePath(ctx context.Context, d *schema.ResourceData, catalog *govcd.Catalog, vappTemplate, resourceName string) diag.Diagnostics {
 	uploadPieceSize := d.Get("upload_piece_size").(int)
 	task, err := catalog.UploadOvf(d.Get("ova_path").(string), vappTemplate, d.Get("description").(string), int64(uploadPieceSize)*1024*1024) // Convert from megabytes to bytes
 	if err != nil {
@@ -647,20 +655,22 @@ func uploadOvaFromFilePath(d *schema.ResourceData, catalog *govcd.Catalog, vappT
 		return diag.Errorf("error uploading file: %s", err)
 	}
 
-	return finishHandlingTask(d, *task.Task, vappTemplate, resourceName)
+	return finishHandlingTask(ctx, d, *task.Task, vappTemplate, resourceName)
 }
 
-func uploadFromUrl(d *schema.ResourceData, catalog *govcd.Catalog, itemName, resourceName string) diag.Diagnostics {
+func uploadFromUrl(ctx context.Context, d *schema.ResourceData, catalog *govcd.Catalog, itemName, resourceName string) diag.Diagnostics {
 	task, err := catalog.UploadOvfByLink(d.Get("ovf_url").(string), itemName, d.Get("description").(string))
 	if err != nil {
 		log.Printf("[DEBUG] Error uploading OVF from URL: %s", err)
 		return diag.Errorf("error uploading OVF from URL: %s", err)
 	}
 
-	return finishHandlingTask(d, task, itemName, resourceName)
+	return finishHandlingTask(ctx, d, task, itemName, resourceName)
 }
 
-func finishHandlingTask(d *schema.ResourceData, task govcd.Task, itemName string, resourceName string) diag.Diagnostics {
+// finishHandlingTask waits for an upload task to complete. The wait is bounded by the deadline of ctx,
+// which comes from the resource `timeouts`
+func finishHandlingTask(ctx context.Context, d *schema.ResourceData, task govcd.Task, itemName string, resourceName string) diag.Diagnostics {
 	// This is a deprecated feature from vcd_catalog_item, to be removed with vcd_catalog_item
 	if resourceName == "vcd_catalog_item" && d.Get("show_upload_progress").(bool) {
 		for {
@@ -673,11 +683,14 @@ func finishHandlingTask(d *schema.ResourceData, task govcd.Task, itemName string
 			if progress == "100" {
 				break
 			}
+			if ctx.Err() != nil {
+				break
+			}
 			time.Sleep(10 * time.Second)
 		}
 	}
 
-	err := task.WaitTaskCompletion()
+	err := waitTaskCompletionWithContext(ctx, task)
 	if err != nil {
 		return diag.Errorf("error waiting for task to complete: %+v", err)
 	}
@@ -29,6 +29,10 @@ func resourceVcdCseKubernetesCluster() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdCseKubernetesImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+			Delete: schema.DefaultTimeout(120 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"cse_version": {
 				Type:         schema.TypeString,
@@ -307,7 +311,8 @@ func resourceVcdCseKubernetesCluster() *schema.Resource {
 				Default:  60,
 				Description: "The time, in minutes, to wait for the cluster operations to be successfully completed. For example, during cluster creation, it should be in `provisioned`" +
 					"state before the timeout is reached, otherwise the operation will return an error. For cluster deletion, this timeout" +
-					"specifies the time to wait until the cluster is completely deleted. Setting this argument to `0` means to wait indefinitely",
+					"specifies the time to wait until the cluster is completely deleted. Setting this argument to `0` means to wait until the " +
+					"`create` or `delete` timeout of the resource is reached",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 			},
 			"kubernetes_version": {
@@ -505,7 +510,7 @@ func resourceVcdCseKubernetesClusterCreate(ctx context.Context, d *schema.Resour
 		}
 	}
 
-	cluster, err := org.CseCreateKubernetesCluster(creationData, time.Duration(d.Get("operations_timeout_minutes").(int))*time.Minute)
+	cluster, err := org.CseCreateKubernetesCluster(creationData, cseOperationsTimeout(ctx, d))
 	if err != nil && cluster == nil {
 		return diag.Errorf("Kubernetes cluster creation failed: %s", err)
 	}
@@ -704,7 +709,7 @@ func resourceVcdCseKubernetesUpdate(ctx context.Context, d *schema.ResourceData,
 // the flags "markForDelete" and "forceDelete" back to true, so the CSE Server is able to delete all cluster elements
 // and perform a cleanup. Hence, this function sends an update of just these two properties and waits for the cluster RDE
 // to be gone.
-func resourceVcdCseKubernetesDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdCseKubernetesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	cluster, err := vcdClient.CseGetKubernetesClusterById(d.Id())
 	if err != nil {
@@ -713,13 +718,24 @@ func resourceVcdCseKubernetesDelete(_ context.Context, d *schema.ResourceData, m
 		}
 		return diag.FromErr(err)
 	}
-	err = cluster.Delete(time.Duration(d.Get("operations_timeout_minutes").(int)) * time.Minute)
+	err = cluster.Delete(cseOperationsTimeout(ctx, d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
 }
 
+// cseOperationsTimeout returns the time to wait for a cluster operation, which is the lowest value between
+// "operations_timeout_minutes" and the time left before the resource timeout in ctx is reached
+func cseOperationsTimeout(ctx context.Context, d *schema.ResourceData) time.Duration {
+	remaining := remainingTimeout(ctx, 0)
+	operationsTimeout := time.Duration(d.Get("operations_timeout_minutes").(int)) * time.Minute
+	if operationsTimeout == 0 || (remaining > 0 && remaining < operationsTimeout) {
+		return remaining
+	}
+	return operationsTimeout
+}
+
 func resourceVcdCseKubernetesImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 	cluster, err := vcdClient.CseGetKubernetesClusterById(d.Id())
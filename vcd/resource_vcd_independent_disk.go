@@ -25,6 +25,7 @@ func resourceVcdIndependentDisk() *schema.Resource {
 		ReadContext:   resourceVcdIndependentDiskRead,
 		UpdateContext: resourceVcdIndependentDiskUpdate,
 		DeleteContext: resourceVcdIndependentDiskDelete,
+		CustomizeDiff: independentDiskCustomizeDiff(),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdIndependentDiskImport,
 		},
@@ -53,14 +54,15 @@ func resourceVcdIndependentDisk() *schema.Resource {
 				Description: "independent disk description",
 			},
 			"storage_profile": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Storage profile of the disk. Changing it relocates the disk to the new storage profile",
 			},
 			"size_in_mb": {
 				Type:        schema.TypeInt,
 				Required:    true,
-				Description: "size in MB",
+				Description: "size in MB. It can only be increased after creation",
 			},
 			"bus_type": {
 				Type:         schema.TypeString,
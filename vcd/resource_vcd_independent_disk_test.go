@@ -723,3 +723,155 @@ func TestAccVcdIndependentDiskMetadataIgnore(t *testing.T) {
 			"StorageProfile": testConfig.VCD.NsxtProviderVdc.StorageProfile,
 		})
 }
+
+// TestAccVcdIndependentDiskMoveBetweenVms checks that an independent disk can be grown and moved from one VM to
+// another without being recreated, that it is not taken from a VM which still declares it, and that it can't be shrunk
+func TestAccVcdIndependentDiskMoveBetweenVms(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.Nsxt.Vdc,
+		"DiskName":    t.Name(),
+		"VappName":    t.Name(),
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"Size":        "1024",
+		"AttachedVm":  "vm1",
+		"Tags":        "disk",
+	}
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name() + "-step1"
+	configText1 := templateFill(testAccCheckVcdIndependentDiskMove, params)
+
+	params["FuncName"] = t.Name() + "-step2"
+	params["Size"] = "2048"
+	params["AttachedVm"] = "vm2"
+	configText2 := templateFill(testAccCheckVcdIndependentDiskMove, params)
+
+	// The disk is not removed from vm2, so it can't be taken over by vm1
+	params["FuncName"] = t.Name() + "-step4"
+	params["AttachedVm"] = "both"
+	configText4 := templateFill(testAccCheckVcdIndependentDiskMove, params)
+
+	params["FuncName"] = t.Name() + "-step5"
+	params["Size"] = "1024"
+	params["AttachedVm"] = "vm2"
+	configText5 := templateFill(testAccCheckVcdIndependentDiskMove, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText1)
+
+	cachedDiskId := &testCachedFieldValue{}
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testDiskResourcesDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeTestCheckFunc(
+					cachedDiskId.cacheTestResourceFieldValue("vcd_independent_disk.disk", "id"),
+					resource.TestCheckResourceAttr("vcd_vapp_vm.vm1", "disk.#", "1"),
+					resource.TestCheckResourceAttr("vcd_vapp_vm.vm2", "disk.#", "0"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeTestCheckFunc(
+					cachedDiskId.testCheckCachedResourceFieldValue("vcd_independent_disk.disk", "id"),
+					resource.TestCheckResourceAttr("vcd_independent_disk.disk", "size_in_mb", "2048"),
+					resource.TestCheckResourceAttr("vcd_vapp_vm.vm1", "disk.#", "0"),
+					resource.TestCheckResourceAttr("vcd_vapp_vm.vm2", "disk.#", "1"),
+				),
+			},
+			{
+				// The disk is read before the VMs are updated, so the new attachment is checked after a refresh
+				Config: configText2,
+				Check: resource.ComposeTestCheckFunc(
+					cachedDiskId.testCheckCachedResourceFieldValue("vcd_independent_disk.disk", "id"),
+					resource.TestCheckResourceAttr("vcd_independent_disk.disk", "attached_vm_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("vcd_independent_disk.disk", "attached_vm_ids.*", "vcd_vapp_vm.vm2", "id"),
+				),
+			},
+			{
+				Config:      configText4,
+				ExpectError: regexp.MustCompile(`disk .* is still attached to VM .vm2.`),
+			},
+			{
+				Config:      configText5,
+				ExpectError: regexp.MustCompile(`independent disk can only be grown`),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccCheckVcdIndependentDiskMove = `
+resource "vcd_independent_disk" "disk" {
+  org          = "{{.Org}}"
+  vdc          = "{{.Vdc}}"
+  name         = "{{.DiskName}}"
+  size_in_mb   = "{{.Size}}"
+  bus_type     = "SCSI"
+  bus_sub_type = "VirtualSCSI"
+}
+
+resource "vcd_vapp" "test" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VappName}}"
+}
+
+locals {
+  disks = {
+    vm1 = contains(["vm1", "both"], "{{.AttachedVm}}") ? [vcd_independent_disk.disk.name] : []
+    vm2 = contains(["vm2", "both"], "{{.AttachedVm}}") ? [vcd_independent_disk.disk.name] : []
+  }
+}
+
+resource "vcd_vapp_vm" "vm1" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  vapp_name     = vcd_vapp.test.name
+  name          = "vm1"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 1024
+  cpus          = 1
+  power_on      = false
+
+  dynamic "disk" {
+    for_each = local.disks.vm1
+    content {
+      name        = disk.value
+      bus_number  = 1
+      unit_number = 0
+    }
+  }
+}
+
+resource "vcd_vapp_vm" "vm2" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  vapp_name     = vcd_vapp.test.name
+  name          = "vm2"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 1024
+  cpus          = 1
+  power_on      = false
+
+  dynamic "disk" {
+    for_each = local.disks.vm2
+    content {
+      name        = disk.value
+      bus_number  = 1
+      unit_number = 0
+    }
+  }
+}
+`
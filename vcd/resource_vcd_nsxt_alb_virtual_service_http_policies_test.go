@@ -0,0 +1,323 @@
+//go:build nsxt || alb || ALL || functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdNsxtAlbVirtualServiceHttpPolicies(t *testing.T) {
+	preTestChecks(t)
+	skipIfNotSysAdmin(t)
+
+	skipNoNsxtAlbConfiguration(t)
+
+	// String map to fill the template
+	var params = StringMap{
+		"TestName":           t.Name(),
+		"VirtualServiceName": t.Name(),
+		"ControllerName":     t.Name(),
+		"ControllerUrl":      testConfig.Nsxt.NsxtAlbControllerUrl,
+		"ControllerUsername": testConfig.Nsxt.NsxtAlbControllerUser,
+		"ControllerPassword": testConfig.Nsxt.NsxtAlbControllerPassword,
+		"ImportableCloud":    testConfig.Nsxt.NsxtAlbImportableCloud,
+		"ReservationModel":   "DEDICATED",
+		"Org":                testConfig.VCD.Org,
+		"NsxtVdc":            testConfig.Nsxt.Vdc,
+		"EdgeGw":             testConfig.Nsxt.EdgeGateway,
+		"IsActive":           "true",
+		"Tags":               "nsxt alb",
+	}
+	changeSupportedFeatureSetIfVersionIsLessThan37("LicenseType", "SupportedFeatureSet", params, false)
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name() + "step1"
+	configText1 := templateFill(testAccVcdNsxtAlbVirtualServiceHttpPoliciesStep1, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "step2"
+	configText2 := templateFill(testAccVcdNsxtAlbVirtualServiceHttpPoliciesStep2, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	params["FuncName"] = t.Name() + "step3"
+	configText3 := templateFill(testAccVcdNsxtAlbVirtualServiceHttpPoliciesStep3, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 3: %s", configText3)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	vcdClient := createTemporaryVCDConnection(true)
+	if vcdClient == nil {
+		t.Skip(acceptanceTestsSkipped)
+	}
+	if vcdClient.Client.APIVCDMaxVersionIs("< 38.0") {
+		t.Skip("NSX-T ALB Virtual Service HTTP policies require VCD 10.5+")
+	}
+
+	reqRules := "vcd_nsxt_alb_virtual_service_http_req_rules.test"
+	respRules := "vcd_nsxt_alb_virtual_service_http_resp_rules.test"
+	secRules := "vcd_nsxt_alb_virtual_service_http_sec_rules.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckVcdAlbControllerDestroy("vcd_nsxt_alb_controller.first"),
+			testAccCheckVcdAlbServiceEngineGroupDestroy("vcd_nsxt_alb_cloud.first"),
+			testAccCheckVcdAlbCloudDestroy("vcd_nsxt_alb_cloud.first"),
+			testAccCheckVcdNsxtEdgeGatewayAlbSettingsDestroy(params["EdgeGw"].(string)),
+			testAccCheckVcdAlbVirtualServiceDestroy("vcd_nsxt_alb_virtual_service.test"),
+		),
+
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(reqRules, "id", "vcd_nsxt_alb_virtual_service.test", "id"),
+					resource.TestCheckResourceAttr(reqRules, "rule.#", "2"),
+					resource.TestCheckResourceAttr(reqRules, "rule.0.name", "redirect"),
+					resource.TestCheckResourceAttr(reqRules, "rule.0.redirect_action.0.status_code", "302"),
+					resource.TestCheckResourceAttr(reqRules, "rule.1.modify_header_action.#", "2"),
+
+					resource.TestCheckResourceAttrPair(respRules, "id", "vcd_nsxt_alb_virtual_service.test", "id"),
+					resource.TestCheckResourceAttr(respRules, "rule.#", "1"),
+					resource.TestCheckResourceAttr(respRules, "rule.0.match_criteria.0.status_code.0.http_status_code", "200,300-399"),
+					resource.TestCheckResourceAttr(respRules, "rule.0.rewrite_location_header_action.0.protocol", "HTTPS"),
+
+					resource.TestCheckResourceAttrPair(secRules, "id", "vcd_nsxt_alb_virtual_service.test", "id"),
+					resource.TestCheckResourceAttr(secRules, "rule.#", "2"),
+					resource.TestCheckResourceAttr(secRules, "rule.0.actions.0.connections", "CLOSE"),
+					resource.TestCheckResourceAttr(secRules, "rule.1.actions.0.rate_limit.0.count", "100"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(reqRules, "rule.#", "1"),
+					resource.TestCheckResourceAttr(reqRules, "rule.0.name", "rewrite"),
+					resource.TestCheckResourceAttr(reqRules, "rule.0.rewrite_url_action.0.existing_path", "/new"),
+					resource.TestCheckResourceAttr(respRules, "rule.0.active", "false"),
+					resource.TestCheckResourceAttr(secRules, "rule.#", "1"),
+					resource.TestCheckResourceAttr(secRules, "rule.0.actions.0.send_response.0.status_code", "403"),
+				),
+			},
+			{
+				Config: configText3, // Datasource check
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resourceFieldsEqual("data."+reqRules, reqRules, nil),
+					resourceFieldsEqual("data."+respRules, respRules, nil),
+					resourceFieldsEqual("data."+secRules, secRules, nil),
+				),
+			},
+			{
+				ResourceName:      reqRules,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdNsxtEdgeGatewayObject(testConfig.Nsxt.EdgeGateway, params["VirtualServiceName"].(string)),
+			},
+			{
+				ResourceName:      respRules,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdNsxtEdgeGatewayObject(testConfig.Nsxt.EdgeGateway, params["VirtualServiceName"].(string)),
+			},
+			{
+				ResourceName:      secRules,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdNsxtEdgeGatewayObject(testConfig.Nsxt.EdgeGateway, params["VirtualServiceName"].(string)),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtAlbVirtualServiceHttpPoliciesVs = testAccVcdNsxtAlbVirtualServicePrereqs + `
+resource "vcd_nsxt_alb_virtual_service" "test" {
+  org = "{{.Org}}"
+  vdc = "{{.NsxtVdc}}"
+
+  name            = "{{.VirtualServiceName}}"
+  edge_gateway_id = vcd_nsxt_alb_settings.test.edge_gateway_id
+
+  pool_id                  = vcd_nsxt_alb_pool.test.id
+  service_engine_group_id  = vcd_nsxt_alb_edgegateway_service_engine_group.assignment.service_engine_group_id
+  virtual_ip_address       = tolist(data.vcd_nsxt_edgegateway.existing.subnet)[0].primary_ip
+  application_profile_type = "HTTP"
+  service_port {
+    start_port = 80
+    type       = "TCP_PROXY"
+  }
+}
+`
+
+const testAccVcdNsxtAlbVirtualServiceHttpPoliciesStep1 = testAccVcdNsxtAlbVirtualServiceHttpPoliciesVs + `
+resource "vcd_nsxt_alb_virtual_service_http_req_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name    = "redirect"
+    logging = true
+    match_criteria {
+      client_ip_address {
+        criteria     = "IS_NOT_IN"
+        ip_addresses = ["10.10.10.0/24"]
+      }
+      http_methods {
+        criteria = "IS_IN"
+        methods  = ["GET", "POST"]
+      }
+    }
+    redirect_action {
+      protocol    = "HTTPS"
+      port        = 443
+      status_code = 302
+      path        = "/secure"
+    }
+  }
+
+  rule {
+    name = "headers"
+    match_criteria {
+      path {
+        criteria = "BEGINS_WITH"
+        paths    = ["/api"]
+      }
+      request_headers {
+        criteria = "EXISTS"
+        name     = "X-Debug"
+      }
+    }
+    modify_header_action {
+      action = "ADD"
+      name   = "X-Forwarded-Proto"
+      value  = "https"
+    }
+    modify_header_action {
+      action = "REMOVE"
+      name   = "X-Debug"
+    }
+  }
+}
+
+resource "vcd_nsxt_alb_virtual_service_http_resp_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name = "location"
+    match_criteria {
+      status_code {
+        criteria         = "IS_IN"
+        http_status_code = "200,300-399"
+      }
+    }
+    rewrite_location_header_action {
+      protocol = "HTTPS"
+      host     = "www.example.com"
+    }
+  }
+}
+
+resource "vcd_nsxt_alb_virtual_service_http_sec_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name = "deny"
+    match_criteria {
+      client_ip_address {
+        criteria     = "IS_IN"
+        ip_addresses = ["192.168.1.1", "192.168.2.0/24"]
+      }
+    }
+    actions {
+      connections = "CLOSE"
+    }
+  }
+
+  rule {
+    name = "rate-limit"
+    actions {
+      rate_limit {
+        count                   = 100
+        period                  = 10
+        action_close_connection = true
+      }
+    }
+  }
+}
+`
+
+const testAccVcdNsxtAlbVirtualServiceHttpPoliciesStep2 = testAccVcdNsxtAlbVirtualServiceHttpPoliciesVs + `
+resource "vcd_nsxt_alb_virtual_service_http_req_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name = "rewrite"
+    match_criteria {
+      path {
+        criteria = "EQUALS"
+        paths    = ["/old"]
+      }
+    }
+    rewrite_url_action {
+      host_header   = "internal.example.com"
+      existing_path = "/new"
+      keep_query    = true
+    }
+  }
+}
+
+resource "vcd_nsxt_alb_virtual_service_http_resp_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name   = "location"
+    active = false
+    match_criteria {
+      status_code {
+        criteria         = "IS_IN"
+        http_status_code = "200,300-399"
+      }
+    }
+    rewrite_location_header_action {
+      protocol = "HTTPS"
+      host     = "www.example.com"
+    }
+  }
+}
+
+resource "vcd_nsxt_alb_virtual_service_http_sec_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name = "forbidden"
+    match_criteria {
+      protocol_type = "HTTP"
+    }
+    actions {
+      send_response {
+        status_code = "403"
+      }
+    }
+  }
+}
+`
+
+const testAccVcdNsxtAlbVirtualServiceHttpPoliciesStep3 = testAccVcdNsxtAlbVirtualServiceHttpPoliciesStep2 + `
+# skip-binary-test: Terraform resource cannot have resource and datasource in the same file
+
+data "vcd_nsxt_alb_virtual_service_http_req_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service_http_req_rules.test.virtual_service_id
+}
+
+data "vcd_nsxt_alb_virtual_service_http_resp_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service_http_resp_rules.test.virtual_service_id
+}
+
+data "vcd_nsxt_alb_virtual_service_http_sec_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service_http_sec_rules.test.virtual_service_id
+}
+`
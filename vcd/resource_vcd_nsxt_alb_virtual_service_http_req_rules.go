@@ -0,0 +1,208 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func resourceVcdAlbVirtualServiceHttpReqRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdAlbVirtualServiceHttpReqRulesCreateUpdate,
+		ReadContext:   resourceVcdAlbVirtualServiceHttpReqRulesRead,
+		UpdateContext: resourceVcdAlbVirtualServiceHttpReqRulesCreateUpdate,
+		DeleteContext: resourceVcdAlbVirtualServiceHttpReqRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdAlbVsHttpPolicyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"virtual_service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NSX-T ALB Virtual Service ID",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        albVsHttpRequestRuleSchema(false),
+				Description: "A single HTTP Request Rule",
+			},
+		},
+	}
+}
+
+// albVsHttpRequestRuleSchema returns the schema of an HTTP request rule. Rules must define exactly one of
+// 'redirect_action', 'modify_header_action' or 'rewrite_url_action'
+func albVsHttpRequestRuleSchema(computed bool) *schema.Resource {
+	return albVsHttpRuleSchema(albVsHttpMatchCriteriaSchema(false, computed), map[string]*schema.Schema{
+		"redirect_action": albVsHttpField(&schema.Schema{
+			Type:        schema.TypeList,
+			MaxItems:    1,
+			Elem:        albVsHttpRedirectSchema(computed),
+			Description: "Redirect request",
+		}, false, computed),
+		"modify_header_action": albVsHttpModifyHeaderSchema(computed),
+		"rewrite_url_action": albVsHttpField(&schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"host_header": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeString,
+						Description: "Host to use for the rewritten URL",
+					}, true, computed),
+					"existing_path": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeString,
+						Description: "Path to use for the rewritten URL",
+					}, true, computed),
+					"keep_query": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeBool,
+						Description: "Whether or not to keep the existing query string when rewriting the URL",
+					}, false, computed),
+					"query": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeString,
+						Description: "Query string to use or append to the existing query string",
+					}, false, computed),
+				},
+			},
+			Description: "Rewrite URL",
+		}, false, computed),
+	}, computed)
+}
+
+func resourceVcdAlbVirtualServiceHttpReqRulesCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	vcdClient.lockById(virtualServiceId)
+	defer vcdClient.unlockById(virtualServiceId)
+
+	rules, err := getAlbVsHttpRequestRulesType(d)
+	if err != nil {
+		return diag.Errorf("error getting NSX-T ALB Virtual Service HTTP Request Rules type: %s", err)
+	}
+
+	err = updateAlbVsHttpPolicy(vcdClient, virtualServiceId, albVsHttpRequestRulesEndpoint, rules)
+	if err != nil {
+		return diag.Errorf("error setting NSX-T ALB Virtual Service HTTP Request Rules: %s", err)
+	}
+
+	d.SetId(virtualServiceId)
+
+	return resourceVcdAlbVirtualServiceHttpReqRulesRead(ctx, d, meta)
+}
+
+func resourceVcdAlbVirtualServiceHttpReqRulesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(readAlbVsHttpRequestRules(d, meta, "resource"))
+}
+
+func readAlbVsHttpRequestRules(d *schema.ResourceData, meta interface{}, origin string) error {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	rules := &albVsHttpRequestRules{}
+	err := getAlbVsHttpPolicy(vcdClient, virtualServiceId, albVsHttpRequestRulesEndpoint, rules)
+	if err != nil {
+		if origin == "resource" && govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] NSX-T ALB Virtual Service '%s' not found. Removing HTTP Request Rules from state", virtualServiceId)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error retrieving NSX-T ALB Virtual Service HTTP Request Rules: %s", err)
+	}
+
+	err = setAlbVsHttpRequestRulesData(d, rules)
+	if err != nil {
+		return fmt.Errorf("error storing NSX-T ALB Virtual Service HTTP Request Rules: %s", err)
+	}
+	d.SetId(virtualServiceId)
+
+	return nil
+}
+
+func resourceVcdAlbVirtualServiceHttpReqRulesDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	vcdClient.lockById(virtualServiceId)
+	defer vcdClient.unlockById(virtualServiceId)
+
+	err := updateAlbVsHttpPolicy(vcdClient, virtualServiceId, albVsHttpRequestRulesEndpoint, &albVsHttpRequestRules{Values: []albVsHttpRequestRule{}})
+	if err != nil {
+		return diag.Errorf("error removing NSX-T ALB Virtual Service HTTP Request Rules: %s", err)
+	}
+
+	return nil
+}
+
+func getAlbVsHttpRequestRulesType(d *schema.ResourceData) (*albVsHttpRequestRules, error) {
+	ruleList := d.Get("rule").([]interface{})
+	rules := &albVsHttpRequestRules{Values: make([]albVsHttpRequestRule, len(ruleList))}
+
+	for index, ruleItem := range ruleList {
+		ruleMap := ruleItem.(map[string]interface{})
+		rule := albVsHttpRequestRule{
+			Name:          ruleMap["name"].(string),
+			Active:        ruleMap["active"].(bool),
+			Logging:       ruleMap["logging"].(bool),
+			MatchCriteria: getAlbVsHttpMatchCriteriaType(ruleMap, false),
+			HeaderActions: getAlbVsHttpHeaderActionsType(ruleMap["modify_header_action"].([]interface{})),
+		}
+
+		actionCount := 0
+		if len(rule.HeaderActions) > 0 {
+			actionCount++
+		}
+		if block := firstBlock(ruleMap["redirect_action"]); block != nil {
+			rule.RedirectAction = getAlbVsHttpRedirectType(block)
+			actionCount++
+		}
+		if block := firstBlock(ruleMap["rewrite_url_action"]); block != nil {
+			rule.RewriteURLAction = &albVsHttpRewriteURLAction{
+				Host:         block["host_header"].(string),
+				ExistingPath: block["existing_path"].(string),
+				KeepQuery:    block["keep_query"].(bool),
+				Query:        block["query"].(string),
+			}
+			actionCount++
+		}
+		if actionCount != 1 {
+			return nil, fmt.Errorf("rule '%s' must define exactly one of 'redirect_action', 'modify_header_action' or 'rewrite_url_action'", rule.Name)
+		}
+
+		rules.Values[index] = rule
+	}
+
+	return rules, nil
+}
+
+func setAlbVsHttpRequestRulesData(d *schema.ResourceData, rules *albVsHttpRequestRules) error {
+	ruleSlice := make([]interface{}, len(rules.Values))
+	for index, rule := range rules.Values {
+		ruleMap := map[string]interface{}{
+			"name":                 rule.Name,
+			"active":               rule.Active,
+			"logging":              rule.Logging,
+			"match_criteria":       setAlbVsHttpMatchCriteriaData(rule.MatchCriteria, false),
+			"redirect_action":      setAlbVsHttpRedirectData(rule.RedirectAction),
+			"modify_header_action": setAlbVsHttpHeaderActionsData(rule.HeaderActions),
+		}
+		if rule.RewriteURLAction != nil {
+			ruleMap["rewrite_url_action"] = []interface{}{map[string]interface{}{
+				"host_header":   rule.RewriteURLAction.Host,
+				"existing_path": rule.RewriteURLAction.ExistingPath,
+				"keep_query":    rule.RewriteURLAction.KeepQuery,
+				"query":         rule.RewriteURLAction.Query,
+			}}
+		}
+		ruleSlice[index] = ruleMap
+	}
+
+	return d.Set("rule", ruleSlice)
+}
@@ -0,0 +1,205 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func resourceVcdAlbVirtualServiceHttpRespRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdAlbVirtualServiceHttpRespRulesCreateUpdate,
+		ReadContext:   resourceVcdAlbVirtualServiceHttpRespRulesRead,
+		UpdateContext: resourceVcdAlbVirtualServiceHttpRespRulesCreateUpdate,
+		DeleteContext: resourceVcdAlbVirtualServiceHttpRespRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdAlbVsHttpPolicyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"virtual_service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NSX-T ALB Virtual Service ID",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        albVsHttpResponseRuleSchema(false),
+				Description: "A single HTTP Response Rule",
+			},
+		},
+	}
+}
+
+// albVsHttpResponseRuleSchema returns the schema of an HTTP response rule. Rules must define exactly one of
+// 'rewrite_location_header_action' or 'modify_header_action'
+func albVsHttpResponseRuleSchema(computed bool) *schema.Resource {
+	return albVsHttpRuleSchema(albVsHttpMatchCriteriaSchema(true, computed), map[string]*schema.Schema{
+		"rewrite_location_header_action": albVsHttpField(&schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"protocol": albVsHttpField(&schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice([]string{"HTTP", "HTTPS"}, false),
+						Description:  "HTTP or HTTPS protocol",
+					}, true, computed),
+					"port": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeInt,
+						Description: "Port to which redirect the request",
+					}, false, computed),
+					"host": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeString,
+						Description: "Host to which redirect the request",
+					}, false, computed),
+					"path": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeString,
+						Description: "Path to which redirect the request",
+					}, false, computed),
+					"keep_query": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeBool,
+						Description: "Defines whether to keep the query string of the original request",
+					}, false, computed),
+				},
+			},
+			Description: "Rewrite location header",
+		}, false, computed),
+		"modify_header_action": albVsHttpModifyHeaderSchema(computed),
+	}, computed)
+}
+
+func resourceVcdAlbVirtualServiceHttpRespRulesCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	vcdClient.lockById(virtualServiceId)
+	defer vcdClient.unlockById(virtualServiceId)
+
+	rules, err := getAlbVsHttpResponseRulesType(d)
+	if err != nil {
+		return diag.Errorf("error getting NSX-T ALB Virtual Service HTTP Response Rules type: %s", err)
+	}
+
+	err = updateAlbVsHttpPolicy(vcdClient, virtualServiceId, albVsHttpResponseRulesEndpoint, rules)
+	if err != nil {
+		return diag.Errorf("error setting NSX-T ALB Virtual Service HTTP Response Rules: %s", err)
+	}
+
+	d.SetId(virtualServiceId)
+
+	return resourceVcdAlbVirtualServiceHttpRespRulesRead(ctx, d, meta)
+}
+
+func resourceVcdAlbVirtualServiceHttpRespRulesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(readAlbVsHttpResponseRules(d, meta, "resource"))
+}
+
+func readAlbVsHttpResponseRules(d *schema.ResourceData, meta interface{}, origin string) error {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	rules := &albVsHttpResponseRules{}
+	err := getAlbVsHttpPolicy(vcdClient, virtualServiceId, albVsHttpResponseRulesEndpoint, rules)
+	if err != nil {
+		if origin == "resource" && govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] NSX-T ALB Virtual Service '%s' not found. Removing HTTP Response Rules from state", virtualServiceId)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error retrieving NSX-T ALB Virtual Service HTTP Response Rules: %s", err)
+	}
+
+	err = setAlbVsHttpResponseRulesData(d, rules)
+	if err != nil {
+		return fmt.Errorf("error storing NSX-T ALB Virtual Service HTTP Response Rules: %s", err)
+	}
+	d.SetId(virtualServiceId)
+
+	return nil
+}
+
+func resourceVcdAlbVirtualServiceHttpRespRulesDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	vcdClient.lockById(virtualServiceId)
+	defer vcdClient.unlockById(virtualServiceId)
+
+	err := updateAlbVsHttpPolicy(vcdClient, virtualServiceId, albVsHttpResponseRulesEndpoint, &albVsHttpResponseRules{Values: []albVsHttpResponseRule{}})
+	if err != nil {
+		return diag.Errorf("error removing NSX-T ALB Virtual Service HTTP Response Rules: %s", err)
+	}
+
+	return nil
+}
+
+func getAlbVsHttpResponseRulesType(d *schema.ResourceData) (*albVsHttpResponseRules, error) {
+	ruleList := d.Get("rule").([]interface{})
+	rules := &albVsHttpResponseRules{Values: make([]albVsHttpResponseRule, len(ruleList))}
+
+	for index, ruleItem := range ruleList {
+		ruleMap := ruleItem.(map[string]interface{})
+		rule := albVsHttpResponseRule{
+			Name:          ruleMap["name"].(string),
+			Active:        ruleMap["active"].(bool),
+			Logging:       ruleMap["logging"].(bool),
+			MatchCriteria: getAlbVsHttpMatchCriteriaType(ruleMap, true),
+			HeaderActions: getAlbVsHttpHeaderActionsType(ruleMap["modify_header_action"].([]interface{})),
+		}
+
+		if block := firstBlock(ruleMap["rewrite_location_header_action"]); block != nil {
+			rule.RewriteLocationHeaderAction = &albVsHttpRewriteLocationAction{
+				Protocol:  block["protocol"].(string),
+				Host:      block["host"].(string),
+				Path:      block["path"].(string),
+				KeepQuery: block["keep_query"].(bool),
+			}
+			if port := block["port"].(int); port != 0 {
+				rule.RewriteLocationHeaderAction.Port = addrOf(port)
+			}
+		}
+		if (rule.RewriteLocationHeaderAction == nil) == (len(rule.HeaderActions) == 0) {
+			return nil, fmt.Errorf("rule '%s' must define exactly one of 'rewrite_location_header_action' or 'modify_header_action'", rule.Name)
+		}
+
+		rules.Values[index] = rule
+	}
+
+	return rules, nil
+}
+
+func setAlbVsHttpResponseRulesData(d *schema.ResourceData, rules *albVsHttpResponseRules) error {
+	ruleSlice := make([]interface{}, len(rules.Values))
+	for index, rule := range rules.Values {
+		ruleMap := map[string]interface{}{
+			"name":                 rule.Name,
+			"active":               rule.Active,
+			"logging":              rule.Logging,
+			"match_criteria":       setAlbVsHttpMatchCriteriaData(rule.MatchCriteria, true),
+			"modify_header_action": setAlbVsHttpHeaderActionsData(rule.HeaderActions),
+		}
+		if rule.RewriteLocationHeaderAction != nil {
+			action := map[string]interface{}{
+				"protocol":   rule.RewriteLocationHeaderAction.Protocol,
+				"host":       rule.RewriteLocationHeaderAction.Host,
+				"path":       rule.RewriteLocationHeaderAction.Path,
+				"keep_query": rule.RewriteLocationHeaderAction.KeepQuery,
+			}
+			if rule.RewriteLocationHeaderAction.Port != nil {
+				action["port"] = *rule.RewriteLocationHeaderAction.Port
+			}
+			ruleMap["rewrite_location_header_action"] = []interface{}{action}
+		}
+		ruleSlice[index] = ruleMap
+	}
+
+	return d.Set("rule", ruleSlice)
+}
@@ -0,0 +1,257 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func resourceVcdAlbVirtualServiceHttpSecRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdAlbVirtualServiceHttpSecRulesCreateUpdate,
+		ReadContext:   resourceVcdAlbVirtualServiceHttpSecRulesRead,
+		UpdateContext: resourceVcdAlbVirtualServiceHttpSecRulesCreateUpdate,
+		DeleteContext: resourceVcdAlbVirtualServiceHttpSecRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdAlbVsHttpPolicyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"virtual_service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NSX-T ALB Virtual Service ID",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        albVsHttpSecurityRuleSchema(false),
+				Description: "A single HTTP Security Rule",
+			},
+		},
+	}
+}
+
+// albVsHttpSecurityRuleSchema returns the schema of an HTTP security rule. The 'actions' block must define
+// exactly one action
+func albVsHttpSecurityRuleSchema(computed bool) *schema.Resource {
+	return albVsHttpRuleSchema(albVsHttpMatchCriteriaSchema(false, computed), map[string]*schema.Schema{
+		"actions": albVsHttpField(&schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"connections": albVsHttpField(&schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice([]string{"ALLOW", "CLOSE"}, false),
+						Description:  "ALLOW or CLOSE connections",
+					}, false, computed),
+					"redirect_to_https": albVsHttpField(&schema.Schema{
+						Type:         schema.TypeInt,
+						ValidateFunc: validation.IsPortNumber,
+						Description:  "Port number that should be redirected to HTTPS",
+					}, false, computed),
+					"send_response": albVsHttpField(&schema.Schema{
+						Type:        schema.TypeList,
+						MaxItems:    1,
+						Elem:        albVsHttpLocalResponseSchema(computed),
+						Description: "Send a local response",
+					}, false, computed),
+					"rate_limit": albVsHttpField(&schema.Schema{
+						Type:     schema.TypeList,
+						MaxItems: 1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"count": albVsHttpField(&schema.Schema{
+									Type:        schema.TypeInt,
+									Description: "Maximum number of connections, requests or packets permitted each period",
+								}, true, computed),
+								"period": albVsHttpField(&schema.Schema{
+									Type:        schema.TypeInt,
+									Description: "Time value in seconds to enforce rate count",
+								}, true, computed),
+								"action_close_connection": albVsHttpField(&schema.Schema{
+									Type:        schema.TypeBool,
+									Description: "Close the connection when the rate is exceeded",
+								}, false, computed),
+								"action_redirect": albVsHttpField(&schema.Schema{
+									Type:        schema.TypeList,
+									MaxItems:    1,
+									Elem:        albVsHttpRedirectSchema(computed),
+									Description: "Redirect the request when the rate is exceeded",
+								}, false, computed),
+								"action_local_response": albVsHttpField(&schema.Schema{
+									Type:        schema.TypeList,
+									MaxItems:    1,
+									Elem:        albVsHttpLocalResponseSchema(computed),
+									Description: "Send a local response when the rate is exceeded",
+								}, false, computed),
+							},
+						},
+						Description: "Apply actions based on rate limits",
+					}, false, computed),
+				},
+			},
+			Description: "Action to perform when the rule matches",
+		}, true, computed),
+	}, computed)
+}
+
+func resourceVcdAlbVirtualServiceHttpSecRulesCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	vcdClient.lockById(virtualServiceId)
+	defer vcdClient.unlockById(virtualServiceId)
+
+	rules, err := getAlbVsHttpSecurityRulesType(d)
+	if err != nil {
+		return diag.Errorf("error getting NSX-T ALB Virtual Service HTTP Security Rules type: %s", err)
+	}
+
+	err = updateAlbVsHttpPolicy(vcdClient, virtualServiceId, albVsHttpSecurityRulesEndpoint, rules)
+	if err != nil {
+		return diag.Errorf("error setting NSX-T ALB Virtual Service HTTP Security Rules: %s", err)
+	}
+
+	d.SetId(virtualServiceId)
+
+	return resourceVcdAlbVirtualServiceHttpSecRulesRead(ctx, d, meta)
+}
+
+func resourceVcdAlbVirtualServiceHttpSecRulesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(readAlbVsHttpSecurityRules(d, meta, "resource"))
+}
+
+func readAlbVsHttpSecurityRules(d *schema.ResourceData, meta interface{}, origin string) error {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	rules := &albVsHttpSecurityRules{}
+	err := getAlbVsHttpPolicy(vcdClient, virtualServiceId, albVsHttpSecurityRulesEndpoint, rules)
+	if err != nil {
+		if origin == "resource" && govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] NSX-T ALB Virtual Service '%s' not found. Removing HTTP Security Rules from state", virtualServiceId)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error retrieving NSX-T ALB Virtual Service HTTP Security Rules: %s", err)
+	}
+
+	err = setAlbVsHttpSecurityRulesData(d, rules)
+	if err != nil {
+		return fmt.Errorf("error storing NSX-T ALB Virtual Service HTTP Security Rules: %s", err)
+	}
+	d.SetId(virtualServiceId)
+
+	return nil
+}
+
+func resourceVcdAlbVirtualServiceHttpSecRulesDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+
+	vcdClient.lockById(virtualServiceId)
+	defer vcdClient.unlockById(virtualServiceId)
+
+	err := updateAlbVsHttpPolicy(vcdClient, virtualServiceId, albVsHttpSecurityRulesEndpoint, &albVsHttpSecurityRules{Values: []albVsHttpSecurityRule{}})
+	if err != nil {
+		return diag.Errorf("error removing NSX-T ALB Virtual Service HTTP Security Rules: %s", err)
+	}
+
+	return nil
+}
+
+func getAlbVsHttpSecurityRulesType(d *schema.ResourceData) (*albVsHttpSecurityRules, error) {
+	ruleList := d.Get("rule").([]interface{})
+	rules := &albVsHttpSecurityRules{Values: make([]albVsHttpSecurityRule, len(ruleList))}
+
+	for index, ruleItem := range ruleList {
+		ruleMap := ruleItem.(map[string]interface{})
+		rule := albVsHttpSecurityRule{
+			Name:          ruleMap["name"].(string),
+			Active:        ruleMap["active"].(bool),
+			Logging:       ruleMap["logging"].(bool),
+			MatchCriteria: getAlbVsHttpMatchCriteriaType(ruleMap, false),
+		}
+
+		actions := firstBlock(ruleMap["actions"])
+		if actions == nil {
+			return nil, fmt.Errorf("rule '%s' must define one action", rule.Name)
+		}
+		actionCount := 0
+		if connections := actions["connections"].(string); connections != "" {
+			rule.AllowOrCloseConnectionAction = connections
+			actionCount++
+		}
+		if port := actions["redirect_to_https"].(int); port != 0 {
+			rule.RedirectToHTTPSAction = &albVsHttpRedirectToHttps{Port: port}
+			actionCount++
+		}
+		if block := firstBlock(actions["send_response"]); block != nil {
+			rule.SendResponseAction = getAlbVsHttpLocalResponseType(block)
+			actionCount++
+		}
+		if block := firstBlock(actions["rate_limit"]); block != nil {
+			rule.RateLimitAction = &albVsHttpRateLimitAction{
+				Count:  block["count"].(int),
+				Period: block["period"].(int),
+			}
+			if block["action_close_connection"].(bool) {
+				rule.RateLimitAction.CloseConnectionAction = "CLOSE"
+			}
+			if redirect := firstBlock(block["action_redirect"]); redirect != nil {
+				rule.RateLimitAction.RedirectAction = getAlbVsHttpRedirectType(redirect)
+			}
+			if response := firstBlock(block["action_local_response"]); response != nil {
+				rule.RateLimitAction.LocalResponseAction = getAlbVsHttpLocalResponseType(response)
+			}
+			actionCount++
+		}
+		if actionCount != 1 {
+			return nil, fmt.Errorf("rule '%s' must define exactly one of 'connections', 'redirect_to_https', 'send_response' or 'rate_limit' actions", rule.Name)
+		}
+
+		rules.Values[index] = rule
+	}
+
+	return rules, nil
+}
+
+func setAlbVsHttpSecurityRulesData(d *schema.ResourceData, rules *albVsHttpSecurityRules) error {
+	ruleSlice := make([]interface{}, len(rules.Values))
+	for index, rule := range rules.Values {
+		actions := map[string]interface{}{
+			"connections":   rule.AllowOrCloseConnectionAction,
+			"send_response": setAlbVsHttpLocalResponseData(rule.SendResponseAction),
+		}
+		if rule.RedirectToHTTPSAction != nil {
+			actions["redirect_to_https"] = rule.RedirectToHTTPSAction.Port
+		}
+		if rule.RateLimitAction != nil {
+			actions["rate_limit"] = []interface{}{map[string]interface{}{
+				"count":                   rule.RateLimitAction.Count,
+				"period":                  rule.RateLimitAction.Period,
+				"action_close_connection": rule.RateLimitAction.CloseConnectionAction != "",
+				"action_redirect":         setAlbVsHttpRedirectData(rule.RateLimitAction.RedirectAction),
+				"action_local_response":   setAlbVsHttpLocalResponseData(rule.RateLimitAction.LocalResponseAction),
+			}}
+		}
+
+		ruleSlice[index] = map[string]interface{}{
+			"name":           rule.Name,
+			"active":         rule.Active,
+			"logging":        rule.Logging,
+			"match_criteria": setAlbVsHttpMatchCriteriaData(rule.MatchCriteria, false),
+			"actions":        []interface{}{actions},
+		}
+	}
+
+	return d.Set("rule", ruleSlice)
+}
@@ -37,6 +37,7 @@ func resourceVcdNsxtDistributedFirewall() *schema.Resource {
 				ForceNew:    true,
 				Description: "ID of VDC Group for Distributed Firewall",
 			},
+			"owner": dfwOwnerSchema("Owner of all the rules, stored as a marker at the end of their comment (VCD 10.3.2+)"),
 			"rule": {
 				Type:        schema.TypeList, // Firewall rule order matters
 				Required:    true,
@@ -64,6 +65,11 @@ func resourceVcdNsxtDistributedFirewall() *schema.Resource {
 							Optional:    true,
 							Description: "Comment that is shown next to rule in UI (VCD 10.3.2+)",
 						},
+						"owner": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Owner of the rule",
+						},
 						"direction": {
 							Type:         schema.TypeString,
 							Optional:     true,
@@ -206,6 +212,7 @@ func resourceVcdNsxtDistributedFirewallRead(_ context.Context, d *schema.Resourc
 	if err != nil {
 		return diag.Errorf("[Distributed Firewall Read] error storing NSX-T Firewall data to schema: %s", err)
 	}
+	dSet(d, "owner", dfwCommonOwner(fwRules.DistributedFirewallRuleContainer.Values))
 
 	return nil
 }
@@ -277,11 +284,14 @@ func setDistributedFirewallData(vcdClient *VCDClient, dfwRules *types.Distribute
 		netContextProfileSlice := extractIdsFromOpenApiReferences(value.NetworkContextProfiles)
 		netPortProfileSet := convertStringsToTypeSet(netContextProfileSlice)
 
+		comment, owner := dfwCommentOwner(value.Comments)
+
 		result[index] = map[string]interface{}{
 			"id":                          value.ID,
 			"name":                        value.Name,
 			"description":                 value.Description,
-			"comment":                     value.Comments,
+			"comment":                     comment,
+			"owner":                       owner,
 			"action":                      value.ActionValue,
 			"enabled":                     value.Enabled,
 			"ip_protocol":                 value.IpProtocol,
@@ -341,12 +351,13 @@ func getDistributedFirewallTypes(vcdClient *VCDClient, d *schema.ResourceData) (
 
 			// Fields requiring 10.3.2+
 			// TODO remove when VCD 10.3 is not supported anymore
-			comment := oneRuleMapInterface["comment"].(string)
+			err := setDistributedFirewallRuleComment(vcdClient, sliceOfRules[index], oneRuleMapInterface["comment"].(string), d.Get("owner").(string))
+			if err != nil {
+				return nil, err
+			}
 			sourceGroupsExcluded := oneRuleMapInterface["source_groups_excluded"].(bool)
 			destinationGroupsExcluded := oneRuleMapInterface["destination_groups_excluded"].(bool)
 			if vcdClient.Client.APIVCDMaxVersionIs(">= 36.2") {
-				sliceOfRules[index].Comments = comment
-
 				if sourceGroupsExcluded {
 					sliceOfRules[index].SourceGroupsExcluded = &sourceGroupsExcluded
 				}
@@ -355,10 +366,6 @@ func getDistributedFirewallTypes(vcdClient *VCDClient, d *schema.ResourceData) (
 					sliceOfRules[index].DestinationGroupsExcluded = &destinationGroupsExcluded
 				}
 			} else {
-				if comment != "" {
-					return nil, fmt.Errorf("field 'comment' can only be set in VCD 10.3.2+")
-				}
-
 				// Two below checks will only throw an error if 'true' value has been set. False
 				// will be ignored (when either set, or not set at all), because the only somewhat
 				// reliable way is to use d.GetOkExists which has been deprecated in SDK with no
@@ -58,6 +58,8 @@ func resourceVcdNsxtDistributedFirewallRule() *schema.Resource {
 				Optional:    true,
 				Description: "Comment that is shown next to rule in UI (VCD 10.3.2+)",
 			},
+			"owner": dfwOwnerSchema("Owner of the rule, stored as a marker at the end of the comment. " +
+				"Allows several configurations to share the Distributed Firewall of a VDC Group (VCD 10.3.2+)"),
 			"direction": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -316,12 +318,13 @@ func getDistributedFirewallRuleType(vcdClient *VCDClient, d *schema.ResourceData
 	networkContextPortProfileIds := convertSchemaSetToSliceOfStrings(d.Get("network_context_profile_ids").(*schema.Set))
 	ressss.NetworkContextProfiles = convertSliceOfStringsToOpenApiReferenceIds(networkContextPortProfileIds)
 
-	comment := d.Get("comment").(string)
+	err := setDistributedFirewallRuleComment(vcdClient, ressss, d.Get("comment").(string), d.Get("owner").(string))
+	if err != nil {
+		return nil, err
+	}
 	sourceGroupsExcluded := d.Get("source_groups_excluded").(bool)
 	destinationGroupsExcluded := d.Get("destination_groups_excluded").(bool)
 	if vcdClient.Client.APIVCDMaxVersionIs(">= 36.2") {
-		ressss.Comments = comment
-
 		if sourceGroupsExcluded {
 			ressss.SourceGroupsExcluded = &sourceGroupsExcluded
 		}
@@ -330,10 +333,6 @@ func getDistributedFirewallRuleType(vcdClient *VCDClient, d *schema.ResourceData
 			ressss.DestinationGroupsExcluded = &destinationGroupsExcluded
 		}
 	} else {
-		if comment != "" {
-			return nil, fmt.Errorf("field 'comment' can only be set in VCD 10.3.2+")
-		}
-
 		// Two below checks will only throw an error if 'true' value has been set. False
 		// will be ignored (when either set, or not set at all), because the only somewhat
 		// reliable way is to use d.GetOkExists which has been deprecated in SDK with no
@@ -354,7 +353,9 @@ func getDistributedFirewallRuleType(vcdClient *VCDClient, d *schema.ResourceData
 func setDistributedFirewallRuleData(dfwRule *types.DistributedFirewallRule, d *schema.ResourceData) error {
 	dSet(d, "name", dfwRule.Name)
 	dSet(d, "description", dfwRule.Description)
-	dSet(d, "comment", dfwRule.Comments)
+	comment, owner := dfwCommentOwner(dfwRule.Comments)
+	dSet(d, "comment", comment)
+	dSet(d, "owner", owner)
 	dSet(d, "action", dfwRule.ActionValue)
 	dSet(d, "enabled", dfwRule.Enabled)
 	dSet(d, "ip_protocol", dfwRule.IpProtocol)
@@ -604,3 +604,143 @@ data "vcd_nsxt_distributed_firewall" "allrules" {
   depends_on = [vcd_nsxt_distributed_firewall_rule.r1, vcd_nsxt_distributed_firewall_rule.r2, vcd_nsxt_distributed_firewall_rule.r3]
 }
 `
+
+// TestAccVcdDistributedFirewallRuleOwner checks that the owner of the rules is stored in their comment and that
+// the effective policy data source classifies the rules by owner. The default rule of the VDC Group is kept, so that
+// there is one unmanaged rule
+func TestAccVcdDistributedFirewallRuleOwner(t *testing.T) {
+	preTestChecks(t)
+	skipIfNotSysAdmin(t)
+
+	if checkVersion(testConfig.Provider.ApiVersion, "< 36.2") {
+		t.Skipf("This test tests VCD 10.3.2+ (API V36.2+) features. Skipping.")
+	}
+
+	// String map to fill the template
+	var params = StringMap{
+		"Org":                       testConfig.VCD.Org,
+		"Name":                      t.Name(),
+		"ProviderVdc":               testConfig.VCD.NsxtProviderVdc.Name,
+		"NetworkPool":               testConfig.VCD.NsxtProviderVdc.NetworkPool,
+		"ProviderVdcStorageProfile": testConfig.VCD.NsxtProviderVdc.StorageProfile,
+		"Dfw":                       "true",
+		"DefaultPolicy":             "true",
+		"RemoveDefaultFirewallRule": "false", // will not remove default firewall rule in VDC Group
+		"TestName":                  t.Name(),
+		"NsxtManager":               testConfig.Nsxt.Manager,
+
+		"Tags": "vdcGroup nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name() + "-newVdc"
+	configTextPre := templateFill(testAccVcdVdcGroupNew, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configTextPre)
+
+	params["FuncName"] = t.Name() + "-step2"
+	configText2 := templateFill(dfwRuleStep2Owner, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	policyDef := "data.vcd_nsxt_distributed_firewall_effective_policy.team-a"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// Setup prerequisites
+				Config: configTextPre,
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_distributed_firewall_rule.r1", "comment", "web traffic"),
+					resource.TestCheckResourceAttr("vcd_nsxt_distributed_firewall_rule.r1", "owner", "team-a"),
+					resource.TestCheckResourceAttr("vcd_nsxt_distributed_firewall_rule.r2", "comment", ""),
+					resource.TestCheckResourceAttr("vcd_nsxt_distributed_firewall_rule.r2", "owner", "team-b"),
+
+					resource.TestCheckResourceAttr("data.vcd_nsxt_distributed_firewall_rule.r1", "owner", "team-a"),
+					resource.TestCheckResourceAttr("data.vcd_nsxt_distributed_firewall_rule.r1", "comment", "web traffic"),
+
+					resource.TestCheckResourceAttr(policyDef, "rule.#", "3"),
+					resource.TestCheckResourceAttr(policyDef, "owners.#", "2"),
+					resource.TestCheckResourceAttr(policyDef, "owned_rule_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(policyDef, "owned_rule_ids.0", "vcd_nsxt_distributed_firewall_rule.r1", "id"),
+					resource.TestCheckResourceAttr(policyDef, "other_owner_rule_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(policyDef, "other_owner_rule_ids.0", "vcd_nsxt_distributed_firewall_rule.r2", "id"),
+					resource.TestCheckResourceAttr(policyDef, "unmanaged_rule_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(policyDef, "unmanaged_rule_ids.0", "data.vcd_nsxt_distributed_firewall_rule.defaultdroprule", "id"),
+					resource.TestCheckTypeSetElemNestedAttrs(policyDef, "rule.*", map[string]string{
+						"name":      "rule1",
+						"comment":   "web traffic",
+						"owner":     "team-a",
+						"ownership": "owned",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(policyDef, "rule.*", map[string]string{
+						"name":      "rule2",
+						"owner":     "team-b",
+						"ownership": "other_owner",
+					}),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const dfwRuleStep2Owner = testAccVcdVdcGroupNew + `
+data "vcd_nsxt_distributed_firewall_rule" "defaultdroprule" {
+  org          = "{{.Org}}"
+  vdc_group_id = vcd_vdc_group.test1.id
+
+  name = "Default_VdcGroup_{{.TestName}}"
+}
+
+resource "vcd_nsxt_distributed_firewall_rule" "r1" {
+  org          = "{{.Org}}"
+  vdc_group_id = vcd_vdc_group.test1.id
+
+  above_rule_id = data.vcd_nsxt_distributed_firewall_rule.defaultdroprule.id
+
+  name    = "rule1"
+  action  = "ALLOW"
+  comment = "web traffic"
+  owner   = "team-a"
+}
+
+resource "vcd_nsxt_distributed_firewall_rule" "r2" {
+  org          = "{{.Org}}"
+  vdc_group_id = vcd_vdc_group.test1.id
+
+  above_rule_id = vcd_nsxt_distributed_firewall_rule.r1.id
+
+  name   = "rule2"
+  action = "DROP"
+  owner  = "team-b"
+}
+
+data "vcd_nsxt_distributed_firewall_rule" "r1" {
+  org          = "{{.Org}}"
+  vdc_group_id = vcd_vdc_group.test1.id
+
+  name = vcd_nsxt_distributed_firewall_rule.r1.name
+}
+
+data "vcd_nsxt_distributed_firewall_effective_policy" "team-a" {
+  org          = "{{.Org}}"
+  vdc_group_id = vcd_vdc_group.test1.id
+  owner        = "team-a"
+
+  depends_on = [vcd_nsxt_distributed_firewall_rule.r1, vcd_nsxt_distributed_firewall_rule.r2]
+}
+
+check "no_unexpected_rules" {
+  assert {
+    condition     = length(data.vcd_nsxt_distributed_firewall_effective_policy.team-a.unmanaged_rule_ids) <= 1
+    error_message = "unexpected unmanaged rules in the Distributed Firewall"
+  }
+}
+`
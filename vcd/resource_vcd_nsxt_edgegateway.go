@@ -277,6 +277,7 @@ func resourceVcdNsxtEdgeGatewayCreate(ctx context.Context, d *schema.ResourceDat
 	log.Printf("[TRACE] NSX-T Edge Gateway creation initiated")
 
 	vcdClient := meta.(*VCDClient)
+	defer vcdClient.readCache.reset()
 
 	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
 	if err != nil {
@@ -328,6 +329,7 @@ func resourceVcdNsxtEdgeGatewayUpdate(ctx context.Context, d *schema.ResourceDat
 	}
 
 	vcdClient := meta.(*VCDClient)
+	defer vcdClient.readCache.reset()
 	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
 	if err != nil {
 		return diag.Errorf("error retrieving Org: %s", err)
@@ -389,6 +391,7 @@ func resourceVcdNsxtEdgeGatewayDelete(_ context.Context, d *schema.ResourceData,
 	log.Printf("[TRACE] edge gateway deletion initiated")
 
 	vcdClient := meta.(*VCDClient)
+	defer vcdClient.readCache.reset()
 	org, err := vcdClient.GetOrgFromResource(d)
 	if err != nil {
 		return diag.Errorf("error retrieving Org: %s", err)
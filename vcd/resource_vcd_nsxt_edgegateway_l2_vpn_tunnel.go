@@ -19,6 +19,7 @@ func resourceVcdNsxtEdgegatewayL2VpnTunnel() *schema.Resource {
 		ReadContext:   resourceVcdNsxtEdgegatewayL2VpnTunnelRead,
 		UpdateContext: resourceVcdNsxtEdgegatewayL2VpnTunnelUpdate,
 		DeleteContext: resourceVcdNsxtEdgegatewayL2VpnTunnelDestroy,
+		CustomizeDiff: resourceVcdNsxtEdgegatewayL2VpnTunnelCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNsxtEdgegatewayL2VpnTunnelImport,
 		},
@@ -128,6 +129,22 @@ var stretchedNetwork = &schema.Resource{
 	},
 }
 
+// resourceVcdNsxtEdgegatewayL2VpnTunnelCustomizeDiff marks the peer code of a SERVER session as unknown when the
+// session is updated, as VCD generates it again from the whole configuration. This way, the CLIENT sessions that
+// use it are updated in the same apply
+func resourceVcdNsxtEdgegatewayL2VpnTunnelCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("session_mode") {
+		return nil
+	}
+	if d.Get("session_mode").(string) != "SERVER" {
+		return nil
+	}
+	if d.Id() == "" || len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	return d.SetNewComputed("peer_code")
+}
+
 func resourceVcdNsxtEdgegatewayL2VpnTunnelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
@@ -14,8 +14,9 @@ import (
 // TestAccVcdNsxtEdgeL2VpnTunnel tests the functionality of the L2 VPN Tunnel for both SERVER and CLIENT sessions
 // It works in the following order:
 // Create both SERVER and CLIENT sessions
-// Try updating the CLIENT session, we can't update the SERVER session at the
-// same time as it alters the peer_code and causes an inconsistent plan
+// Try updating the CLIENT session
+// Rotate the peer_code of the SERVER session by changing its pre-shared key, which updates the CLIENT session in the
+// same apply, and read the status of both sessions
 // Remove the CLIENT session and update the SERVER session in different ways.
 func TestAccVcdNsxtEdgeL2VpnTunnel(t *testing.T) {
 	preTestChecks(t)
@@ -38,6 +39,7 @@ func TestAccVcdNsxtEdgeL2VpnTunnel(t *testing.T) {
 		"RemoteEndpointIpUpdated": "4.3.2.1",
 		"PreSharedKey":            t.Name(),
 		"PreSharedKeyUpdated":     t.Name() + "-update",
+		"PreSharedKeyRotated":     t.Name() + "-rotate",
 	}
 	testParamsNotEmpty(t, params)
 
@@ -53,6 +55,10 @@ func TestAccVcdNsxtEdgeL2VpnTunnel(t *testing.T) {
 	configText3 := templateFill(testAccVcdNsxtEdgegatewayL2VpnTunnelStep3, params)
 	debugPrintf("#[DEBUG] CONFIGURATION for step 3: %s\n", configText3)
 
+	params["FuncName"] = t.Name() + "step3rotate"
+	configText3Rotate := templateFill(testAccVcdNsxtEdgegatewayL2VpnTunnelStep3Rotate, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 3 (rotate): %s\n", configText3Rotate)
+
 	params["FuncName"] = t.Name() + "step4"
 	configText4 := templateFill(testAccVcdNsxtEdgegatewayL2VpnTunnelStep4, params)
 	debugPrintf("#[DEBUG] CONFIGURATION for step 4: %s\n", configText4)
@@ -68,6 +74,9 @@ func TestAccVcdNsxtEdgeL2VpnTunnel(t *testing.T) {
 
 	serverTunnelName := "vcd_nsxt_edgegateway_l2_vpn_tunnel." + params["ServerTunnelName"].(string)
 	clientTunnelName := "vcd_nsxt_edgegateway_l2_vpn_tunnel." + params["ClientTunnelName"].(string)
+	serverStatusName := "data.vcd_nsxt_edgegateway_l2_vpn_tunnel_status.server"
+	clientStatusName := "data.vcd_nsxt_edgegateway_l2_vpn_tunnel_status.client"
+	cachedPeerCode := &testCachedFieldValue{}
 
 	resource.Test(t, resource.TestCase{
 		ProviderFactories: testAccProviders,
@@ -119,6 +128,7 @@ func TestAccVcdNsxtEdgeL2VpnTunnel(t *testing.T) {
 			{
 				Config: configText3,
 				Check: resource.ComposeAggregateTestCheckFunc(
+					cachedPeerCode.cacheTestResourceFieldValue(serverTunnelName, "peer_code"),
 					resource.TestCheckResourceAttr(serverTunnelName, "name", params["ServerTunnelName"].(string)),
 					resource.TestCheckResourceAttr(serverTunnelName, "description", params["ServerTunnelName"].(string)),
 					resource.TestCheckResourceAttr(serverTunnelName, "enabled", "true"),
@@ -137,6 +147,22 @@ func TestAccVcdNsxtEdgeL2VpnTunnel(t *testing.T) {
 					resource.TestCheckResourceAttr(clientTunnelName, "stretched_network.#", "0"),
 				),
 			},
+			{
+				Config: configText3Rotate,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(serverTunnelName, "pre_shared_key", params["PreSharedKeyRotated"].(string)),
+					cachedPeerCode.testCheckCachedResourceFieldValueChanged(serverTunnelName, "peer_code"),
+					resource.TestCheckResourceAttrPair(clientTunnelName, "peer_code", serverTunnelName, "peer_code"),
+
+					resource.TestCheckResourceAttrPair(serverStatusName, "id", serverTunnelName, "id"),
+					resource.TestCheckResourceAttr(serverStatusName, "session_mode", "SERVER"),
+					resource.TestCheckResourceAttrSet(serverStatusName, "status"),
+					resource.TestCheckResourceAttrSet(serverStatusName, "stretched_network.#"),
+					resource.TestCheckResourceAttrPair(clientStatusName, "id", clientTunnelName, "id"),
+					resource.TestCheckResourceAttr(clientStatusName, "session_mode", "CLIENT"),
+					resource.TestCheckResourceAttrSet(clientStatusName, "status"),
+				),
+			},
 			{
 				Config: configText4,
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -336,6 +362,57 @@ resource "vcd_nsxt_edgegateway_l2_vpn_tunnel" "{{.ClientTunnelName}}" {
 }
 `
 
+const testAccVcdNsxtEdgegatewayL2VpnTunnelStep3Rotate = testAccVcdNsxtEdgegatewayL2VpnTunnelData + `
+resource "vcd_nsxt_edgegateway_l2_vpn_tunnel" "{{.ServerTunnelName}}" {
+  name        = "{{.ServerTunnelName}}"
+  description = "{{.ServerTunnelName}}"
+
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.{{.EdgeGw}}.id
+
+  session_mode              = "SERVER"
+  enabled                   = true
+  connector_initiation_mode = "INITIATOR"
+
+  local_endpoint_ip  = data.vcd_nsxt_edgegateway.{{.EdgeGw}}.primary_ip
+  tunnel_interface   = "{{.TunnelInterface}}"
+  remote_endpoint_ip = "{{.RemoteEndpointIp}}"
+
+  pre_shared_key = "{{.PreSharedKeyRotated}}"
+}
+
+resource "vcd_nsxt_edgegateway_l2_vpn_tunnel" "{{.ClientTunnelName}}" {
+  name        = "{{.ClientTunnelName}}"
+  description = "{{.ClientTunnelName}}"
+
+  org             = "{{.Org}}"
+  edge_gateway_id = vcd_nsxt_edgegateway.{{.NewEdgeGw}}.id
+
+  session_mode = "CLIENT"
+  enabled      = true
+
+  local_endpoint_ip  = vcd_nsxt_edgegateway.{{.NewEdgeGw}}.primary_ip
+  remote_endpoint_ip = "{{.RemoteEndpointIp}}"
+
+  peer_code  = vcd_nsxt_edgegateway_l2_vpn_tunnel.{{.ServerTunnelName}}.peer_code
+  depends_on = [vcd_nsxt_edgegateway_l2_vpn_tunnel.{{.ServerTunnelName}}]
+}
+
+data "vcd_nsxt_edgegateway_l2_vpn_tunnel_status" "server" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.{{.EdgeGw}}.id
+  name            = vcd_nsxt_edgegateway_l2_vpn_tunnel.{{.ServerTunnelName}}.name
+}
+
+data "vcd_nsxt_edgegateway_l2_vpn_tunnel_status" "client" {
+  org             = "{{.Org}}"
+  edge_gateway_id = vcd_nsxt_edgegateway.{{.NewEdgeGw}}.id
+  name            = vcd_nsxt_edgegateway_l2_vpn_tunnel.{{.ClientTunnelName}}.name
+
+  depends_on = [vcd_nsxt_edgegateway_l2_vpn_tunnel.{{.ClientTunnelName}}]
+}
+`
+
 const testAccVcdNsxtEdgegatewayL2VpnTunnelStep4 = testAccVcdNsxtEdgegatewayL2VpnTunnelData + `
 resource "vcd_nsxt_edgegateway_l2_vpn_tunnel" "{{.ServerTunnelName}}" {
   name        = "{{.ServerTunnelName}}"
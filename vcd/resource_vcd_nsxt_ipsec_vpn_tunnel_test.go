@@ -102,6 +102,10 @@ func TestAccVcdNsxtIpSecVpnTunnel(t *testing.T) {
 				Config: configText2,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resourceFieldsEqual("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "data.vcd_nsxt_ipsec_vpn_tunnel.tunnel1", ignoreDataSourceFields),
+					resource.TestCheckResourceAttrPair("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "id", "data.vcd_nsxt_ipsec_vpn_tunnel_status.tunnel1", "id"),
+					resource.TestCheckResourceAttrSet("data.vcd_nsxt_ipsec_vpn_tunnel_status.tunnel1", "status"),
+					resource.TestCheckResourceAttrSet("data.vcd_nsxt_ipsec_vpn_tunnel_status.tunnel1", "ike_version"),
+					resource.TestCheckResourceAttrSet("data.vcd_nsxt_ipsec_vpn_tunnel_status.tunnel1", "security_association.#"),
 
 					resource.TestCheckResourceAttrSet("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "id"),
 					resource.TestCheckResourceAttr("vcd_nsxt_ipsec_vpn_tunnel.tunnel1", "name", "test-tunnel-1"),
@@ -277,6 +281,15 @@ data "vcd_nsxt_ipsec_vpn_tunnel" "tunnel1" {
 }
 `
 
+const testAccNsxtIpSecVpnTunnelStatusDS = `
+data "vcd_nsxt_ipsec_vpn_tunnel_status" "tunnel1" {
+  org = "{{.Org}}"
+
+  edge_gateway_id = data.vcd_nsxt_edgegateway.existing_gw.id
+  name            = "{{.ResourceName}}"
+}
+`
+
 const testAccNsxtIpSecVpnTunnel1 = testAccNsxtIpSetPrereqs + `
 resource "vcd_nsxt_ipsec_vpn_tunnel" "tunnel1" {
   org = "{{.Org}}"
@@ -296,7 +309,7 @@ resource "vcd_nsxt_ipsec_vpn_tunnel" "tunnel1" {
   remote_networks   = ["192.168.1.0/24", "192.168.10.0/24", "192.168.20.0/28"]
 }
 `
-const testAccNsxtIpSecVpnTunnel1DS = testAccNsxtIpSecVpnTunnel1 + testAccNsxtIpSecVpnTunnelDS
+const testAccNsxtIpSecVpnTunnel1DS = testAccNsxtIpSecVpnTunnel1 + testAccNsxtIpSecVpnTunnelDS + testAccNsxtIpSecVpnTunnelStatusDS
 
 const testAccNsxtIpSecVpnTunnel2 = testAccNsxtIpSetPrereqs + `
 resource "vcd_nsxt_ipsec_vpn_tunnel" "tunnel1" {
@@ -25,6 +25,7 @@ func resourceVcdNsxtNatRule() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNsxtNatRuleImport,
 		},
+		CustomizeDiff: nsxtNatRuleCustomizeDiff(),
 
 		Schema: map[string]*schema.Schema{
 			"org": {
@@ -203,6 +203,7 @@ func resourceOrg() *schema.Resource {
 // creates an organization based on defined resource
 func resourceOrgCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	vcdClient := m.(*VCDClient)
+	defer vcdClient.readCache.reset()
 
 	orgName, fullName, err := getOrgNames(d)
 	if err != nil {
@@ -337,6 +338,7 @@ func resourceOrgDelete(_ context.Context, d *schema.ResourceData, m interface{})
 
 	//DELETING
 	vcdClient := m.(*VCDClient)
+	defer vcdClient.readCache.reset()
 	deleteForce := d.Get("delete_force").(bool)
 	deleteRecursive := d.Get("delete_recursive").(bool)
 
@@ -378,6 +380,7 @@ func resourceOrgDelete(_ context.Context, d *schema.ResourceData, m interface{})
 func resourceOrgUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	vcdClient := m.(*VCDClient)
+	defer vcdClient.readCache.reset()
 
 	orgName, fullName, err := getOrgNames(d)
 	if err != nil {
@@ -20,6 +20,7 @@ func resourceVcdOrgGroup() *schema.Resource {
 		ReadContext:   resourceVcdOrgGroupRead,
 		UpdateContext: resourceVcdOrgGroupUpdate,
 		DeleteContext: resourceVcdOrgGroupDelete,
+		CustomizeDiff: resourceVcdOrgGroupCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdOrgGroupImport,
 		},
@@ -63,6 +64,20 @@ func resourceVcdOrgGroup() *schema.Resource {
 				},
 				Description: "Read only. Set of user names that belong to the group",
 			},
+			"ldap_user_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Imports all the LDAP users matching this search filter into the Org, with the role of " +
+					"this group. The matching users are listed in 'ldap_user_names' during plan",
+			},
+			"ldap_user_names": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Set of user names imported from LDAP with 'ldap_user_filter'",
+			},
 		},
 	}
 }
@@ -96,6 +111,12 @@ func resourceVcdOrgGroupCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(createdGroup.Group.ID)
 
+	err = syncOrgGroupLdapUsers(d, adminOrg)
+	vcdClient.invalidateOrg(adminOrg.AdminOrg.Name)
+	if err != nil {
+		return diag.Errorf("error importing LDAP users for group %s: %s", groupDefinition.Name, err)
+	}
+
 	return resourceVcdOrgGroupRead(ctx, d, meta)
 }
 
@@ -131,6 +152,23 @@ func resourceVcdOrgGroupRead(_ context.Context, d *schema.ResourceData, meta int
 		return diag.Errorf("could not set user_names field: %s", err)
 	}
 
+	// Users imported with 'ldap_user_filter' that were removed outside of Terraform are imported again by the next apply
+	var ldapUsers []string
+	for _, userName := range convertSchemaSetToSliceOfStrings(d.Get("ldap_user_names").(*schema.Set)) {
+		_, err := adminOrg.GetUserByName(userName, false)
+		if govcd.ContainsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return diag.Errorf("error retrieving LDAP user %s: %s", userName, err)
+		}
+		ldapUsers = append(ldapUsers, userName)
+	}
+	err = d.Set("ldap_user_names", convertStringsToTypeSet(ldapUsers))
+	if err != nil {
+		return diag.Errorf("could not set ldap_user_names field: %s", err)
+	}
+
 	return nil
 }
 
@@ -166,6 +204,15 @@ func resourceVcdOrgGroupUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error updating group %s: %s", group.Group.Name, err)
 	}
 
+	// Imported users get the role of the group, so they are updated also when the role changes
+	if d.HasChanges("ldap_user_names", "role") {
+		err = syncOrgGroupLdapUsers(d, adminOrg)
+		vcdClient.invalidateOrg(adminOrg.AdminOrg.Name)
+		if err != nil {
+			return diag.Errorf("error importing LDAP users for group %s: %s", group.Group.Name, err)
+		}
+	}
+
 	return resourceVcdOrgGroupRead(ctx, d, meta)
 }
 
@@ -181,6 +228,13 @@ func resourceVcdOrgGroupDelete(_ context.Context, d *schema.ResourceData, meta i
 		return diag.Errorf("error finding group for deletion %s: %s", d.Id(), err)
 	}
 
+	// The imported users are deleted first, so that the group is kept when some of them can't be deleted
+	defer vcdClient.invalidateOrg(adminOrg.AdminOrg.Name)
+	_, err = deleteOrgGroupLdapUsers(adminOrg, convertSchemaSetToSliceOfStrings(d.Get("ldap_user_names").(*schema.Set)))
+	if err != nil {
+		return diag.Errorf("could not delete LDAP users of group %s: %s", group.Group.Name, err)
+	}
+
 	err = group.Delete()
 	if err != nil {
 		return diag.Errorf("could not delete group %s: %s", group.Group.Name, err)
@@ -189,6 +243,171 @@ func resourceVcdOrgGroupDelete(_ context.Context, d *schema.ResourceData, meta i
 	return nil
 }
 
+// resourceVcdOrgGroupCustomizeDiff runs the LDAP search of 'ldap_user_filter' during plan, so that the users that
+// are going to be imported or deleted are shown in 'ldap_user_names'
+func resourceVcdOrgGroupCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("ldap_user_filter") || !d.NewValueKnown("org") {
+		return d.SetNewComputed("ldap_user_names")
+	}
+	filter := d.Get("ldap_user_filter").(string)
+	if filter == "" {
+		if d.Id() == "" || d.Get("ldap_user_names").(*schema.Set).Len() > 0 {
+			return d.SetNew("ldap_user_names", []string{})
+		}
+		return nil
+	}
+
+	vcdClient := meta.(*VCDClient)
+	adminOrg, err := vcdClient.GetAdminOrg(d.Get("org").(string))
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+	// The users of the Org decide which users are managed, so they can't come from a cached Org
+	err = adminOrg.Refresh()
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+	results, err := searchOrgLdap(vcdClient, adminOrg, "user", filter)
+	if err != nil {
+		return err
+	}
+	existingUsers := make(map[string]bool)
+	if adminOrg.AdminOrg.Users != nil {
+		for _, userRef := range adminOrg.AdminOrg.Users.User {
+			existingUsers[userRef.Name] = true
+		}
+	}
+	userNames := getOrgGroupLdapUserNames(results, existingUsers, d.Get("ldap_user_names").(*schema.Set))
+	if convertStringsToTypeSet(userNames).Equal(d.Get("ldap_user_names")) {
+		return nil
+	}
+	return d.SetNew("ldap_user_names", userNames)
+}
+
+// getOrgGroupLdapUserNames returns the names of the LDAP search results that are managed by the group. Users that
+// are already in the Org and were not imported by this group are not managed by it
+func getOrgGroupLdapUserNames(results []orgLdapSearchResult, existingUsers map[string]bool, importedUsers *schema.Set) []string {
+	userNames := []string{}
+	for _, result := range results {
+		if existingUsers[result.Name] && !importedUsers.Contains(result.Name) {
+			continue
+		}
+		userNames = append(userNames, result.Name)
+	}
+	return userNames
+}
+
+// syncOrgGroupLdapUsers imports the LDAP users added to 'ldap_user_names', deletes the ones removed from it, and
+// gives the role of the group to the ones already imported when the role changes
+func syncOrgGroupLdapUsers(d *schema.ResourceData, adminOrg *govcd.AdminOrg) (err error) {
+	oldValue, newValue := d.GetChange("ldap_user_names")
+	oldUsers, newUsers := oldValue.(*schema.Set), newValue.(*schema.Set)
+	oldRoleName, roleName := d.GetChange("role")
+
+	// When the synchronization stops halfway, the state keeps the users actually managed by the group, and the
+	// previous role until all the users have the new one, so that the next apply completes the changes
+	var deletedUsers, createdUsers []string
+	// A new group has no previous role
+	usersHaveRole := oldRoleName.(string) == "" || !d.HasChange("role")
+	defer func() {
+		if err == nil {
+			return
+		}
+		_ = d.Set("ldap_user_names", getOrgGroupManagedLdapUsers(oldUsers, deletedUsers, createdUsers))
+		if !usersHaveRole {
+			dSet(d, "role", oldRoleName.(string))
+		}
+	}()
+
+	// The Org can come from the cache, and the users are looked up in its list
+	err = adminOrg.Refresh()
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+
+	deletedUsers, err = deleteOrgGroupLdapUsers(adminOrg, convertSchemaSetToSliceOfStrings(oldUsers.Difference(newUsers)))
+	if err != nil {
+		return err
+	}
+
+	if !usersHaveRole {
+		for _, userName := range convertSchemaSetToSliceOfStrings(oldUsers.Intersection(newUsers)) {
+			user, err := adminOrg.GetUserByName(userName, false)
+			if govcd.ContainsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("error retrieving user %s: %s", userName, err)
+			}
+			if user.GetRoleName() == roleName.(string) {
+				continue
+			}
+			err = user.ChangeRole(roleName.(string))
+			if err != nil {
+				return fmt.Errorf("error changing role of LDAP user %s: %s", userName, err)
+			}
+		}
+		usersHaveRole = true
+	}
+
+	for _, userName := range convertSchemaSetToSliceOfStrings(newUsers.Difference(oldUsers)) {
+		_, err = adminOrg.CreateUserSimple(govcd.OrgUserConfiguration{
+			Name:         userName,
+			RoleName:     roleName.(string),
+			ProviderType: govcd.OrgUserProviderIntegrated,
+			IsEnabled:    true,
+			IsExternal:   true,
+		})
+		if err != nil {
+			return fmt.Errorf("error importing LDAP user %s: %s", userName, err)
+		}
+		createdUsers = append(createdUsers, userName)
+	}
+	return nil
+}
+
+// getOrgGroupManagedLdapUsers returns the LDAP users managed by the group after a partial synchronization: the
+// previous ones, without the deleted ones, and with the created ones
+func getOrgGroupManagedLdapUsers(oldUsers *schema.Set, deletedUsers, createdUsers []string) *schema.Set {
+	managedUsers := oldUsers.Difference(convertStringsToTypeSet(deletedUsers))
+	for _, userName := range createdUsers {
+		managedUsers.Add(userName)
+	}
+	return managedUsers
+}
+
+// deleteOrgGroupLdapUsers deletes the given LDAP users, skipping the ones that don't exist anymore, and returns the
+// users that are gone. Users that can't be deleted, usually because they own catalogs, networks or running vApps,
+// are reported together
+func deleteOrgGroupLdapUsers(adminOrg *govcd.AdminOrg, userNames []string) ([]string, error) {
+	var deletedUsers []string
+	var failedUsers []string
+	var errorMessages []string
+	for _, userName := range userNames {
+		user, err := adminOrg.GetUserByName(userName, false)
+		if govcd.ContainsNotFound(err) {
+			deletedUsers = append(deletedUsers, userName)
+			continue
+		}
+		if err != nil {
+			return deletedUsers, fmt.Errorf("error retrieving user %s: %s", userName, err)
+		}
+		err = user.Delete(false)
+		if err != nil {
+			failedUsers = append(failedUsers, userName)
+			errorMessages = append(errorMessages, fmt.Sprintf("%s: %s", userName, err))
+			continue
+		}
+		deletedUsers = append(deletedUsers, userName)
+	}
+	if len(failedUsers) > 0 {
+		return deletedUsers, fmt.Errorf("LDAP users [%s] could not be deleted. If they still own catalogs, networks or running vApps, "+
+			"transfer or remove those objects and apply again: %s",
+			strings.Join(failedUsers, ", "), strings.Join(errorMessages, "; "))
+	}
+	return deletedUsers, nil
+}
+
 // resourceVcdOrgGroupImport imports an org group into Terraform state
 // This function task is to get the data from vCD and fill the resource data container
 // Expects the d.ID() to be a path to the resource made of Org name + dot + OrgGroup name
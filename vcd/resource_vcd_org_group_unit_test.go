@@ -0,0 +1,99 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test_getOrgGroupLdapUserNames checks that the users already in the Org are only managed by the group when it
+// imported them
+func Test_getOrgGroupLdapUserNames(t *testing.T) {
+	results := []orgLdapSearchResult{{Name: "leela"}, {Name: "fry"}, {Name: "bender"}}
+	tests := []struct {
+		name          string
+		existingUsers map[string]bool
+		importedUsers []string
+		want          []string
+	}{
+		{
+			name: "no existing users",
+			want: []string{"leela", "fry", "bender"},
+		},
+		{
+			name:          "existing user not managed",
+			existingUsers: map[string]bool{"fry": true},
+			want:          []string{"leela", "bender"},
+		},
+		{
+			name:          "existing users imported by the group",
+			existingUsers: map[string]bool{"leela": true, "fry": true},
+			importedUsers: []string{"leela", "fry"},
+			want:          []string{"leela", "fry", "bender"},
+		},
+		{
+			name:          "imported user which does not match anymore",
+			existingUsers: map[string]bool{"leela": true, "hermes": true},
+			importedUsers: []string{"leela", "hermes"},
+			want:          []string{"leela", "fry", "bender"},
+		},
+		{
+			name:          "all users existing and not managed",
+			existingUsers: map[string]bool{"leela": true, "fry": true, "bender": true},
+			want:          []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getOrgGroupLdapUserNames(results, tt.existingUsers, convertStringsToTypeSet(tt.importedUsers))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getOrgGroupLdapUserNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_getOrgGroupManagedLdapUsers checks the users kept in the state when the synchronization of the LDAP users
+// stops halfway
+func Test_getOrgGroupManagedLdapUsers(t *testing.T) {
+	tests := []struct {
+		name         string
+		oldUsers     []string
+		deletedUsers []string
+		createdUsers []string
+		want         []string
+	}{
+		{
+			name:         "deletion failed",
+			oldUsers:     []string{"leela", "fry", "bender"},
+			deletedUsers: []string{"fry"},
+			want:         []string{"leela", "bender"},
+		},
+		{
+			name:         "creation failed",
+			oldUsers:     []string{"leela", "fry"},
+			deletedUsers: []string{"fry"},
+			createdUsers: []string{"hermes"},
+			want:         []string{"leela", "hermes"},
+		},
+		{
+			name:         "new group",
+			createdUsers: []string{"leela", "fry"},
+			want:         []string{"leela", "fry"},
+		},
+		{
+			name:     "nothing done",
+			oldUsers: []string{"leela"},
+			want:     []string{"leela"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getOrgGroupManagedLdapUsers(convertStringsToTypeSet(tt.oldUsers), tt.deletedUsers, tt.createdUsers)
+			if !got.Equal(convertStringsToTypeSet(tt.want)) {
+				t.Errorf("getOrgGroupManagedLdapUsers() = %v, want %v", got.List(), tt.want)
+			}
+		})
+	}
+}
@@ -2,14 +2,29 @@ package vcd
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v2/govcd"
-	"log"
-	"os"
 )
 
+var samlMetadataSources = []string{"identity_provider_metadata_text", "identity_provider_metadata_file", "identity_provider_metadata_url"}
+
 // resourceVcdOrgSaml handles Org SAML settings
 func resourceVcdOrgSaml() *schema.Resource {
 	return &schema.Resource{
@@ -17,6 +32,7 @@ func resourceVcdOrgSaml() *schema.Resource {
 		CreateContext: resourceVcdOrgSamlCreate,
 		UpdateContext: resourceVcdOrgSamlUpdate,
 		DeleteContext: resourceVcdOrgSamlDelete,
+		CustomizeDiff: orgSamlCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdOrgSamlImport,
 		},
@@ -41,13 +57,58 @@ func resourceVcdOrgSaml() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Description:  "The name of the file containing the metadata from the identity provider",
-				ExactlyOneOf: []string{"identity_provider_metadata_text", "identity_provider_metadata_file"},
+				ExactlyOneOf: samlMetadataSources,
 			},
 			"identity_provider_metadata_text": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Description:  "The text of the metadata from the identity provider",
-				ExactlyOneOf: []string{"identity_provider_metadata_text", "identity_provider_metadata_file"},
+				ExactlyOneOf: samlMetadataSources,
+			},
+			"identity_provider_metadata_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The URL from which the metadata of the identity provider is retrieved",
+				ExactlyOneOf: samlMetadataSources,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"signing_certificate_rollover_hours": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "When the signing certificates of the identity provider change, the replaced certificates " +
+					"are still accepted for this number of hours. With 0 (default), they are replaced immediately",
+			},
+			"signing_certificate": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Signing certificates of the identity provider, as found in the metadata stored in VCD",
+				Elem:        samlSigningCertificateSchema,
+			},
+			"previous_signing_certificate": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Replaced signing certificates which are still accepted during the rollover window",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"certificate": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Base64 encoded certificate",
+						},
+						"fingerprint": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "SHA-256 fingerprint of the certificate",
+						},
+						"retained_until": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date until which the certificate is accepted",
+						},
+					},
+				},
 			},
 			"email": {
 				Type:        schema.TypeString,
@@ -102,21 +163,48 @@ func resourceVcdOrgSamlCreateOrUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("[Org SAML %s] error searching for Org %s: %s", origin, orgId, err)
 	}
 
-	fileName := d.Get("identity_provider_metadata_file").(string)
-	metadataText := d.Get("identity_provider_metadata_text").(string)
-
-	if fileName != "" {
-		metadataFromFile, err := os.ReadFile(fileName) // #nosec G304 -- We need user input for this file
-		if err != nil {
-			return diag.Errorf("[Org SAML %s %s] error reading metadata file %s: %s", origin, adminOrg.AdminOrg.Name, fileName, err)
-		}
-		metadataText = string(metadataFromFile)
+	metadataText, err := getSamlMetadata(vcdClient, d.Get("identity_provider_metadata_file").(string),
+		d.Get("identity_provider_metadata_text").(string), d.Get("identity_provider_metadata_url").(string))
+	if err != nil {
+		return diag.Errorf("[Org SAML %s %s] %s", origin, adminOrg.AdminOrg.Name, err)
+	}
+	wantedCertificates, err := getSamlSigningCertificates(metadataText)
+	if err != nil {
+		return diag.Errorf("[Org SAML %s %s] error reading signing certificates from metadata: %s", origin, adminOrg.AdminOrg.Name, err)
 	}
 
 	settings, err := adminOrg.GetFederationSettings()
 	if err != nil {
 		return diag.Errorf("[Org SAML %s %s] error reading federation settings values: %s", origin, adminOrg.AdminOrg.Name, err)
 	}
+
+	// During the rollover window, the signing certificates replaced in the identity provider metadata are added back
+	// to the metadata sent to VCD, so that assertions signed with either certificate are accepted
+	now := time.Now()
+	var retainUntil time.Time
+	var currentCertificates []samlSigningCertificate
+	if origin == "update" {
+		retainUntil = now.Add(time.Duration(d.Get("signing_certificate_rollover_hours").(int)) * time.Hour)
+		currentCertificates, err = getSamlSigningCertificates(settings.SAMLMetadata)
+		if err != nil {
+			log.Printf("[WARN] [Org SAML %s %s] error reading signing certificates from current metadata: %s", origin, adminOrg.AdminOrg.Name, err)
+		}
+	}
+	// The planned value can be unknown, as it is recomputed here
+	previousCertificates, _ := d.GetChange("previous_signing_certificate")
+	retainedCertificates := getRetainedSamlSigningCertificates(expandSamlRetainedCertificates(previousCertificates.([]interface{})),
+		currentCertificates, wantedCertificates, retainUntil, now)
+	if len(retainedCertificates) > 0 {
+		metadataText, err = addSamlSigningCertificates(metadataText, retainedCertificates)
+		if err != nil {
+			return diag.Errorf("[Org SAML %s %s] error adding previous signing certificates to metadata: %s", origin, adminOrg.AdminOrg.Name, err)
+		}
+	}
+	err = d.Set("previous_signing_certificate", flattenSamlRetainedCertificates(retainedCertificates))
+	if err != nil {
+		return diag.Errorf("[Org SAML %s %s] error setting previous signing certificates: %s", origin, adminOrg.AdminOrg.Name, err)
+	}
+
 	settings.SAMLMetadata = metadataText
 	settings.Enabled = enabled
 	settings.SamlSPEntityID = entityId
@@ -176,6 +264,15 @@ func genericVcdOrgSamlRead(ctx context.Context, d *schema.ResourceData, meta int
 	dSet(d, "role", settings.SamlAttributeMapping.RoleAttributeName)
 	dSet(d, "group", settings.SamlAttributeMapping.GroupAttributeName)
 
+	signingCertificates, err := getSamlSigningCertificates(settings.SAMLMetadata)
+	if err != nil {
+		log.Printf("[WARN] unable to read signing certificates from organization %s SAML metadata: %s", adminOrg.AdminOrg.Name, err)
+	}
+	err = d.Set("signing_certificate", flattenSamlSigningCertificates(signingCertificates))
+	if err != nil {
+		return diag.Errorf("error setting organization %s signing certificates: %s", adminOrg.AdminOrg.Name, err)
+	}
+
 	d.SetId(adminOrg.AdminOrg.ID)
 
 	return nil
@@ -219,3 +316,307 @@ func resourceVcdOrgSamlImport(_ context.Context, d *schema.ResourceData, meta in
 	d.SetId(adminOrg.AdminOrg.ID)
 	return []*schema.ResourceData{d}, nil
 }
+
+var samlSigningCertificateSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"fingerprint": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "SHA-256 fingerprint of the certificate",
+		},
+		"subject": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Subject of the certificate",
+		},
+		"not_after": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Expiration date of the certificate",
+		},
+	},
+}
+
+// orgSamlCustomizeDiff plans an update when the signing certificates of the identity provider metadata differ from
+// the ones stored in VCD, such as when the certificates published at 'identity_provider_metadata_url' are rotated, or
+// when a previous signing certificate is past its rollover window
+func orgSamlCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	for _, source := range samlMetadataSources {
+		if !d.NewValueKnown(source) {
+			return nil
+		}
+	}
+	metadataText, err := getSamlMetadata(meta.(*VCDClient), d.Get("identity_provider_metadata_file").(string),
+		d.Get("identity_provider_metadata_text").(string), d.Get("identity_provider_metadata_url").(string))
+	if err != nil {
+		return fmt.Errorf("[Org SAML plan] error checking signing certificates: %s", err)
+	}
+	wantedCertificates, err := getSamlSigningCertificates(metadataText)
+	if err != nil {
+		return fmt.Errorf("[Org SAML plan] error reading signing certificates from metadata: %s", err)
+	}
+	wanted := make(map[string]bool)
+	for _, certificate := range wantedCertificates {
+		wanted[certificate.Fingerprint] = true
+	}
+	retained := getRetainedSamlSigningCertificates(expandSamlRetainedCertificates(d.Get("previous_signing_certificate").([]interface{})),
+		nil, wantedCertificates, time.Time{}, time.Now())
+	for _, certificate := range retained {
+		wanted[certificate.Fingerprint] = true
+	}
+
+	current := make(map[string]bool)
+	for _, certificate := range d.Get("signing_certificate").([]interface{}) {
+		current[certificate.(map[string]interface{})["fingerprint"].(string)] = true
+	}
+	if len(current) == len(wanted) {
+		same := true
+		for fingerprint := range wanted {
+			same = same && current[fingerprint]
+		}
+		if same {
+			return nil
+		}
+	}
+	log.Printf("[INFO] [Org SAML plan] signing certificates of org %s have changed", d.Id())
+	for _, field := range []string{"signing_certificate", "previous_signing_certificate"} {
+		err = d.SetNewComputed(field)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSamlMetadata returns the identity provider metadata from the only one of its sources which is set
+func getSamlMetadata(vcdClient *VCDClient, fileName, text, url string) (string, error) {
+	switch {
+	case fileName != "":
+		metadataFromFile, err := os.ReadFile(fileName) // #nosec G304 -- We need user input for this file
+		if err != nil {
+			return "", fmt.Errorf("error reading metadata file %s: %s", fileName, err)
+		}
+		return string(metadataFromFile), nil
+	case url != "":
+		return fetchSamlMetadata(vcdClient, url)
+	}
+	return text, nil
+}
+
+// fetchSamlMetadata downloads the identity provider metadata with the proxy, CA certificates and client certificate
+// settings of the VCD client. The server certificate is always verified, even with 'allow_unverified_ssl', as the
+// metadata defines the certificates trusted to sign the SAML assertions
+func fetchSamlMetadata(vcdClient *VCDClient, url string) (string, error) {
+	transport, err := samlMetadataTransport(vcdClient.Client.Http.Transport)
+	if err != nil {
+		return "", err
+	}
+	httpClient := &http.Client{Transport: transport, Timeout: time.Minute}
+	resp, err := httpClient.Get(url) // #nosec G107 -- We need user input for this URL
+	if err != nil {
+		return "", fmt.Errorf("error retrieving metadata from %s: %s", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error retrieving metadata from %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading metadata from %s: %s", url, err)
+	}
+	return string(body), nil
+}
+
+// samlMetadataTransport returns a copy of the given VCD client transport which verifies the server certificates.
+// The copy is not throttled, as the metadata is not retrieved from VCD
+func samlMetadataTransport(vcdTransport http.RoundTripper) (*http.Transport, error) {
+	if throttled, ok := vcdTransport.(*throttledTransport); ok {
+		vcdTransport = throttled.transport
+	}
+	transport, ok := vcdTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected HTTP transport type %T", vcdTransport)
+	}
+	transport = transport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.InsecureSkipVerify = false
+	return transport, nil
+}
+
+// samlSigningCertificate is a signing certificate found in the metadata of a SAML identity provider
+type samlSigningCertificate struct {
+	Certificate string // Base64 encoded DER certificate
+	Fingerprint string
+	Subject     string
+	NotAfter    string
+}
+
+// samlRetainedCertificate is a signing certificate that was replaced in the metadata of the identity provider, and
+// that is still accepted until the end of its rollover window
+type samlRetainedCertificate struct {
+	Certificate   string
+	Fingerprint   string
+	RetainedUntil time.Time
+}
+
+// samlIdpMetadata holds the key descriptors of the identity provider in a SAML metadata document
+type samlIdpMetadata struct {
+	IDPSSODescriptor []struct {
+		KeyDescriptor []struct {
+			Use              string   `xml:"use,attr"`
+			X509Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+		} `xml:"KeyDescriptor"`
+	} `xml:"IDPSSODescriptor"`
+}
+
+// getSamlSigningCertificates returns the signing certificates of the identity provider, without duplicates. Key
+// descriptors without 'use' are valid for both signing and encryption
+func getSamlSigningCertificates(metadataText string) ([]samlSigningCertificate, error) {
+	if strings.TrimSpace(metadataText) == "" {
+		return nil, nil
+	}
+	var metadata samlIdpMetadata
+	err := xml.Unmarshal([]byte(metadataText), &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing metadata: %s", err)
+	}
+	var certificates []samlSigningCertificate
+	found := make(map[string]bool)
+	for _, descriptor := range metadata.IDPSSODescriptor {
+		for _, key := range descriptor.KeyDescriptor {
+			if key.Use != "" && key.Use != "signing" {
+				continue
+			}
+			for _, encoded := range key.X509Certificates {
+				certificate, err := parseSamlCertificate(encoded)
+				if err != nil {
+					return nil, err
+				}
+				if !found[certificate.Fingerprint] {
+					found[certificate.Fingerprint] = true
+					certificates = append(certificates, certificate)
+				}
+			}
+		}
+	}
+	return certificates, nil
+}
+
+func parseSamlCertificate(encoded string) (samlSigningCertificate, error) {
+	encoded = strings.Join(strings.Fields(encoded), "")
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return samlSigningCertificate{}, fmt.Errorf("error decoding certificate: %s", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return samlSigningCertificate{}, fmt.Errorf("error parsing certificate: %s", err)
+	}
+	fingerprint := sha256.Sum256(der)
+	return samlSigningCertificate{
+		Certificate: encoded,
+		Fingerprint: hex.EncodeToString(fingerprint[:]),
+		Subject:     certificate.Subject.String(),
+		NotAfter:    certificate.NotAfter.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// getRetainedSamlSigningCertificates returns the signing certificates to keep in the metadata sent to VCD:
+//   - the previous certificates whose rollover window has not ended, unless they are in the wanted metadata again
+//   - the current certificates which are not in the wanted metadata, until retainUntil. They are not retained when
+//     retainUntil is not in the future, or when they were already retained before
+func getRetainedSamlSigningCertificates(previous []samlRetainedCertificate, current, wanted []samlSigningCertificate, retainUntil, now time.Time) []samlRetainedCertificate {
+	isWanted := make(map[string]bool)
+	for _, certificate := range wanted {
+		isWanted[certificate.Fingerprint] = true
+	}
+	wasRetained := make(map[string]bool)
+	var retained []samlRetainedCertificate
+	for _, certificate := range previous {
+		wasRetained[certificate.Fingerprint] = true
+		if !isWanted[certificate.Fingerprint] && certificate.RetainedUntil.After(now) {
+			retained = append(retained, certificate)
+		}
+	}
+	if !retainUntil.After(now) {
+		return retained
+	}
+	for _, certificate := range current {
+		if isWanted[certificate.Fingerprint] || wasRetained[certificate.Fingerprint] {
+			continue
+		}
+		retained = append(retained, samlRetainedCertificate{
+			Certificate:   certificate.Certificate,
+			Fingerprint:   certificate.Fingerprint,
+			RetainedUntil: retainUntil,
+		})
+	}
+	return retained
+}
+
+var samlKeyDescriptorRegexp = regexp.MustCompile(`<([\w.-]+:)?KeyDescriptor[\s>]`)
+
+// addSamlSigningCertificates adds a signing key descriptor for each of the given certificates to the identity
+// provider metadata. The descriptors are inserted before the first existing one, using the same namespace prefix
+func addSamlSigningCertificates(metadataText string, certificates []samlRetainedCertificate) (string, error) {
+	match := samlKeyDescriptorRegexp.FindStringSubmatchIndex(metadataText)
+	if match == nil {
+		return "", fmt.Errorf("no KeyDescriptor found in metadata")
+	}
+	prefix := ""
+	if match[2] >= 0 {
+		prefix = metadataText[match[2]:match[3]]
+	}
+	var descriptors strings.Builder
+	for _, certificate := range certificates {
+		descriptors.WriteString(fmt.Sprintf(`<%sKeyDescriptor use="signing">`+
+			`<ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><ds:X509Data>`+
+			`<ds:X509Certificate>%s</ds:X509Certificate>`+
+			`</ds:X509Data></ds:KeyInfo></%sKeyDescriptor>`, prefix, certificate.Certificate, prefix))
+	}
+	return metadataText[:match[0]] + descriptors.String() + metadataText[match[0]:], nil
+}
+
+func flattenSamlSigningCertificates(certificates []samlSigningCertificate) []interface{} {
+	result := make([]interface{}, len(certificates))
+	for i, certificate := range certificates {
+		result[i] = map[string]interface{}{
+			"fingerprint": certificate.Fingerprint,
+			"subject":     certificate.Subject,
+			"not_after":   certificate.NotAfter,
+		}
+	}
+	return result
+}
+
+func flattenSamlRetainedCertificates(certificates []samlRetainedCertificate) []interface{} {
+	result := make([]interface{}, len(certificates))
+	for i, certificate := range certificates {
+		result[i] = map[string]interface{}{
+			"certificate":    certificate.Certificate,
+			"fingerprint":    certificate.Fingerprint,
+			"retained_until": certificate.RetainedUntil.UTC().Format(time.RFC3339),
+		}
+	}
+	return result
+}
+
+func expandSamlRetainedCertificates(certificates []interface{}) []samlRetainedCertificate {
+	var result []samlRetainedCertificate
+	for _, item := range certificates {
+		certificate := item.(map[string]interface{})
+		// An unparseable date gives a zero time, which makes the certificate expired
+		retainedUntil, _ := time.Parse(time.RFC3339, certificate["retained_until"].(string))
+		result = append(result, samlRetainedCertificate{
+			Certificate:   certificate["certificate"].(string),
+			Fingerprint:   certificate["fingerprint"].(string),
+			RetainedUntil: retainedUntil,
+		})
+	}
+	return result
+}
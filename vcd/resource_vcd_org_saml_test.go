@@ -80,6 +80,9 @@ func TestAccVcdOrgSaml(t *testing.T) {
 						resource.TestCheckResourceAttr(resourceOrgSamlName, "full_name", "fullname"),
 						resource.TestCheckResourceAttr(resourceOrgSamlName, "role", "role"),
 						resource.TestCheckResourceAttr(resourceOrgSamlName, "group", "group"),
+						resource.TestCheckResourceAttr(resourceOrgSamlName, "signing_certificate.#", "2"),
+						resource.TestCheckResourceAttr(resourceOrgSamlName, "previous_signing_certificate.#", "0"),
+						resource.TestCheckResourceAttrPair(resourceOrgSamlName, "signing_certificate.0.fingerprint", datasourceOrgSamlName, "signing_certificate.0.fingerprint"),
 						resource.TestCheckResourceAttr(datasourceOrgSamlName, "entity_id", orgName),
 						resource.TestCheckTypeSetElemAttrPair(resourceOrgSamlName, "enabled", datasourceOrgSamlName, "enabled"),
 						resource.TestCheckTypeSetElemAttrPair(resourceOrgSamlName, "email", datasourceOrgSamlName, "email"),
@@ -0,0 +1,239 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+// Test_samlSigningCertificates checks the extraction of signing certificates from identity provider metadata, and
+// the addition of previous signing certificates to it
+func Test_samlSigningCertificates(t *testing.T) {
+	metadata, err := os.ReadFile("../test-resources/saml-test-idp.xml")
+	if err != nil {
+		t.Skipf("metadata file not found: %s", err)
+	}
+
+	certificates, err := getSamlSigningCertificates(string(metadata))
+	if err != nil {
+		t.Fatalf("error reading signing certificates: %s", err)
+	}
+	// The metadata has two signing certificates and an encryption one
+	if len(certificates) != 2 {
+		t.Fatalf("expected 2 signing certificates, got %d", len(certificates))
+	}
+	for _, certificate := range certificates {
+		if len(certificate.Fingerprint) != 64 {
+			t.Errorf("unexpected fingerprint '%s'", certificate.Fingerprint)
+		}
+		if certificate.Subject != "CN=samltest.id" {
+			t.Errorf("unexpected subject '%s'", certificate.Subject)
+		}
+		if certificate.NotAfter == "" {
+			t.Errorf("empty expiration date for certificate %s", certificate.Fingerprint)
+		}
+	}
+	if certificates[0].Fingerprint == certificates[1].Fingerprint {
+		t.Errorf("expected different certificates")
+	}
+
+	// Remove the first signing certificate from the metadata, and add it back as a previous certificate
+	withoutFirst := removeFirstSamlKeyDescriptor(t, string(metadata))
+	remaining, err := getSamlSigningCertificates(withoutFirst)
+	if err != nil {
+		t.Fatalf("error reading signing certificates: %s", err)
+	}
+	if len(remaining) != 1 || remaining[0].Fingerprint != certificates[1].Fingerprint {
+		t.Fatalf("expected only the second certificate to remain, got %v", remaining)
+	}
+	restored, err := addSamlSigningCertificates(withoutFirst, []samlRetainedCertificate{
+		{Certificate: certificates[0].Certificate, Fingerprint: certificates[0].Fingerprint},
+	})
+	if err != nil {
+		t.Fatalf("error adding signing certificate: %s", err)
+	}
+	restoredCertificates, err := getSamlSigningCertificates(restored)
+	if err != nil {
+		t.Fatalf("error reading signing certificates from restored metadata: %s", err)
+	}
+	if len(restoredCertificates) != 2 || restoredCertificates[0].Fingerprint != certificates[0].Fingerprint {
+		t.Errorf("expected the previous certificate to be added back, got %v", restoredCertificates)
+	}
+
+	emptyCertificates, err := getSamlSigningCertificates("")
+	if err != nil || len(emptyCertificates) != 0 {
+		t.Errorf("expected no certificates and no error for empty metadata, got %v - %v", emptyCertificates, err)
+	}
+	_, err = getSamlSigningCertificates("<EntityDescriptor>")
+	if err == nil {
+		t.Errorf("expected error for invalid metadata")
+	}
+}
+
+func removeFirstSamlKeyDescriptor(t *testing.T, metadata string) string {
+	start := samlKeyDescriptorRegexp.FindStringIndex(metadata)
+	end := len("</KeyDescriptor>")
+	for i := start[0]; i < len(metadata)-end; i++ {
+		if metadata[i:i+end] == "</KeyDescriptor>" {
+			return metadata[:start[0]] + metadata[i+end:]
+		}
+	}
+	t.Fatalf("closing KeyDescriptor not found")
+	return ""
+}
+
+// Test_getRetainedSamlSigningCertificates checks which signing certificates are kept during the rollover window
+func Test_getRetainedSamlSigningCertificates(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	oldCert := samlSigningCertificate{Certificate: "old", Fingerprint: "f-old"}
+	newCert := samlSigningCertificate{Certificate: "new", Fingerprint: "f-new"}
+	olderCert := samlSigningCertificate{Certificate: "older", Fingerprint: "f-older"}
+
+	fingerprints := func(certificates []samlRetainedCertificate) []string {
+		var result []string
+		for _, certificate := range certificates {
+			result = append(result, certificate.Fingerprint)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name        string
+		previous    []samlRetainedCertificate
+		current     []samlSigningCertificate
+		wanted      []samlSigningCertificate
+		retainUntil time.Time
+		want        []string
+	}{
+		{
+			name:        "NoChange",
+			current:     []samlSigningCertificate{oldCert},
+			wanted:      []samlSigningCertificate{oldCert},
+			retainUntil: now.Add(time.Hour),
+			want:        nil,
+		},
+		{
+			name:        "RotationWithRollover",
+			current:     []samlSigningCertificate{oldCert},
+			wanted:      []samlSigningCertificate{newCert},
+			retainUntil: now.Add(time.Hour),
+			want:        []string{"f-old"},
+		},
+		{
+			name:    "RotationWithoutRollover",
+			current: []samlSigningCertificate{oldCert},
+			wanted:  []samlSigningCertificate{newCert},
+			want:    nil,
+		},
+		{
+			name:        "PreviousStillValid",
+			previous:    []samlRetainedCertificate{{Certificate: "old", Fingerprint: "f-old", RetainedUntil: now.Add(time.Minute)}},
+			current:     []samlSigningCertificate{oldCert, newCert},
+			wanted:      []samlSigningCertificate{newCert},
+			retainUntil: now.Add(time.Hour),
+			want:        []string{"f-old"},
+		},
+		{
+			name:        "PreviousExpiredIsNotRetainedAgain",
+			previous:    []samlRetainedCertificate{{Certificate: "old", Fingerprint: "f-old", RetainedUntil: now.Add(-time.Minute)}},
+			current:     []samlSigningCertificate{oldCert, newCert},
+			wanted:      []samlSigningCertificate{newCert},
+			retainUntil: now.Add(time.Hour),
+			want:        nil,
+		},
+		{
+			name:        "PreviousBackInMetadata",
+			previous:    []samlRetainedCertificate{{Certificate: "old", Fingerprint: "f-old", RetainedUntil: now.Add(time.Minute)}},
+			current:     []samlSigningCertificate{oldCert, newCert},
+			wanted:      []samlSigningCertificate{oldCert},
+			retainUntil: now.Add(time.Hour),
+			want:        []string{"f-new"},
+		},
+		{
+			name:        "SecondRotationDuringRollover",
+			previous:    []samlRetainedCertificate{{Certificate: "older", Fingerprint: "f-older", RetainedUntil: now.Add(time.Minute)}},
+			current:     []samlSigningCertificate{olderCert, oldCert},
+			wanted:      []samlSigningCertificate{newCert},
+			retainUntil: now.Add(time.Hour),
+			want:        []string{"f-older", "f-old"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fingerprints(getRetainedSamlSigningCertificates(tt.previous, tt.current, tt.wanted, tt.retainUntil, now))
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+// Test_samlMetadataTransport checks that the metadata is retrieved with the CA certificates of the VCD client, and
+// that the server certificate is verified even when the VCD client skips the verification
+func Test_samlMetadataTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<EntityDescriptor/>"))
+	}))
+	defer server.Close()
+	serverCAs := x509.NewCertPool()
+	serverCAs.AddCert(server.Certificate())
+
+	tests := []struct {
+		name      string
+		transport http.RoundTripper
+		wantErr   bool
+	}{
+		{
+			name:      "unverified VCD client",
+			transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}, // #nosec G402 -- test
+			wantErr:   true,
+		},
+		{
+			name: "unverified throttled VCD client",
+			transport: &throttledTransport{
+				transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}, // #nosec G402 -- test
+			},
+			wantErr: true,
+		},
+		{
+			name:      "unverified VCD client with CA certificates",
+			transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true, RootCAs: serverCAs}}, // #nosec G402 -- test
+		},
+		{
+			name:      "no TLS configuration",
+			transport: &http.Transport{},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vcdClient := &VCDClient{VCDClient: &govcd.VCDClient{Client: govcd.Client{Http: http.Client{Transport: tt.transport}}}}
+			metadata, err := fetchSamlMetadata(vcdClient, server.URL)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected a certificate verification error, got metadata %q", metadata)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if metadata != "<EntityDescriptor/>" {
+				t.Errorf("unexpected metadata %q", metadata)
+			}
+		})
+	}
+}
@@ -0,0 +1,441 @@
+package vcd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// The Organization email and password policy settings are not covered by the SDK. They are read and updated
+// directly in the 'settings/email' and 'settings/passwordPolicy' sections of the Admin Org
+
+const (
+	mimeOrgEmailSettings          = "application/vnd.vmware.admin.organizationEmailSettings+xml"
+	mimeOrgPasswordPolicySettings = "application/vnd.vmware.admin.organizationPasswordPolicySettings+xml"
+)
+
+// orgEmailSettings represents the type OrgEmailSettingsType. The order of the fields is the one required by the API
+type orgEmailSettings struct {
+	XMLName xml.Name `xml:"OrgEmailSettings"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	HREF    string   `xml:"href,attr,omitempty"`
+	Type    string   `xml:"type,attr,omitempty"`
+
+	IsDefaultSmtpServer     bool                   `xml:"IsDefaultSmtpServer"`     // Use the system SMTP server
+	IsDefaultOrgEmail       bool                   `xml:"IsDefaultOrgEmail"`       // Use the system sender address
+	FromEmailAddress        string                 `xml:"FromEmailAddress"`        // Sender address, when IsDefaultOrgEmail is false
+	DefaultSubjectPrefix    string                 `xml:"DefaultSubjectPrefix"`    // Prefix of the subject of the notifications
+	IsAlertEmailToAllAdmins bool                   `xml:"IsAlertEmailToAllAdmins"` // Send alerts to all the Org administrators
+	AlertEmailTo            string                 `xml:"AlertEmailTo,omitempty"`  // Comma separated recipients, when IsAlertEmailToAllAdmins is false
+	SmtpServerSettings      *orgSmtpServerSettings `xml:"SmtpServerSettings,omitempty"`
+}
+
+type orgSmtpServerSettings struct {
+	IsUseAuthentication bool   `xml:"IsUseAuthentication"`
+	Host                string `xml:"Host"`
+	Port                int    `xml:"Port,omitempty"`
+	SecureMode          string `xml:"SecureMode,omitempty"` // One of NONE, SSL, START_TLS
+	Username            string `xml:"Username,omitempty"`
+	Password            string `xml:"Password,omitempty"` // Never returned by GET
+}
+
+// orgPasswordPolicySettings represents the type OrgPasswordPolicySettingsType
+type orgPasswordPolicySettings struct {
+	XMLName xml.Name `xml:"OrgPasswordPolicySettings"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	HREF    string   `xml:"href,attr,omitempty"`
+	Type    string   `xml:"type,attr,omitempty"`
+
+	AccountLockoutEnabled         bool `xml:"AccountLockoutEnabled"`
+	InvalidLoginsBeforeLockout    int  `xml:"InvalidLoginsBeforeLockout"`
+	AccountLockoutIntervalMinutes int  `xml:"AccountLockoutIntervalMinutes"`
+}
+
+// Settings applied when the resource is deleted
+var (
+	defaultOrgEmailSettings = orgEmailSettings{
+		IsDefaultSmtpServer:     true,
+		IsDefaultOrgEmail:       true,
+		IsAlertEmailToAllAdmins: true,
+	}
+	defaultOrgPasswordPolicySettings = orgPasswordPolicySettings{
+		AccountLockoutEnabled:         false,
+		InvalidLoginsBeforeLockout:    5,
+		AccountLockoutIntervalMinutes: 10,
+	}
+)
+
+func resourceVcdOrgSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdOrgSettingsCreateOrUpdate,
+		ReadContext:   resourceVcdOrgSettingsRead,
+		UpdateContext: resourceVcdOrgSettingsCreateOrUpdate,
+		DeleteContext: resourceVcdOrgSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdOrgSettingsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Organization ID",
+			},
+			"email": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Email and SMTP settings of the Organization. When not set, the current settings are kept",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"use_system_default_smtp_server": { // IsDefaultSmtpServer
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Use the SMTP server of the system. When false, 'smtp_server' is required",
+						},
+						"use_system_default_sender": { // IsDefaultOrgEmail
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Use the sender address of the system. When false, 'from_email_address' is required",
+						},
+						"from_email_address": { // FromEmailAddress
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Email address of the sender of the notifications",
+						},
+						"default_subject_prefix": { // DefaultSubjectPrefix
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Prefix of the subject of the notifications",
+						},
+						"alert_all_org_admins": { // IsAlertEmailToAllAdmins
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Send alerts to all the Organization administrators. When false, 'alert_email_to' is required",
+						},
+						"alert_email_to": { // AlertEmailTo
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Email addresses that receive the alerts",
+						},
+						"smtp_server": { // SmtpServerSettings
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "SMTP server used by the Organization",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"host": { // Host
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Host name or IP address of the SMTP server",
+									},
+									"port": { // Port
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      25,
+										ValidateFunc: validation.IsPortNumber,
+										Description:  "Port of the SMTP server",
+									},
+									"secure_mode": { // SecureMode
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "NONE",
+										ValidateFunc: validation.StringInSlice([]string{"NONE", "SSL", "START_TLS"}, false),
+										Description:  "Connection security of the SMTP server. One of NONE, SSL, START_TLS",
+									},
+									"username": { // Username
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "User name for the SMTP server authentication",
+									},
+									"password": { // Password
+										Type:        schema.TypeString,
+										Optional:    true,
+										Sensitive:   true,
+										Description: "Password for the SMTP server authentication. This value is never returned by VCD",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"password_policy": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Account lockout policy of the Organization. When not set, the current settings are kept",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_lockout_enabled": { // AccountLockoutEnabled
+							Type:        schema.TypeBool,
+							Required:    true,
+							Description: "Whether accounts are locked after too many invalid logins",
+						},
+						"invalid_logins_before_lockout": { // InvalidLoginsBeforeLockout
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      5,
+							ValidateFunc: validation.IntBetween(1, 15),
+							Description:  "Number of invalid logins that lock the account",
+						},
+						"account_lockout_interval_minutes": { // AccountLockoutIntervalMinutes
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntBetween(1, 30),
+							Description:  "Time in minutes during which the account stays locked",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVcdOrgSettingsCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	defer vcdClient.readCache.reset()
+	orgId := d.Get("org_id").(string)
+
+	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgId)
+	if err != nil {
+		return diag.Errorf("[Org settings] error searching for Org %s: %s", orgId, err)
+	}
+
+	if d.IsNewResource() || d.HasChange("email") {
+		if emailBlock := firstBlock(d.Get("email")); emailBlock != nil {
+			settings, err := getOrgEmailSettingsType(emailBlock)
+			if err != nil {
+				return diag.Errorf("[Org settings] error collecting email settings: %s", err)
+			}
+			err = updateOrgSettingsSection(vcdClient, adminOrg, "email", mimeOrgEmailSettings, settings)
+			if err != nil {
+				return diag.Errorf("[Org settings] error updating email settings of Org %s: %s", orgId, err)
+			}
+		}
+	}
+
+	if d.IsNewResource() || d.HasChange("password_policy") {
+		if policyBlock := firstBlock(d.Get("password_policy")); policyBlock != nil {
+			settings := &orgPasswordPolicySettings{
+				AccountLockoutEnabled:         policyBlock["account_lockout_enabled"].(bool),
+				InvalidLoginsBeforeLockout:    policyBlock["invalid_logins_before_lockout"].(int),
+				AccountLockoutIntervalMinutes: policyBlock["account_lockout_interval_minutes"].(int),
+			}
+			err = updateOrgSettingsSection(vcdClient, adminOrg, "passwordPolicy", mimeOrgPasswordPolicySettings, settings)
+			if err != nil {
+				return diag.Errorf("[Org settings] error updating password policy of Org %s: %s", orgId, err)
+			}
+		}
+	}
+
+	d.SetId(adminOrg.AdminOrg.ID)
+	return resourceVcdOrgSettingsRead(ctx, d, meta)
+}
+
+func resourceVcdOrgSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdOrgSettingsRead(ctx, d, meta, "resource")
+}
+
+func genericVcdOrgSettingsRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	orgId := d.Get("org_id").(string)
+
+	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgId)
+	if govcd.IsNotFound(err) && origin == "resource" {
+		log.Printf("[INFO] unable to find Organization %s settings: %s. Removing from state", orgId, err)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("unable to find organization %s: %s", orgId, err)
+	}
+
+	// The resource only refreshes the sections that it manages, while data source and import read all of them
+	readAll := origin != "resource"
+
+	if readAll || len(d.Get("email").([]interface{})) > 0 {
+		email := &orgEmailSettings{}
+		err = getOrgSettingsSection(vcdClient, adminOrg, "email", email)
+		if err != nil {
+			return diag.Errorf("[Org settings read %s] error getting email settings for Org %s: %s", origin, orgId, err)
+		}
+		// The SMTP password is never returned: the one from the configuration is kept
+		smtpPassword := ""
+		if smtpBlock := firstBlock(d.Get("email.0.smtp_server")); smtpBlock != nil {
+			smtpPassword = smtpBlock["password"].(string)
+		}
+		err = d.Set("email", setOrgEmailSettingsData(email, smtpPassword))
+		if err != nil {
+			return diag.Errorf("[Org settings read %s] error setting 'email' field: %s", origin, err)
+		}
+	}
+
+	if readAll || len(d.Get("password_policy").([]interface{})) > 0 {
+		passwordPolicy := &orgPasswordPolicySettings{}
+		err = getOrgSettingsSection(vcdClient, adminOrg, "passwordPolicy", passwordPolicy)
+		if err != nil {
+			return diag.Errorf("[Org settings read %s] error getting password policy for Org %s: %s", origin, orgId, err)
+		}
+		err = d.Set("password_policy", []interface{}{map[string]interface{}{
+			"account_lockout_enabled":          passwordPolicy.AccountLockoutEnabled,
+			"invalid_logins_before_lockout":    passwordPolicy.InvalidLoginsBeforeLockout,
+			"account_lockout_interval_minutes": passwordPolicy.AccountLockoutIntervalMinutes,
+		}})
+		if err != nil {
+			return diag.Errorf("[Org settings read %s] error setting 'password_policy' field: %s", origin, err)
+		}
+	}
+
+	dSet(d, "org_id", orgId)
+	d.SetId(adminOrg.AdminOrg.ID)
+	return nil
+}
+
+// resourceVcdOrgSettingsDelete restores the default email settings and password policy of the Organization,
+// but only for the sections managed by the resource
+func resourceVcdOrgSettingsDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	defer vcdClient.readCache.reset()
+	orgId := d.Get("org_id").(string)
+
+	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgId)
+	if err != nil {
+		return diag.Errorf("[Org settings delete] error searching for Org %s: %s", orgId, err)
+	}
+
+	if len(d.Get("email").([]interface{})) > 0 {
+		emailSettings := defaultOrgEmailSettings
+		err = updateOrgSettingsSection(vcdClient, adminOrg, "email", mimeOrgEmailSettings, &emailSettings)
+		if err != nil {
+			return diag.Errorf("[Org settings delete] error restoring email settings of Org %s: %s", orgId, err)
+		}
+	}
+	if len(d.Get("password_policy").([]interface{})) > 0 {
+		passwordPolicySettings := defaultOrgPasswordPolicySettings
+		err = updateOrgSettingsSection(vcdClient, adminOrg, "passwordPolicy", mimeOrgPasswordPolicySettings, &passwordPolicySettings)
+		if err != nil {
+			return diag.Errorf("[Org settings delete] error restoring password policy of Org %s: %s", orgId, err)
+		}
+	}
+	return nil
+}
+
+func getOrgSettingsSection(vcdClient *VCDClient, adminOrg *govcd.AdminOrg, section string, out interface{}) error {
+	_, err := vcdClient.Client.ExecuteRequest(adminOrg.AdminOrg.HREF+"/settings/"+section, http.MethodGet,
+		"", "error retrieving Org settings: %s", nil, out)
+	return err
+}
+
+func updateOrgSettingsSection(vcdClient *VCDClient, adminOrg *govcd.AdminOrg, section, contentType string, settings interface{}) error {
+	switch typedSettings := settings.(type) {
+	case *orgEmailSettings:
+		typedSettings.Xmlns = types.XMLNamespaceVCloud
+	case *orgPasswordPolicySettings:
+		typedSettings.Xmlns = types.XMLNamespaceVCloud
+	}
+	_, err := vcdClient.Client.ExecuteRequest(adminOrg.AdminOrg.HREF+"/settings/"+section, http.MethodPut,
+		contentType, "error updating Org settings: %s", settings, nil)
+	return err
+}
+
+func getOrgEmailSettingsType(emailBlock map[string]interface{}) (*orgEmailSettings, error) {
+	settings := &orgEmailSettings{
+		IsDefaultSmtpServer:     emailBlock["use_system_default_smtp_server"].(bool),
+		IsDefaultOrgEmail:       emailBlock["use_system_default_sender"].(bool),
+		FromEmailAddress:        emailBlock["from_email_address"].(string),
+		DefaultSubjectPrefix:    emailBlock["default_subject_prefix"].(string),
+		IsAlertEmailToAllAdmins: emailBlock["alert_all_org_admins"].(bool),
+		AlertEmailTo:            strings.Join(convertSchemaSetToSliceOfStrings(emailBlock["alert_email_to"].(*schema.Set)), ","),
+	}
+
+	smtpBlock := firstBlock(emailBlock["smtp_server"])
+	if !settings.IsDefaultSmtpServer && smtpBlock == nil {
+		return nil, fmt.Errorf("'smtp_server' is required when 'use_system_default_smtp_server' is false")
+	}
+	if !settings.IsDefaultOrgEmail && settings.FromEmailAddress == "" {
+		return nil, fmt.Errorf("'from_email_address' is required when 'use_system_default_sender' is false")
+	}
+	if !settings.IsAlertEmailToAllAdmins && settings.AlertEmailTo == "" {
+		return nil, fmt.Errorf("'alert_email_to' is required when 'alert_all_org_admins' is false")
+	}
+
+	if smtpBlock != nil {
+		settings.SmtpServerSettings = &orgSmtpServerSettings{
+			Host:       smtpBlock["host"].(string),
+			Port:       smtpBlock["port"].(int),
+			SecureMode: smtpBlock["secure_mode"].(string),
+			Username:   smtpBlock["username"].(string),
+			Password:   smtpBlock["password"].(string),
+		}
+		settings.SmtpServerSettings.IsUseAuthentication = settings.SmtpServerSettings.Username != ""
+	}
+	return settings, nil
+}
+
+func setOrgEmailSettingsData(settings *orgEmailSettings, smtpPassword string) []interface{} {
+	var alertEmailTo []string
+	if settings.AlertEmailTo != "" {
+		alertEmailTo = strings.Split(settings.AlertEmailTo, ",")
+		for i := range alertEmailTo {
+			alertEmailTo[i] = strings.TrimSpace(alertEmailTo[i])
+		}
+	}
+
+	emailMap := map[string]interface{}{
+		"use_system_default_smtp_server": settings.IsDefaultSmtpServer,
+		"use_system_default_sender":      settings.IsDefaultOrgEmail,
+		"from_email_address":             settings.FromEmailAddress,
+		"default_subject_prefix":         settings.DefaultSubjectPrefix,
+		"alert_all_org_admins":           settings.IsAlertEmailToAllAdmins,
+		"alert_email_to":                 convertStringsToTypeSet(alertEmailTo),
+	}
+	// The system SMTP settings are not reported when the Organization uses the default server
+	if settings.SmtpServerSettings != nil && !settings.IsDefaultSmtpServer {
+		emailMap["smtp_server"] = []interface{}{map[string]interface{}{
+			"host":        settings.SmtpServerSettings.Host,
+			"port":        settings.SmtpServerSettings.Port,
+			"secure_mode": settings.SmtpServerSettings.SecureMode,
+			"username":    settings.SmtpServerSettings.Username,
+			"password":    smtpPassword,
+		}}
+	}
+	return []interface{}{emailMap}
+}
+
+// resourceVcdOrgSettingsImport is responsible for importing the resource.
+// The import path is just the org name (or Org ID). Both email settings and password policy are imported.
+//
+// Example import path (id): orgName
+func resourceVcdOrgSettingsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	orgName := d.Id()
+
+	vcdClient := meta.(*VCDClient)
+	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrg, err)
+	}
+
+	dSet(d, "org_id", adminOrg.AdminOrg.ID)
+
+	// All the sections are imported, so that the following refresh operations keep reading them
+	if diags := genericVcdOrgSettingsRead(ctx, d, meta, "import"); diags.HasError() {
+		return nil, fmt.Errorf("error reading Org settings: %s", diags[0].Summary)
+	}
+	return []*schema.ResourceData{d}, nil
+}
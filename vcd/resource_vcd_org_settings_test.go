@@ -0,0 +1,134 @@
+//go:build org || ALL || functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdOrgSettings(t *testing.T) {
+	preTestChecks(t)
+	skipIfNotSysAdmin(t)
+
+	var params = StringMap{
+		"OrgName":  t.Name(),
+		"FuncName": t.Name(),
+		"Tags":     "org",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccOrgSettings, params)
+
+	params["FuncName"] = t.Name() + "-Update"
+	configTextUpdate := templateFill(testAccOrgSettingsUpdate, params)
+
+	params["FuncName"] = t.Name() + "-DS"
+	configTextDS := templateFill(testAccOrgSettingsUpdate+testAccOrgSettingsDS, params)
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+	debugPrintf("#[DEBUG] CONFIGURATION Resource: %s\n", configText)
+	debugPrintf("#[DEBUG] CONFIGURATION Resource update: %s\n", configTextUpdate)
+	debugPrintf("#[DEBUG] CONFIGURATION Data source: %s\n", configTextDS)
+
+	resourceDef := "vcd_org_settings.test"
+	datasourceDef := "data.vcd_org_settings.test"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckOrgDestroy(params["OrgName"].(string)),
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceDef, "org_id", "vcd_org.test", "id"),
+					resource.TestCheckResourceAttr(resourceDef, "email.0.use_system_default_smtp_server", "false"),
+					resource.TestCheckResourceAttr(resourceDef, "email.0.use_system_default_sender", "false"),
+					resource.TestCheckResourceAttr(resourceDef, "email.0.from_email_address", "noreply@example.com"),
+					resource.TestCheckResourceAttr(resourceDef, "email.0.alert_email_to.#", "2"),
+					resource.TestCheckResourceAttr(resourceDef, "email.0.smtp_server.0.host", "smtp.example.com"),
+					resource.TestCheckResourceAttr(resourceDef, "email.0.smtp_server.0.port", "587"),
+					resource.TestCheckResourceAttr(resourceDef, "password_policy.#", "0"),
+				),
+			},
+			{
+				Config: configTextUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceDef, "email.0.use_system_default_smtp_server", "true"),
+					resource.TestCheckResourceAttr(resourceDef, "email.0.alert_all_org_admins", "true"),
+					resource.TestCheckResourceAttr(resourceDef, "email.0.smtp_server.#", "0"),
+					resource.TestCheckResourceAttr(resourceDef, "password_policy.0.account_lockout_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceDef, "password_policy.0.invalid_logins_before_lockout", "3"),
+					resource.TestCheckResourceAttr(resourceDef, "password_policy.0.account_lockout_interval_minutes", "15"),
+				),
+			},
+			{
+				Config: configTextDS,
+				Check: resource.ComposeTestCheckFunc(
+					resourceFieldsEqual(resourceDef, datasourceDef, nil),
+				),
+			},
+			{
+				ResourceName:      resourceDef,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdTopHierarchy(params["OrgName"].(string)),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccOrgSettingsOrg = `
+resource "vcd_org" "test" {
+  name             = "{{.OrgName}}"
+  full_name        = "{{.OrgName}}"
+  delete_recursive = true
+  delete_force     = true
+}
+`
+
+const testAccOrgSettings = testAccOrgSettingsOrg + `
+resource "vcd_org_settings" "test" {
+  org_id = vcd_org.test.id
+
+  email {
+    use_system_default_smtp_server = false
+    use_system_default_sender      = false
+    from_email_address             = "noreply@example.com"
+    default_subject_prefix         = "[{{.OrgName}}]"
+    alert_all_org_admins           = false
+    alert_email_to                 = ["ops@example.com", "security@example.com"]
+
+    smtp_server {
+      host        = "smtp.example.com"
+      port        = 587
+      secure_mode = "START_TLS"
+    }
+  }
+}
+`
+
+const testAccOrgSettingsUpdate = testAccOrgSettingsOrg + `
+resource "vcd_org_settings" "test" {
+  org_id = vcd_org.test.id
+
+  email {
+    default_subject_prefix = "[{{.OrgName}}]"
+  }
+
+  password_policy {
+    account_lockout_enabled          = true
+    invalid_logins_before_lockout    = 3
+    account_lockout_interval_minutes = 15
+  }
+}
+`
+
+const testAccOrgSettingsDS = `
+data "vcd_org_settings" "test" {
+  org_id = vcd_org_settings.test.org_id
+}
+`
@@ -311,6 +311,7 @@ func resourceVcdOrgUserCreate(ctx context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	meta.(*VCDClient).invalidateOrg(adminOrg.AdminOrg.Name)
 	return resourceVcdOrgUserRead(ctx, d, meta)
 }
 
@@ -318,7 +319,7 @@ func resourceVcdOrgUserCreate(ctx context.Context, d *schema.ResourceData, meta
 func resourceVcdOrgUserDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	takeOwnership := d.Get("take_ownership").(bool)
-	orgUser, _, err := resourceToOrgUser(d, meta)
+	orgUser, adminOrg, err := resourceToOrgUser(d, meta)
 	if err != nil {
 		return diag.Errorf("[user delete] %s", err)
 	}
@@ -326,6 +327,7 @@ func resourceVcdOrgUserDelete(_ context.Context, d *schema.ResourceData, meta in
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	meta.(*VCDClient).invalidateOrg(adminOrg.AdminOrg.Name)
 	return nil
 }
 
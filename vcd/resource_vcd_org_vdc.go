@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
@@ -58,6 +59,10 @@ func resourceVcdOrgVdc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdOrgVdcImport,
 		},
+		CustomizeDiff: orgVdcCustomizeDiff(),
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -304,6 +309,7 @@ func resourceVcdVdcCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	log.Printf("[TRACE] VDC creation initiated: %s", orgVdcName)
 
 	vcdClient := meta.(*VCDClient)
+	defer vcdClient.readCache.reset()
 
 	if !vcdClient.Client.IsSysAdmin {
 		return diag.Errorf("functionality requires System administrator privileges")
@@ -616,6 +622,7 @@ func resourceVcdVdcUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	log.Printf("[TRACE] VDC update initiated: %s", vdcName)
 
 	vcdClient := meta.(*VCDClient)
+	defer vcdClient.readCache.reset()
 
 	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
 	if err != nil {
@@ -858,11 +865,12 @@ func updateStorageProfiles(set *schema.Set, client *VCDClient, adminVdc *govcd.A
 }
 
 // Deletes a VDC, optionally removing all objects in it as well
-func resourceVcdVdcDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdVdcDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vdcName := d.Get("name").(string)
 	log.Printf("[TRACE] VDC delete started: %s", vdcName)
 
 	vcdClient := meta.(*VCDClient)
+	defer vcdClient.readCache.reset()
 
 	if !vcdClient.Client.IsSysAdmin {
 		return diag.Errorf("functionality requires System administrator privileges")
@@ -880,7 +888,10 @@ func resourceVcdVdcDelete(_ context.Context, d *schema.ResourceData, meta interf
 		return nil
 	}
 
-	err = vdc.DeleteWait(d.Get("delete_force").(bool), d.Get("delete_recursive").(bool))
+	task, err := vdc.Delete(d.Get("delete_force").(bool), d.Get("delete_recursive").(bool))
+	if err == nil {
+		err = waitTaskCompletionWithContext(ctx, task)
+	}
 	if err != nil {
 		log.Printf("[DEBUG] Error removing VDC %s, err: %s", vdcName, err)
 		return diag.Errorf("error removing VDC %s, err: %s", vdcName, err)
@@ -0,0 +1,246 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// vcd_vapp_lease_renewal keeps the leases of a vApp from expiring. VCD computes the expiration of the leases from the
+// moment they are set, so a lease is renewed by setting it again with the same duration. The renewal happens in an
+// update, which is planned by the CustomizeDiff function when any lease expires within the given threshold.
+
+func resourceVcdVappLeaseRenewal() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdVappLeaseRenewalCreate,
+		ReadContext:   resourceVcdVappLeaseRenewalRead,
+		UpdateContext: resourceVcdVappLeaseRenewalUpdate,
+		DeleteContext: resourceVcdVappLeaseRenewalDelete,
+		CustomizeDiff: vappLeaseRenewalCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vapp_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "The vApp whose leases are renewed. For a standalone VM, use the 'vapp_name' " +
+					"attribute of 'vcd_vm'",
+			},
+			"renew_threshold_in_sec": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "The leases are renewed during apply when any of them expires within this number of " +
+					"seconds. With 0 (default), the leases are renewed on every apply",
+			},
+			"runtime_lease_in_sec": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Duration of the runtime lease, in seconds. 0 means that the lease never expires",
+			},
+			"storage_lease_in_sec": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Duration of the storage lease, in seconds. 0 means that the lease never expires",
+			},
+			"runtime_lease_expiration": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration date of the runtime lease. Empty when the lease doesn't expire or the vApp is not deployed",
+			},
+			"storage_lease_expiration": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration date of the storage lease. Empty when the lease doesn't expire",
+			},
+			"renewed_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date of the last renewal of the leases",
+			},
+		},
+	}
+}
+
+func resourceVcdVappLeaseRenewalCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVcdVappLeaseRenewalRenew(ctx, d, meta, "create")
+}
+
+func resourceVcdVappLeaseRenewalUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVcdVappLeaseRenewalRenew(ctx, d, meta, "update")
+}
+
+func resourceVcdVappLeaseRenewalRenew(ctx context.Context, d *schema.ResourceData, meta interface{}, operation string) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vcdClient.lockParentVapp(d)
+	defer vcdClient.unLockParentVapp(d)
+
+	vapp, err := getVappForLeaseRenewal(vcdClient, d)
+	if err != nil {
+		return diag.Errorf("[vApp lease renewal %s] %s", operation, err)
+	}
+
+	lease, err := vapp.GetLease()
+	if err != nil {
+		return diag.Errorf("[vApp lease renewal %s] error retrieving lease of vApp '%s': %s", operation, vapp.VApp.Name, err)
+	}
+	needsRenewal, err := vappLeaseNeedsRenewal(lease, d.Get("renew_threshold_in_sec").(int), time.Now())
+	if err != nil {
+		return diag.Errorf("[vApp lease renewal %s] %s", operation, err)
+	}
+	if needsRenewal {
+		err = renewVappLease(ctx, vcdClient, vapp, lease)
+		if err != nil {
+			return diag.Errorf("[vApp lease renewal %s] error renewing lease of vApp '%s': %s", operation, vapp.VApp.Name, err)
+		}
+		dSet(d, "renewed_at", time.Now().UTC().Format(time.RFC3339))
+	}
+
+	d.SetId(vapp.VApp.ID)
+
+	return resourceVcdVappLeaseRenewalRead(ctx, d, meta)
+}
+
+func resourceVcdVappLeaseRenewalRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vapp, err := getVappForLeaseRenewal(vcdClient, d)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] vApp '%s' not found. Removing lease renewal from state", d.Get("vapp_name").(string))
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[vApp lease renewal read] %s", err)
+	}
+
+	lease, err := vapp.GetLease()
+	if err != nil {
+		return diag.Errorf("[vApp lease renewal read] error retrieving lease of vApp '%s': %s", vapp.VApp.Name, err)
+	}
+	dSet(d, "runtime_lease_in_sec", lease.DeploymentLeaseInSeconds)
+	dSet(d, "storage_lease_in_sec", lease.StorageLeaseInSeconds)
+	dSet(d, "runtime_lease_expiration", lease.DeploymentLeaseExpiration)
+	dSet(d, "storage_lease_expiration", lease.StorageLeaseExpiration)
+
+	return nil
+}
+
+// resourceVcdVappLeaseRenewalDelete only removes the resource from the state. The leases of the vApp are not changed
+func resourceVcdVappLeaseRenewalDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// vappLeaseRenewalCustomizeDiff plans an update, which renews the leases, when any of the leases stored in the state
+// expires within the threshold
+func vappLeaseRenewalCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	lease := &types.LeaseSettingsSection{
+		DeploymentLeaseExpiration: d.Get("runtime_lease_expiration").(string),
+		StorageLeaseExpiration:    d.Get("storage_lease_expiration").(string),
+	}
+	needsRenewal, err := vappLeaseNeedsRenewal(lease, d.Get("renew_threshold_in_sec").(int), time.Now())
+	if err != nil {
+		return err
+	}
+	if !needsRenewal {
+		return nil
+	}
+	for _, field := range []string{"runtime_lease_expiration", "storage_lease_expiration", "renewed_at"} {
+		err = d.SetNewComputed(field)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getVappForLeaseRenewal(vcdClient *VCDClient, d *schema.ResourceData) (*govcd.VApp, error) {
+	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+	vappName := d.Get("vapp_name").(string)
+	vapp, err := vdc.GetVAppByName(vappName, false)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving vApp '%s': %w", vappName, err)
+	}
+	return vapp, nil
+}
+
+// vappLeaseNeedsRenewal checks whether any of the leases expires within the threshold. Leases without expiration
+// date, such as leases that never expire or the runtime lease of a vApp which is not deployed, don't need renewal
+func vappLeaseNeedsRenewal(lease *types.LeaseSettingsSection, thresholdInSec int, now time.Time) (bool, error) {
+	for _, expiration := range []string{lease.DeploymentLeaseExpiration, lease.StorageLeaseExpiration} {
+		remaining, expires, err := vappLeaseRemainingSeconds(expiration, now)
+		if err != nil {
+			return false, err
+		}
+		if expires && (thresholdInSec == 0 || remaining < thresholdInSec) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// vappLeaseRemainingSeconds returns the seconds left before the given lease expiration date, which can be negative
+// when the lease already expired. It returns false when there is no expiration date
+func vappLeaseRemainingSeconds(expiration string, now time.Time) (int, bool, error) {
+	if expiration == "" {
+		return 0, false, nil
+	}
+	expirationTime, err := time.Parse(time.RFC3339, expiration)
+	if err != nil {
+		return 0, false, fmt.Errorf("error parsing lease expiration date '%s': %s", expiration, err)
+	}
+	return int(expirationTime.Sub(now).Seconds()), true, nil
+}
+
+// renewVappLease sets the leases of a vApp again with their current duration, so that VCD computes their expiration
+// from now. govcd.VApp.RenewLease can't be used, as it doesn't update the leases when their duration doesn't change
+func renewVappLease(ctx context.Context, vcdClient *VCDClient, vapp *govcd.VApp, lease *types.LeaseSettingsSection) error {
+	href := lease.HREF
+	if href == "" {
+		return fmt.Errorf("link to update lease settings not found for vApp %s", vapp.VApp.Name)
+	}
+	leaseSettings := &types.UpdateLeaseSettingsSection{
+		HREF:                     href,
+		XmlnsOvf:                 types.XMLNamespaceOVF,
+		Xmlns:                    types.XMLNamespaceVCloud,
+		OVFInfo:                  "Lease section settings",
+		Type:                     types.MimeLeaseSettingSection,
+		DeploymentLeaseInSeconds: addrOf(lease.DeploymentLeaseInSeconds),
+		StorageLeaseInSeconds:    addrOf(lease.StorageLeaseInSeconds),
+	}
+	task, err := vcdClient.Client.ExecuteTaskRequest(href, http.MethodPut, types.MimeLeaseSettingSection,
+		"error updating vApp lease: %s", leaseSettings)
+	if err != nil {
+		return err
+	}
+	return waitTaskCompletionWithContext(ctx, task)
+}
@@ -0,0 +1,105 @@
+//go:build vapp || ALL || functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdVappLeaseRenewal(t *testing.T) {
+	preTestChecks(t)
+	vappName := t.Name()
+
+	var params = StringMap{
+		"Org":          testConfig.VCD.Org,
+		"Vdc":          testConfig.Nsxt.Vdc,
+		"VappName":     vappName,
+		"StorageLease": 2 * 86400,
+		"Threshold":    3 * 86400,
+		"FuncName":     t.Name(),
+		"Tags":         "vapp",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdVappLeaseRenewal, params)
+
+	params["FuncName"] = t.Name() + "_step2"
+	params["Threshold"] = 3600
+	configText2 := templateFill(testAccVcdVappLeaseRenewal, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	debugPrintf("#[DEBUG] CONFIGURATION step2: %s\n", configText2)
+
+	resourceName := "vcd_vapp_lease_renewal.renewal"
+	dataSourceName := "data.vcd_vapp_lease_expiry.expiry"
+	renewedAt := testCachedFieldValue{}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppDestroy,
+		Steps: []resource.TestStep{
+			// The storage lease expires within the threshold, so the lease is renewed on every apply. The plan
+			// after apply is never empty
+			{
+				Config:             configText,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "vcd_vapp."+vappName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "storage_lease_in_sec", "172800"),
+					resource.TestMatchResourceAttr(resourceName, "storage_lease_expiration", regexp.MustCompile(`^\S+`)),
+					resource.TestMatchResourceAttr(resourceName, "renewed_at", regexp.MustCompile(`^\S+`)),
+					renewedAt.cacheTestResourceFieldValue(resourceName, "renewed_at"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "vapp.*", map[string]string{
+						"name":                 vappName,
+						"storage_lease_in_sec": "172800",
+					}),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "expiring.*", vappName),
+				),
+			},
+			// With a lower threshold, the lease is not renewed and the plan is stable
+			{
+				Config: configText2,
+				Check: resource.ComposeTestCheckFunc(
+					renewedAt.testCheckCachedResourceFieldValue(resourceName, "renewed_at"),
+					resource.TestMatchResourceAttr(resourceName, "storage_lease_expiration", regexp.MustCompile(`^\S+`)),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdVappLeaseRenewal = `
+resource "vcd_vapp" "{{.VappName}}" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VappName}}"
+
+  lease {
+    runtime_lease_in_sec = 0
+    storage_lease_in_sec = {{.StorageLease}}
+  }
+}
+
+resource "vcd_vapp_lease_renewal" "renewal" {
+  org                    = "{{.Org}}"
+  vdc                    = "{{.Vdc}}"
+  vapp_name              = vcd_vapp.{{.VappName}}.name
+  renew_threshold_in_sec = {{.Threshold}}
+}
+
+data "vcd_vapp_lease_expiry" "expiry" {
+  org                 = "{{.Org}}"
+  vdc                 = "{{.Vdc}}"
+  expiring_within_sec = 3 * 86400
+
+  depends_on = [vcd_vapp_lease_renewal.renewal]
+}
+`
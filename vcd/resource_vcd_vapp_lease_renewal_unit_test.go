@@ -0,0 +1,127 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Test_vappLeaseNeedsRenewal checks the evaluation of the lease expiration dates against the renewal threshold
+func Test_vappLeaseNeedsRenewal(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	inOneHour := now.Add(time.Hour).Format(time.RFC3339)
+	inOneDay := now.Add(24 * time.Hour).Format(time.RFC3339)
+	oneHourAgo := now.Add(-time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name      string
+		lease     types.LeaseSettingsSection
+		threshold int
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "NeverExpires",
+			lease:     types.LeaseSettingsSection{},
+			threshold: 7200,
+			want:      false,
+		},
+		{
+			name:      "NeverExpiresZeroThreshold",
+			lease:     types.LeaseSettingsSection{},
+			threshold: 0,
+			want:      false,
+		},
+		{
+			name:      "RuntimeWithinThreshold",
+			lease:     types.LeaseSettingsSection{DeploymentLeaseExpiration: inOneHour, StorageLeaseExpiration: inOneDay},
+			threshold: 7200,
+			want:      true,
+		},
+		{
+			name:      "StorageWithinThreshold",
+			lease:     types.LeaseSettingsSection{StorageLeaseExpiration: inOneHour},
+			threshold: 7200,
+			want:      true,
+		},
+		{
+			name:      "OutsideThreshold",
+			lease:     types.LeaseSettingsSection{DeploymentLeaseExpiration: inOneDay, StorageLeaseExpiration: inOneDay},
+			threshold: 7200,
+			want:      false,
+		},
+		{
+			name:      "AlreadyExpired",
+			lease:     types.LeaseSettingsSection{StorageLeaseExpiration: oneHourAgo},
+			threshold: 60,
+			want:      true,
+		},
+		{
+			name:      "ZeroThresholdAlwaysRenews",
+			lease:     types.LeaseSettingsSection{StorageLeaseExpiration: inOneDay},
+			threshold: 0,
+			want:      true,
+		},
+		{
+			name:      "InvalidDate",
+			lease:     types.LeaseSettingsSection{StorageLeaseExpiration: "tomorrow"},
+			threshold: 7200,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vappLeaseNeedsRenewal(&tt.lease, tt.threshold, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("vappLeaseNeedsRenewal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("vappLeaseNeedsRenewal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_flattenVappLeaseExpiry checks the remaining time and the expiring flag reported for a vApp
+func Test_flattenVappLeaseExpiry(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	lease := &types.LeaseSettingsSection{
+		DeploymentLeaseInSeconds:  3600,
+		StorageLeaseInSeconds:     86400,
+		DeploymentLeaseExpiration: now.Add(30 * time.Minute).Format(time.RFC3339),
+		StorageLeaseExpiration:    now.Add(20 * time.Hour).Format(time.RFC3339),
+	}
+
+	vappLease, isExpiring, err := flattenVappLeaseExpiry("vapp1", "urn:vcloud:vapp:1", "POWERED_ON", lease, 3600, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !isExpiring {
+		t.Errorf("expected vApp to be expiring")
+	}
+	if vappLease["runtime_lease_remaining_sec"] != 1800 {
+		t.Errorf("expected 1800 seconds of runtime lease left, got %v", vappLease["runtime_lease_remaining_sec"])
+	}
+	if vappLease["storage_lease_remaining_sec"] != 72000 {
+		t.Errorf("expected 72000 seconds of storage lease left, got %v", vappLease["storage_lease_remaining_sec"])
+	}
+
+	_, isExpiring, err = flattenVappLeaseExpiry("vapp1", "urn:vcloud:vapp:1", "POWERED_ON", lease, 60, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if isExpiring {
+		t.Errorf("expected vApp not to be expiring within 60 seconds")
+	}
+
+	_, isExpiring, err = flattenVappLeaseExpiry("vapp2", "urn:vcloud:vapp:2", "POWERED_OFF", &types.LeaseSettingsSection{}, 86400, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if isExpiring {
+		t.Errorf("expected vApp with leases that never expire not to be expiring")
+	}
+}
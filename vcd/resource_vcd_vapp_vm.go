@@ -78,7 +78,19 @@ func resourceVcdVAppVm() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdVappVmImport,
 		},
-		Schema: vmSchemaFunc(vappVmType),
+		Schema:        vmSchemaFunc(vappVmType),
+		CustomizeDiff: vmCustomizeDiff(vappVmType),
+		Timeouts:      vmResourceTimeouts(),
+	}
+}
+
+// vmResourceTimeouts returns the default timeouts shared by vcd_vapp_vm and vcd_vm. They limit the
+// time spent waiting for power and deletion tasks
+func vmResourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(60 * time.Minute),
+		Update: schema.DefaultTimeout(60 * time.Minute),
+		Delete: schema.DefaultTimeout(60 * time.Minute),
 	}
 }
 
@@ -777,7 +789,7 @@ func vmSchemaFunc(vmType typeOfVm) map[string]*schema.Schema {
 
 // resourceVcdVAppVmCreate is an entry function for VM within vApp creation. It locks parent vApp and cascades down the
 // other functions that need to be run
-func resourceVcdVAppVmCreate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdVAppVmCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	startTime := time.Now()
 
 	vappName := d.Get("vapp_name").(string)
@@ -836,7 +848,7 @@ func resourceVcdVAppVmCreate(_ context.Context, d *schema.ResourceData, meta int
 		}
 	}
 
-	diags := genericResourceVmCreate(d, meta, vappVmType)
+	diags := genericResourceVmCreate(ctx, d, meta, vappVmType)
 	// We need to check if there were errors, as genericResourceVmCreate can also return a warning
 	if diags.HasError() {
 		return diags
@@ -855,7 +867,7 @@ func resourceVcdVAppVmCreate(_ context.Context, d *schema.ResourceData, meta int
 // genericResourceVmCreate does the following:
 // * Executes VM create functions based on the type of VM (standalone or vApp member)
 // * Runs additional customization functions which are common for all 4 types of VMs
-func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
+func genericResourceVmCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
 	diags := diag.Diagnostics{}
 	vcdClient := meta.(*VCDClient)
 
@@ -1030,7 +1042,7 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 	if err != nil {
 		return diag.Errorf(errorRetrievingOrgAndVdc, err)
 	}
-	err = attachDetachIndependentDisks(d, *vm, vdc)
+	err = attachDetachIndependentDisks(vcdClient, d, *vm, vdc)
 	if err != nil {
 		return diag.Errorf("error attaching-detaching independent disks when creating VM : %s", err)
 	}
@@ -1081,7 +1093,7 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 			if err != nil {
 				return diag.Errorf("error powering on: %s", err)
 			}
-			err = task.WaitTaskCompletion()
+			err = waitTaskCompletionWithContext(ctx, task)
 			if err != nil {
 				return diag.Errorf(errorCompletingTask, err)
 			}
@@ -1732,14 +1744,18 @@ func createVmEmpty(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*
 	return newVm, nil
 }
 
-func resourceVcdVAppVmUpdate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericResourceVcdVmUpdate(d, meta, vappVmType)
+func resourceVcdVAppVmUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericResourceVcdVmUpdate(ctx, d, meta, vappVmType)
 }
 
-func genericResourceVcdVmUpdate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
+func genericResourceVcdVmUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
 	log.Printf("[DEBUG] [VM update] started with lock")
 	vcdClient := meta.(*VCDClient)
 
+	// Disks removed by this update can be taken over by other VMs of the same apply, even while this update waits
+	// for the vApp lock
+	defer registerIndependentDiskRemovals(d)()
+
 	// When there is more then one VM in a vApp Terraform will try to parallelise their creation.
 	// However, vApp throws errors when simultaneous requests are executed.
 	// To avoid them, below block is using mutex as a workaround,
@@ -1762,7 +1778,7 @@ func genericResourceVcdVmUpdate(d *schema.ResourceData, meta interface{}, vmType
 		return err
 	}
 
-	return resourceVcdVAppVmUpdateExecute(d, meta, "update", vmType, nil)
+	return resourceVcdVAppVmUpdateExecute(ctx, d, meta, "update", vmType, nil)
 }
 
 func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
@@ -1866,7 +1882,7 @@ func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}, vmType typeOf
 	return nil
 }
 
-func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, executionType string, vmType typeOfVm, computePolicy *types.VdcComputePolicy) diag.Diagnostics {
+func resourceVcdVAppVmUpdateExecute(ctx context.Context, d *schema.ResourceData, meta interface{}, executionType string, vmType typeOfVm, computePolicy *types.VdcComputePolicy) diag.Diagnostics {
 	diags := diag.Diagnostics{}
 	log.Printf("[DEBUG] [VM update] started without lock")
 
@@ -1948,7 +1964,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			if err != nil {
 				return diag.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
 			}
-			err = task.WaitTaskCompletion()
+			err = waitTaskCompletionWithContext(ctx, task)
 			if err != nil {
 				return diag.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
 			}
@@ -1956,7 +1972,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 
 		// detaching independent disks - only possible when VM power off
 		if d.HasChange("disk") {
-			err = attachDetachIndependentDisks(d, *vm, vdc)
+			err = attachDetachIndependentDisks(vcd, d, *vm, vdc)
 			if err != nil {
 				errAttachedDisk := updateStateOfAttachedIndependentDisks(d, *vm)
 				if errAttachedDisk != nil {
@@ -2026,7 +2042,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 				return diag.Errorf("error changing hardware assisted virtualization: %s", err)
 			}
 
-			err = task.WaitTaskCompletion()
+			err = waitTaskCompletionWithContext(ctx, task)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -2188,7 +2204,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			if err != nil {
 				return diag.Errorf("error powering on: %s", err)
 			}
-			err = task.WaitTaskCompletion()
+			err = waitTaskCompletionWithContext(ctx, task)
 			if err != nil {
 				return diag.Errorf(errorCompletingTask, err)
 			}
@@ -2206,7 +2222,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 				if err != nil {
 					return diag.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
 				}
-				err = task.WaitTaskCompletion()
+				err = waitTaskCompletionWithContext(ctx, task)
 				if err != nil {
 					return diag.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
 				}
@@ -2459,7 +2475,7 @@ func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string) d
 	return nil
 }
 
-func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdVAppVmDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[DEBUG] [VM delete] started")
 
 	vcdClient := meta.(*VCDClient)
@@ -2515,7 +2531,7 @@ func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta int
 			return diag.Errorf("error Undeploying: %s", err)
 		}
 
-		err = task.WaitTaskCompletion()
+		err = waitTaskCompletionWithContext(ctx, task)
 		if err != nil {
 			return diag.Errorf("error Undeploying VM: %s", err)
 		}
@@ -2535,7 +2551,7 @@ func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta int
 		if err != nil {
 			return diag.Errorf("error detaching disk `%s`: %s", existingDiskHref, err)
 		}
-		err = task.WaitTaskCompletion()
+		err = waitTaskCompletionWithContext(ctx, task)
 		if err != nil {
 			return diag.Errorf("error waiting detaching disk task to finish`%s`: %s", existingDiskHref, err)
 		}
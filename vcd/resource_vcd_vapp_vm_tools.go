@@ -15,6 +15,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -161,7 +162,7 @@ func lookupStorageProfile(d *schema.ResourceData, vdc *govcd.Vdc) (*types.Refere
 
 // lookupComputePolicy returns the Compute Policy associated to the value of the given Compute Policy attribute. If the
 // attribute is not set, the returned policy will be nil. If the obtained policy is incorrect, it will return an error.
-func lookupComputePolicy(d *schema.ResourceData, vcdClient *VCDClient, computePolicyAttribute string) (*govcd.VdcComputePolicyV2, error) {
+func lookupComputePolicy(d schemaGetter, vcdClient *VCDClient, computePolicyAttribute string) (*govcd.VdcComputePolicyV2, error) {
 	if value, ok := d.GetOk(computePolicyAttribute); ok {
 		computePolicy, err := vcdClient.GetVdcComputePolicyV2ById(value.(string))
 		if err != nil {
@@ -498,8 +499,9 @@ func getVmFromResource(d *schema.ResourceData, meta interface{}, vmType typeOfVm
 }
 
 // attachDetachIndependentDisks updates attached disks to latest state, removes not needed, and adds
-// new ones
-func attachDetachIndependentDisks(d *schema.ResourceData, vm govcd.VM, vdc *govcd.Vdc) error {
+// new ones. A disk which is not shared and is attached to another VM is moved to this one when the other
+// VM removes it in the same apply, so that a disk can be moved between VMs without losing data
+func attachDetachIndependentDisks(vcdClient *VCDClient, d *schema.ResourceData, vm govcd.VM, vdc *govcd.Vdc) error {
 	oldValues, newValues := d.GetChange("disk")
 
 	attachDisks := newValues.(*schema.Set).Difference(oldValues.(*schema.Set))
@@ -524,13 +526,9 @@ func attachDetachIndependentDisks(d *schema.ResourceData, vm govcd.VM, vdc *govc
 			attachParams.BusNumber = diskData.busNumber
 		}
 
-		task, err := vm.DetachDisk(attachParams)
+		err = detachIndependentDiskFromVm(vdc, &vm, attachParams)
 		if err != nil {
-			return fmt.Errorf("error detaching disk `%s` to vm %s", diskData.name, err)
-		}
-		err = task.WaitTaskCompletion()
-		if err != nil {
-			return fmt.Errorf("error waiting for task to complete detaching disk `%s` to vm %s", diskData.name, err)
+			return err
 		}
 	}
 
@@ -553,6 +551,11 @@ func attachDetachIndependentDisks(d *schema.ResourceData, vm govcd.VM, vdc *govc
 			return fmt.Errorf("did not find disk `%s`: %s", diskData.name, err)
 		}
 
+		err = detachIndependentDiskFromOtherVms(vcdClient, vdc, disk.Disk.HREF, vm.VM.HREF)
+		if err != nil {
+			return err
+		}
+
 		attachParams := &types.DiskAttachOrDetachParams{Disk: &types.Reference{HREF: disk.Disk.HREF}}
 		if diskData.unitNumber != nil {
 			attachParams.UnitNumber = diskData.unitNumber
@@ -573,6 +576,195 @@ func attachDetachIndependentDisks(d *schema.ResourceData, vm govcd.VM, vdc *govc
 	return nil
 }
 
+// detachIndependentDiskFromVm detaches an independent disk from a VM. The disk is skipped when it is not attached
+// to the VM anymore, which happens when another VM of the same configuration took it over already
+func detachIndependentDiskFromVm(vdc *govcd.Vdc, vm *govcd.VM, detachParams *types.DiskAttachOrDetachParams) error {
+	lockVmsForIndependentDisks([]string{vm.VM.HREF})
+	defer unlockVmsForIndependentDisks([]string{vm.VM.HREF})
+
+	disk, err := vdc.GetDiskByHref(detachParams.Disk.HREF)
+	if err != nil {
+		return fmt.Errorf("error retrieving disk `%s`: %s", detachParams.Disk.HREF, err)
+	}
+	attachedVmsHrefs, err := disk.GetAttachedVmsHrefs()
+	if err != nil {
+		return fmt.Errorf("error retrieving VMs attached to disk `%s`: %s", disk.Disk.Name, err)
+	}
+	if !isVmHrefInList(vm.VM.HREF, attachedVmsHrefs) {
+		log.Printf("[DEBUG] disk `%s` is not attached to VM `%s` anymore, skipping detach", disk.Disk.Name, vm.VM.Name)
+		return nil
+	}
+
+	task, err := vm.DetachDisk(detachParams)
+	if err != nil {
+		return fmt.Errorf("error detaching disk `%s` to vm %s", disk.Disk.Name, err)
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf("error waiting for task to complete detaching disk `%s` to vm %s", disk.Disk.Name, err)
+	}
+	return nil
+}
+
+// independentDiskPollInterval is the delay between checks of a disk which is still attached to another VM
+const independentDiskPollInterval = 5 * time.Second
+
+// independentDiskRemovals records the independent disks that VM updates running in this apply are going to detach,
+// as "VM UUID|disk name" keys. A disk can only be taken over from another VM when that VM is removing it
+var independentDiskRemovals = struct {
+	keys map[string]int
+	sync.Mutex
+}{keys: make(map[string]int)}
+
+func independentDiskRemovalKey(vmId, diskName string) string {
+	return extractUuid(vmId) + "|" + diskName
+}
+
+// registerIndependentDiskRemovals records the disks removed from the 'disk' blocks of the VM being updated. It
+// returns the function that removes the records, to be called when the update ends
+func registerIndependentDiskRemovals(d *schema.ResourceData) func() {
+	if !d.HasChange("disk") {
+		return func() {}
+	}
+	oldValues, newValues := d.GetChange("disk")
+	var keys []string
+	for _, disk := range oldValues.(*schema.Set).Difference(newValues.(*schema.Set)).List() {
+		keys = append(keys, independentDiskRemovalKey(d.Id(), disk.(map[string]interface{})["name"].(string)))
+	}
+
+	independentDiskRemovals.Lock()
+	defer independentDiskRemovals.Unlock()
+	for _, key := range keys {
+		independentDiskRemovals.keys[key]++
+	}
+	return func() {
+		independentDiskRemovals.Lock()
+		defer independentDiskRemovals.Unlock()
+		for _, key := range keys {
+			independentDiskRemovals.keys[key]--
+			if independentDiskRemovals.keys[key] <= 0 {
+				delete(independentDiskRemovals.keys, key)
+			}
+		}
+	}
+}
+
+// isIndependentDiskRemovalRegistered checks whether an update of the given VM is removing the given disk
+func isIndependentDiskRemovalRegistered(vmHref, diskName string) bool {
+	independentDiskRemovals.Lock()
+	defer independentDiskRemovals.Unlock()
+	return independentDiskRemovals.keys[independentDiskRemovalKey(vmHref, diskName)] > 0
+}
+
+// detachIndependentDiskFromOtherVms makes a disk which is not shared available to the given VM. The disk is taken
+// over from another VM only when the update of that VM, in the same apply, removes it from its 'disk' blocks.
+// Otherwise, it waits up to 'max_retry_timeout' for the disk to be detached, and then fails. Shared disks are left
+// attached to their VMs
+func detachIndependentDiskFromOtherVms(vcdClient *VCDClient, vdc *govcd.Vdc, diskHref, vmHref string) error {
+	disk, err := vdc.GetDiskByHref(diskHref)
+	if err != nil {
+		return fmt.Errorf("error retrieving disk `%s`: %s", diskHref, err)
+	}
+	if disk.Disk.SharingType == "DiskSharing" || disk.Disk.SharingType == "ControllerSharing" {
+		return nil
+	}
+
+	deadline := time.Now().Add(time.Duration(vcdClient.MaxRetryTimeout) * time.Second)
+	for {
+		attachedVmsHrefs, err := disk.GetAttachedVmsHrefs()
+		if err != nil {
+			return fmt.Errorf("error retrieving VMs attached to disk `%s`: %s", disk.Disk.Name, err)
+		}
+		var otherVmsHrefs []string
+		for _, attachedVmHref := range attachedVmsHrefs {
+			if !isVmHrefInList(attachedVmHref, []string{vmHref}) {
+				otherVmsHrefs = append(otherVmsHrefs, attachedVmHref)
+			}
+		}
+		if len(otherVmsHrefs) == 0 {
+			return nil
+		}
+
+		remainingVmHref, err := takeOverIndependentDisk(vcdClient, disk, otherVmsHrefs)
+		if err != nil {
+			return err
+		}
+		if remainingVmHref == "" {
+			return nil
+		}
+
+		if time.Now().Add(independentDiskPollInterval).After(deadline) {
+			remainingVmName := remainingVmHref
+			remainingVm, err := vcdClient.Client.GetVMByHref(remainingVmHref)
+			if err == nil {
+				remainingVmName = remainingVm.VM.Name
+			}
+			return fmt.Errorf("disk `%s` is still attached to VM `%s` after %d seconds. Remove the disk from the `disk` "+
+				"blocks of VM `%s` in the same apply to move it, or detach it from that VM first. If the update of VM `%s` "+
+				"could not run at the same time (e.g. with -parallelism=1 or 'max_concurrent_operations'), add a "+
+				"'depends_on' to this VM so that it is updated first",
+				disk.Disk.Name, remainingVmName, vcdClient.MaxRetryTimeout, remainingVmName, remainingVmName)
+		}
+		log.Printf("[DEBUG] disk `%s` is attached to VM `%s`, which is not removing it. Waiting for it to be detached",
+			disk.Disk.Name, remainingVmHref)
+		time.Sleep(independentDiskPollInterval)
+	}
+}
+
+// takeOverIndependentDisk detaches the disk from the given VMs which are removing it in the same apply. It returns
+// the HREF of a VM that is still attached to the disk and is not removing it, if any
+func takeOverIndependentDisk(vcdClient *VCDClient, disk *govcd.Disk, otherVmsHrefs []string) (string, error) {
+	// The other VMs may be detaching the disk themselves, and it is checked again once the lock is acquired
+	lockVmsForIndependentDisks(otherVmsHrefs)
+	defer unlockVmsForIndependentDisks(otherVmsHrefs)
+
+	attachedVmsHrefs, err := disk.GetAttachedVmsHrefs()
+	if err != nil {
+		return "", fmt.Errorf("error retrieving VMs attached to disk `%s`: %s", disk.Disk.Name, err)
+	}
+	remainingVmHref := ""
+	for _, otherVmHref := range otherVmsHrefs {
+		if !isVmHrefInList(otherVmHref, attachedVmsHrefs) {
+			continue
+		}
+		if !isIndependentDiskRemovalRegistered(otherVmHref, disk.Disk.Name) {
+			remainingVmHref = otherVmHref
+			continue
+		}
+		otherVm, err := vcdClient.Client.GetVMByHref(otherVmHref)
+		if err != nil {
+			return "", fmt.Errorf("error retrieving VM attached to disk `%s`: %s", disk.Disk.Name, err)
+		}
+		if types.VAppStatuses[otherVm.VM.Status] != "POWERED_OFF" && busTypesFromValues[disk.Disk.BusType] == "IDE" {
+			return "", fmt.Errorf("can not move disk `%s` of type `IDE` from VM `%s` which is not powered off",
+				disk.Disk.Name, otherVm.VM.Name)
+		}
+
+		log.Printf("[DEBUG] moving disk `%s` from VM `%s`", disk.Disk.Name, otherVm.VM.Name)
+		task, err := otherVm.DetachDisk(&types.DiskAttachOrDetachParams{Disk: &types.Reference{HREF: disk.Disk.HREF}})
+		if err != nil {
+			return "", fmt.Errorf("error detaching disk `%s` from vm `%s`: %s", disk.Disk.Name, otherVm.VM.Name, err)
+		}
+		err = task.WaitTaskCompletion()
+		if err != nil {
+			return "", fmt.Errorf("error waiting for task to complete detaching disk `%s` from vm `%s`: %s",
+				disk.Disk.Name, otherVm.VM.Name, err)
+		}
+	}
+	return remainingVmHref, nil
+}
+
+// isVmHrefInList checks whether a VM HREF is in the given list, comparing the VM IDs, as the same VM can be
+// referenced with different HREF formats
+func isVmHrefInList(vmHref string, vmHrefs []string) bool {
+	for _, href := range vmHrefs {
+		if extractUuid(href) == extractUuid(vmHref) {
+			return true
+		}
+	}
+	return false
+}
+
 func updateStateOfAttachedIndependentDisks(d *schema.ResourceData, vm govcd.VM) error {
 
 	existingDisks := getVmIndependentDisks(vm)
@@ -189,6 +189,7 @@ func resourceVdcGroup() *schema.Resource {
 // resourceVcdVdcGroupCreate covers Create functionality for resource
 func resourceVcdVdcGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
+	defer vcdClient.readCache.reset()
 
 	diagErr := isInvalidPropertySetup(d.Get("dfw_enabled").(bool),
 		d.Get("default_policy_status").(bool),
@@ -250,6 +251,7 @@ func isInvalidPropertySetup(dfw_enabled, default_policy_status, remove_default_f
 // resourceVcdVdcGroupUpdate covers Update functionality for resource
 func resourceVcdVdcGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
+	defer vcdClient.readCache.reset()
 
 	// Return immediately if only 'force_delete' value was changed as it only affects delete
 	// operation, but one must be able to update it so that it can be changed for existing resource
@@ -442,6 +444,7 @@ func setVdcGroupConfigurationData(config *types.VdcGroup, d *schema.ResourceData
 
 func resourceVcdVdcGroupDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
+	defer vcdClient.readCache.reset()
 
 	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
 	if err != nil {
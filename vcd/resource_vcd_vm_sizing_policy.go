@@ -560,6 +560,23 @@ func getVmSizingPolicy(d *schema.ResourceData, meta interface{}, policyId string
 	return []*schema.ResourceData{d}, nil
 }
 
+// computePolicyFilter returns the filter that retrieves the compute policies of the given type ("sizing", "placement"
+// or "vgpu"), skipping the ones that VCD generates automatically
+func computePolicyFilter(vcdClient *VCDClient, policyType string) (string, error) {
+	filter := "isAutoGenerated==false;" // If we don't skip the auto generated policies, we also get in the list the ones that are created and assigned to a VDC by default
+	switch policyType {
+	case "sizing":
+		filter += "isSizingOnly==true"
+	case "placement":
+		filter += fmt.Sprintf("%sisSizingOnly==false", getVgpuFilterToPrepend(vcdClient, false))
+	case "vgpu":
+		filter += getVgpuFilter(vcdClient, true)
+	default:
+		return "", fmt.Errorf("unrecognized type of policy to import: %s", policyType)
+	}
+	return filter, nil
+}
+
 func listComputePoliciesForImport(meta interface{}, origin, policyType string) ([]*schema.ResourceData, error) {
 
 	vcdClient := meta.(*VCDClient)
@@ -570,18 +587,11 @@ func listComputePoliciesForImport(meta interface{}, origin, policyType string) (
 	if err != nil {
 		logForScreen(origin, fmt.Sprintf("error writing to buffer: %s", err))
 	}
-	queryParams := url.Values{}
-	filter := "isAutoGenerated==false;" // If we don't skip the auto generated policies, we also get in the list the ones that are created and assigned to a VDC by default
-	switch policyType {
-	case "sizing":
-		filter += "isSizingOnly==true"
-	case "placement":
-		filter += fmt.Sprintf("%sisSizingOnly==false", getVgpuFilterToPrepend(vcdClient, false))
-	case "vgpu":
-		filter += getVgpuFilter(vcdClient, true)
-	default:
-		return nil, fmt.Errorf("unrecognized type of policy to import: %s", policyType)
+	filter, err := computePolicyFilter(vcdClient, policyType)
+	if err != nil {
+		return nil, err
 	}
+	queryParams := url.Values{}
 	queryParams.Add("filter", filter)
 
 	policies, err := vcdClient.VCDClient.GetAllVdcComputePoliciesV2(queryParams)
@@ -0,0 +1,326 @@
+package vcd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+const mimeCreateSnapshotParams = "application/vnd.vmware.vcloud.createSnapshotParams+xml"
+
+// createSnapshotParams is the payload used to create a snapshot of a vApp or a VM
+type createSnapshotParams struct {
+	XMLName     xml.Name `xml:"CreateSnapshotParams"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Name        string   `xml:"name,attr,omitempty"`
+	Memory      bool     `xml:"memory,attr"`
+	Quiesce     bool     `xml:"quiesce,attr"`
+	Description string   `xml:"Description,omitempty"`
+}
+
+// snapshotTarget is the vApp or VM owning a snapshot. VCD exposes the same snapshot actions for both entities
+type snapshotTarget struct {
+	id   string
+	href string
+}
+
+func resourceVcdVmSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdVmSnapshotCreate,
+		ReadContext:   resourceVcdVmSnapshotRead,
+		UpdateContext: resourceVcdVmSnapshotUpdate,
+		DeleteContext: resourceVcdVmSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdVmSnapshotImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vapp_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The vApp to snapshot. For a standalone VM, use the 'vapp_name' attribute of 'vcd_vm'",
+			},
+			"vm_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The VM to snapshot. When empty, the snapshot includes all the VMs of the vApp",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name of the snapshot",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Description of the snapshot",
+			},
+			"memory": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether to include the memory of powered on VMs in the snapshot",
+			},
+			"quiesce": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether to quiesce the file system of the VMs before taking the snapshot. Requires VMware Tools",
+			},
+			"revert_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to revert the vApp or VM to the snapshot before removing it on destroy",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation date of the snapshot",
+			},
+			"powered_on": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the snapshot was taken while the VM was powered on",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the snapshot, in bytes",
+			},
+		},
+	}
+}
+
+// lockSnapshotParents locks the parent vApp and, for VM snapshots, the parent VM.
+// It returns the function that releases both locks
+func lockSnapshotParents(vcdClient *VCDClient, d *schema.ResourceData) func() {
+	vcdClient.lockParentVapp(d)
+	if d.Get("vm_name").(string) == "" {
+		return func() {
+			vcdClient.unLockParentVapp(d)
+		}
+	}
+	vcdClient.lockParentVm(d)
+	return func() {
+		vcdClient.unLockParentVm(d)
+		vcdClient.unLockParentVapp(d)
+	}
+}
+
+func resourceVcdVmSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	unlock := lockSnapshotParents(vcdClient, d)
+	defer unlock()
+
+	target, err := getSnapshotTarget(vcdClient, d)
+	if err != nil {
+		return diag.Errorf("[vm snapshot create] %s", err)
+	}
+
+	params := &createSnapshotParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        d.Get("name").(string),
+		Memory:      d.Get("memory").(bool),
+		Quiesce:     d.Get("quiesce").(bool),
+		Description: d.Get("description").(string),
+	}
+	err = executeSnapshotAction(ctx, vcdClient, target, "createSnapshot", mimeCreateSnapshotParams, params)
+	if err != nil {
+		return diag.Errorf("[vm snapshot create] error creating snapshot of %s: %s", snapshotTargetDescription(d), err)
+	}
+
+	d.SetId(target.id)
+	return resourceVcdVmSnapshotRead(ctx, d, meta)
+}
+
+func resourceVcdVmSnapshotRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	target, err := getSnapshotTarget(vcdClient, d)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] unable to find %s. Removing snapshot from state: %s", snapshotTargetDescription(d), err)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[vm snapshot read] %s", err)
+	}
+
+	snapshot, err := getCurrentSnapshot(vcdClient, target)
+	if err != nil {
+		return diag.Errorf("[vm snapshot read] error retrieving snapshot of %s: %s", snapshotTargetDescription(d), err)
+	}
+	if snapshot == nil {
+		log.Printf("[DEBUG] %s has no snapshot. Removing it from state", snapshotTargetDescription(d))
+		d.SetId("")
+		return nil
+	}
+
+	setSnapshotData(d, snapshot)
+	d.SetId(target.id)
+	return nil
+}
+
+// resourceVcdVmSnapshotUpdate only handles "revert_on_destroy", which does not require any change in VCD
+func resourceVcdVmSnapshotUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVcdVmSnapshotRead(ctx, d, meta)
+}
+
+func resourceVcdVmSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	unlock := lockSnapshotParents(vcdClient, d)
+	defer unlock()
+
+	target, err := getSnapshotTarget(vcdClient, d)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			return nil
+		}
+		return diag.Errorf("[vm snapshot delete] %s", err)
+	}
+
+	if d.Get("revert_on_destroy").(bool) {
+		err = executeSnapshotAction(ctx, vcdClient, target, "revertToCurrentSnapshot", "", nil)
+		if err != nil {
+			return diag.Errorf("[vm snapshot delete] error reverting %s to its snapshot: %s", snapshotTargetDescription(d), err)
+		}
+	}
+
+	err = executeSnapshotAction(ctx, vcdClient, target, "removeAllSnapshots", "", nil)
+	if err != nil {
+		return diag.Errorf("[vm snapshot delete] error removing snapshot of %s: %s", snapshotTargetDescription(d), err)
+	}
+	return nil
+}
+
+// resourceVcdVmSnapshotImport imports the snapshot of a vApp or of a VM
+// Example import paths (_the_id_string_):
+// * org-name.vdc-name.vapp-name (snapshot of the whole vApp)
+// * org-name.vdc-name.vapp-name.vm-name (snapshot of a single VM)
+func resourceVcdVmSnapshotImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	if len(resourceURI) != 3 && len(resourceURI) != 4 {
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.vapp-name or org-name.vdc-name.vapp-name.vm-name")
+	}
+
+	dSet(d, "org", resourceURI[0])
+	dSet(d, "vdc", resourceURI[1])
+	dSet(d, "vapp_name", resourceURI[2])
+	if len(resourceURI) == 4 {
+		dSet(d, "vm_name", resourceURI[3])
+	}
+	// Creation options are not returned by VCD, hence they are set to their default values
+	dSet(d, "memory", false)
+	dSet(d, "quiesce", false)
+	dSet(d, "revert_on_destroy", false)
+
+	vcdClient := meta.(*VCDClient)
+	target, err := getSnapshotTarget(vcdClient, d)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := getCurrentSnapshot(vcdClient, target)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("%s has no snapshot", snapshotTargetDescription(d))
+	}
+
+	d.SetId(target.id)
+	return []*schema.ResourceData{d}, nil
+}
+
+// getSnapshotTarget retrieves the vApp or VM identified by "vapp_name" and "vm_name"
+func getSnapshotTarget(vcdClient *VCDClient, d *schema.ResourceData) (*snapshotTarget, error) {
+	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+	vapp, err := vdc.GetVAppByName(d.Get("vapp_name").(string), false)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving vApp '%s': %s", d.Get("vapp_name").(string), err)
+	}
+
+	vmName := d.Get("vm_name").(string)
+	if vmName == "" {
+		return &snapshotTarget{id: vapp.VApp.ID, href: vapp.VApp.HREF}, nil
+	}
+	vm, err := vapp.GetVMByName(vmName, false)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving VM '%s': %s", vmName, err)
+	}
+	return &snapshotTarget{id: vm.VM.ID, href: vm.VM.HREF}, nil
+}
+
+// getCurrentSnapshot returns the snapshot of the given vApp or VM, or nil if there is none
+func getCurrentSnapshot(vcdClient *VCDClient, target *snapshotTarget) (*types.SnapshotItem, error) {
+	snapshotSection := &types.SnapshotSection{}
+	_, err := vcdClient.Client.ExecuteRequest(target.href+"/snapshotSection", http.MethodGet, "",
+		"error retrieving snapshot section: %s", nil, snapshotSection)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshotSection.Snapshot) == 0 {
+		return nil, nil
+	}
+	// VCD keeps a single snapshot for each vApp or VM
+	return snapshotSection.Snapshot[0], nil
+}
+
+// executeSnapshotAction runs one of the snapshot actions of a vApp or VM and waits for its task to complete
+func executeSnapshotAction(ctx context.Context, vcdClient *VCDClient, target *snapshotTarget, action, contentType string, payload interface{}) error {
+	task, err := vcdClient.Client.ExecuteTaskRequest(target.href+"/action/"+action, http.MethodPost, contentType,
+		"error executing "+action+": %s", payload)
+	if err != nil {
+		return err
+	}
+	return waitTaskCompletionWithContext(ctx, task)
+}
+
+func setSnapshotData(d *schema.ResourceData, snapshot *types.SnapshotItem) {
+	dSet(d, "created", snapshot.Created)
+	dSet(d, "powered_on", snapshot.PoweredOn)
+	dSet(d, "size", snapshot.Size)
+}
+
+func snapshotTargetDescription(d *schema.ResourceData) string {
+	if vmName := d.Get("vm_name").(string); vmName != "" {
+		return fmt.Sprintf("VM '%s' in vApp '%s'", vmName, d.Get("vapp_name").(string))
+	}
+	return fmt.Sprintf("vApp '%s'", d.Get("vapp_name").(string))
+}
@@ -0,0 +1,107 @@
+//go:build vapp || vm || ALL || functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdVmSnapshot(t *testing.T) {
+	preTestChecks(t)
+
+	vappName := t.Name() + "-vapp"
+	vmName := t.Name() + "-vm"
+	var params = StringMap{
+		"Org":      testConfig.VCD.Org,
+		"Vdc":      testConfig.Nsxt.Vdc,
+		"VappName": vappName,
+		"VmName":   vmName,
+		"FuncName": t.Name(),
+		"Tags":     "vapp vm",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdVmSnapshot, params)
+	params["FuncName"] = t.Name() + "-DS"
+	configTextDS := templateFill(testAccVcdVmSnapshot+testAccVcdVmSnapshotDS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configTextDS)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vm_snapshot.snap"
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "vcd_vapp_vm.vm", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "created"),
+					resource.TestCheckResourceAttr(resourceName, "powered_on", "false"),
+				),
+			},
+			{
+				Config: configTextDS,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vcd_vm_snapshot.snap", "exists", "true"),
+					resourceFieldsEqual(resourceName, "data.vcd_vm_snapshot.snap", []string{"name", "description", "memory", "quiesce", "revert_on_destroy", "%"}),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdVappObject(vappName, vmName, testConfig.Nsxt.Vdc),
+				ImportStateVerifyIgnore: []string{"name", "description"},
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdVmSnapshot = `
+resource "vcd_vapp" "vapp" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VappName}}"
+}
+
+resource "vcd_vapp_vm" "vm" {
+  org       = "{{.Org}}"
+  vdc       = "{{.Vdc}}"
+  vapp_name = vcd_vapp.vapp.name
+  name      = "{{.VmName}}"
+
+  computer_name    = "snapshot-vm"
+  power_on         = false
+  memory           = 1024
+  cpus             = 1
+  cpu_cores        = 1
+  os_type          = "sles10_64Guest"
+  hardware_version = "vmx-14"
+}
+
+resource "vcd_vm_snapshot" "snap" {
+  org       = "{{.Org}}"
+  vdc       = "{{.Vdc}}"
+  vapp_name = vcd_vapp_vm.vm.vapp_name
+  vm_name   = vcd_vapp_vm.vm.name
+
+  name        = "{{.FuncName}}"
+  description = "snapshot taken by {{.FuncName}}"
+}
+`
+
+const testAccVcdVmSnapshotDS = `
+data "vcd_vm_snapshot" "snap" {
+  org       = "{{.Org}}"
+  vdc       = "{{.Vdc}}"
+  vapp_name = vcd_vm_snapshot.snap.vapp_name
+  vm_name   = vcd_vm_snapshot.snap.vm_name
+}
+`
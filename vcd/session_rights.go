@@ -0,0 +1,180 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// currentSession contains the identity and the effective rights of the principal (user, API token or service
+// account) authenticated by the provider
+type currentSession struct {
+	UserName       string
+	UserId         string
+	OrgName        string
+	OrgId          string
+	ConnectionType string
+	IsSysAdmin     bool
+	Roles          []string
+	Rights         map[string]bool
+}
+
+// sessionRightsCache keeps the current session once it is loaded, as it doesn't change until the client
+// authenticates again. A failed load is not kept, and is retried at the next use
+type sessionRightsCache struct {
+	sync.Mutex
+	session *currentSession
+	// availableRights contains all the rights of this VCD. It is nil when the rights can't be listed
+	availableRights map[string]bool
+}
+
+// getCurrentSession returns the identity and the rights of the authenticated principal. The rights are the union of
+// the rights of all its roles
+func getCurrentSession(vcdClient *VCDClient) (*currentSession, error) {
+	sessionInfo, err := vcdClient.Client.GetSessionInfo()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving session information: %s", err)
+	}
+	session := &currentSession{
+		UserName:   sessionInfo.User.Name,
+		UserId:     sessionInfo.User.ID,
+		OrgName:    sessionInfo.Org.Name,
+		OrgId:      sessionInfo.Org.ID,
+		IsSysAdmin: vcdClient.Client.IsSysAdmin,
+		Roles:      sessionInfo.Roles,
+		Rights:     make(map[string]bool),
+	}
+	switch {
+	case vcdClient.Client.UsingBearerToken:
+		session.ConnectionType = "bearer_token"
+	case vcdClient.Client.UsingAccessToken:
+		session.ConnectionType = "api_token"
+	default:
+		session.ConnectionType = "password"
+	}
+
+	if len(sessionInfo.RoleRefs) == 0 {
+		return session, nil
+	}
+	adminOrg, err := vcdClient.GetAdminOrgById(sessionInfo.Org.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving Org %s of the session: %s", sessionInfo.Org.Name, err)
+	}
+	for _, roleRef := range sessionInfo.RoleRefs {
+		role, err := adminOrg.GetRoleById(roleRef.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving role %s of the session: %s", roleRef.Name, err)
+		}
+		rights, err := role.GetRights(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving rights of role %s: %s", roleRef.Name, err)
+		}
+		for _, right := range rights {
+			session.Rights[right.Name] = true
+		}
+	}
+	return session, nil
+}
+
+// getCachedSession returns the current session, loading it at the first successful call
+func (cli *VCDClient) getCachedSession() (*currentSession, map[string]bool, error) {
+	cache := cli.sessionRights
+	cache.Lock()
+	defer cache.Unlock()
+	if cache.session != nil {
+		return cache.session, cache.availableRights, nil
+	}
+	session, err := getCurrentSession(cli)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Rights missing from this VCD can't be granted, and are not reported. Tenants may not be allowed to list them
+	allRights, err := cli.Client.GetAllRights(nil)
+	if err != nil {
+		debugPrintf("[preflight rights check] unable to list the available rights: %s\n", err)
+	} else {
+		cache.availableRights = make(map[string]bool, len(allRights))
+		for _, right := range allRights {
+			cache.availableRights[right.Name] = true
+		}
+	}
+	cache.session = session
+	return cache.session, cache.availableRights, nil
+}
+
+// getMissingRights returns the rights needed to manage the given resource type that are not in the session rights.
+// Rights that are not available in VCD are ignored, unless availableRights is nil
+func getMissingRights(resourceType string, sessionRights, availableRights map[string]bool) []string {
+	typeRights, found := rightsByResourceType[resourceType]
+	if !found || typeRights.manage == nil {
+		return nil
+	}
+	missing := make(map[string]bool)
+	for _, right := range append(append([]string{}, typeRights.view...), typeRights.manage...) {
+		if sessionRights[right] || (availableRights != nil && !availableRights[right]) {
+			continue
+		}
+		missing[right] = true
+	}
+	return sortedKeys(missing)
+}
+
+// checkResourceRights returns an error when the current session doesn't have the rights needed to manage the
+// given resource type. When the rights available in VCD can't be listed, the missing rights may not exist in this
+// VCD version, and they are only logged as a warning. System administrators are not checked
+func (cli *VCDClient) checkResourceRights(resourceType string) error {
+	if cli.Client.IsSysAdmin {
+		return nil
+	}
+	session, availableRights, err := cli.getCachedSession()
+	if err != nil {
+		return fmt.Errorf("[preflight rights check] unable to determine the rights of the current session: %s", err)
+	}
+	missing := getMissingRights(resourceType, session.Rights, availableRights)
+	if len(missing) == 0 {
+		return nil
+	}
+	if availableRights == nil {
+		log.Printf("[WARN] [preflight rights check] user '%s' of Org '%s' may lack the following rights needed by %s, "+
+			"unless they are not available in this VCD:\n\"%s\"",
+			session.UserName, session.OrgName, resourceType, strings.Join(missing, "\",\n\""))
+		return nil
+	}
+	return fmt.Errorf("[preflight rights check] user '%s' of Org '%s' lacks the following rights needed by %s:\n\"%s\"",
+		session.UserName, session.OrgName, resourceType, strings.Join(missing, "\",\n\""))
+}
+
+// checkRightsOnPlan returns a copy of the given resources that, when the provider 'preflight_rights_check' is set,
+// fail the plan of a creation or update if the current session lacks the rights needed by the resource
+func checkRightsOnPlan(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	checkedResources := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		checkedResource := *resource
+		checkedResource.CustomizeDiff = withRightsCheck(name, resource.CustomizeDiff)
+		checkedResources[name] = &checkedResource
+	}
+	return checkedResources
+}
+
+func withRightsCheck(resourceType string, customizeDiff schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if customizeDiff != nil {
+			err := customizeDiff(ctx, d, meta)
+			if err != nil {
+				return err
+			}
+		}
+		vcdClient, ok := meta.(*VCDClient)
+		if !ok || !vcdClient.preflightRightsCheck {
+			return nil
+		}
+		if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+			return nil
+		}
+		return vcdClient.checkResourceRights(resourceType)
+	}
+}
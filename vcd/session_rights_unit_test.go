@@ -0,0 +1,138 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func Test_getMissingRights(t *testing.T) {
+	sessionRights := map[string]bool{
+		rightGatewayView:                          true,
+		"Organization vDC Gateway: View NAT":      true,
+		"Organization vDC Gateway: Configure NAT": true,
+	}
+
+	missing := getMissingRights("vcd_nsxt_nat_rule", sessionRights, nil)
+	if len(missing) != 0 {
+		t.Errorf("expected no missing rights, got %v", missing)
+	}
+
+	missing = getMissingRights("vcd_catalog", sessionRights, nil)
+	wantMissing := make(map[string]bool)
+	for _, right := range append(append([]string{}, rightsByResourceType["vcd_catalog"].view...), rightsByResourceType["vcd_catalog"].manage...) {
+		wantMissing[right] = true
+	}
+	if !reflect.DeepEqual(missing, sortedKeys(wantMissing)) {
+		t.Errorf("expected missing rights %v, got %v", sortedKeys(wantMissing), missing)
+	}
+
+	// Rights that are not available in VCD are not reported
+	missing = getMissingRights("vcd_catalog", sessionRights, map[string]bool{rightCatalogView: true})
+	if !reflect.DeepEqual(missing, []string{rightCatalogView}) {
+		t.Errorf("expected only the available rights to be missing, got %v", missing)
+	}
+
+	// Resource types without known rights are never checked
+	for _, resourceType := range []string{"vcd_not_a_resource", "vcd_nsxt_edgegateway"} {
+		missing = getMissingRights(resourceType, nil, nil)
+		if len(missing) != 0 {
+			t.Errorf("expected no missing rights for %s, got %v", resourceType, missing)
+		}
+	}
+}
+
+func Test_checkResourceRights(t *testing.T) {
+	cache := &sessionRightsCache{
+		session: &currentSession{
+			UserName: "test-user",
+			OrgName:  "test-org",
+			Rights:   map[string]bool{rightGatewayView: true},
+		},
+		availableRights: map[string]bool{
+			rightGatewayView:                          true,
+			"Organization vDC Gateway: View NAT":      true,
+			"Organization vDC Gateway: Configure NAT": true,
+		},
+	}
+	cli := &VCDClient{VCDClient: &govcd.VCDClient{}, sessionRights: cache}
+
+	err := cli.checkResourceRights("vcd_nsxt_nat_rule")
+	if err == nil {
+		t.Fatalf("expected error for missing rights")
+	}
+	if !strings.Contains(err.Error(), "Organization vDC Gateway: Configure NAT") || !strings.Contains(err.Error(), "test-user") {
+		t.Errorf("unexpected error message: %s", err)
+	}
+
+	// When the available rights are unknown, the missing rights are only a warning
+	cache.availableRights = nil
+	err = cli.checkResourceRights("vcd_nsxt_nat_rule")
+	if err != nil {
+		t.Errorf("expected no error when the available rights are unknown, got %s", err)
+	}
+
+	cli.Client.IsSysAdmin = true
+	err = cli.checkResourceRights("vcd_nsxt_nat_rule")
+	if err != nil {
+		t.Errorf("expected no check for System administrators, got %s", err)
+	}
+}
+
+func Test_checkRightsOnPlan(t *testing.T) {
+	checkedResources := checkRightsOnPlan(globalResourceMap)
+	for name, resource := range checkedResources {
+		if resource.CustomizeDiff == nil {
+			t.Errorf("%s has no rights check", name)
+		}
+		if globalResourceMap[name] == resource {
+			t.Errorf("%s was not copied", name)
+		}
+	}
+}
+
+// Test_getCachedSessionRetry checks that a failure to load the session is not kept, and that the next use retries
+func Test_getCachedSessionRetry(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/versions") {
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<SupportedVersions><VersionInfo><Version>37.0</Version></VersionInfo></SupportedVersions>`))
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL + "/api")
+	if err != nil {
+		t.Fatalf("error parsing server URL: %s", err)
+	}
+	vcdClient := govcd.NewVCDClient(*serverUrl, true)
+	vcdClient.Client.APIVersion = "37.0"
+	// Loads the supported versions
+	vcdClient.Client.APIVCDMaxVersionIs(">= 37.0")
+	cli := &VCDClient{VCDClient: vcdClient, sessionRights: &sessionRightsCache{}}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		_, _, err = cli.getCachedSession()
+		if err == nil {
+			t.Fatalf("attempt %d: expected an error retrieving the session", attempt)
+		}
+		if got := atomic.LoadInt32(&requests); got < int32(attempt) {
+			t.Fatalf("attempt %d: expected the session to be retrieved again, got %d requests", attempt, got)
+		}
+	}
+	if cli.sessionRights.session != nil {
+		t.Errorf("expected no cached session after a failure")
+	}
+}
@@ -105,6 +105,29 @@ func convertStringsToTypeSet(param []string) *schema.Set {
 	return set
 }
 
+// convertSchemaSetToSliceOfInts accepts Terraform's *schema.Set object and converts it to slice of ints.
+// This is useful for extracting values from a set of ints
+func convertSchemaSetToSliceOfInts(param *schema.Set) []int {
+	paramList := param.List()
+	result := make([]int, len(paramList))
+	for index, value := range paramList {
+		result[index] = value.(int)
+	}
+
+	return result
+}
+
+// convertIntsToTypeSet accepts a slice of ints and returns a *schema.Set suitable for storing in Terraform
+// set of ints
+func convertIntsToTypeSet(param []int) *schema.Set {
+	sliceOfInterfaces := make([]interface{}, len(param))
+	for index, value := range param {
+		sliceOfInterfaces[index] = value
+	}
+
+	return schema.NewSet(schema.HashSchema(&schema.Schema{Type: schema.TypeInt}), sliceOfInterfaces)
+}
+
 // addrOf is a generic function to return the address of a variable
 // Note. It is mainly meant for converting literal values to pointers (e.g. `addrOf(true)`) or cases
 // for converting variables coming out straight from Terraform schema (e.g.